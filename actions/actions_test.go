@@ -0,0 +1,303 @@
+package actions
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+)
+
+var (
+	// testDB is the migrated test database, it's nil when the database can't be used
+	testDB *pop.Connection
+	// testDBUnavailable is why the test database couldn't be reached, tests that need it are skipped
+	testDBUnavailable error
+	// testDBFailure is why the test database couldn't be set up, tests that need it fail
+	testDBFailure error
+)
+
+func TestMain(m *testing.M) {
+	// The CSRF middleware lets requests through in the test environment, as with buffalo test
+	envy.Set("GO_ENV", "test")
+	openTestDB()
+	os.Exit(m.Run())
+}
+
+// openTestDB connects to and migrates the test database. The app wraps every request in a
+// transaction on models.DB, so it has to point at the test database before App() is built.
+func openTestDB() {
+	db, err := pop.Connect("test")
+	if err != nil {
+		testDBFailure = err
+		return
+	}
+	if err := db.RawQuery("SELECT 1").Exec(); err != nil {
+		testDBUnavailable = err
+		return
+	}
+	migrator, err := pop.NewFileMigrator("../migrations", db)
+	if err != nil {
+		testDBFailure = err
+		return
+	}
+	if err := migrator.Up(); err != nil {
+		testDBFailure = err
+		return
+	}
+	testDB = db
+	models.DB = db
+}
+
+// requireTestDB returns the test database. The test is skipped when it isn't reachable.
+func requireTestDB(t *testing.T) *pop.Connection {
+	t.Helper()
+	if testDBUnavailable != nil {
+		t.Skipf("test database unavailable: %v", testDBUnavailable)
+	}
+	if testDBFailure != nil {
+		t.Fatal(testDBFailure)
+	}
+	return testDB
+}
+
+// testTx returns a transaction on the test database that is rolled back when the test ends
+func testTx(t *testing.T) *pop.Connection {
+	t.Helper()
+	tx, err := requireTestDB(t).NewTransaction()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		tx.TX.Rollback()
+	})
+	return tx
+}
+
+// createTestUser creates a verified account with the given role and the password "Test-Passw0rd!"
+func createTestUser(t *testing.T, tx *pop.Connection, role string) *models.User {
+	t.Helper()
+	user := &models.User{
+		Email:                fmt.Sprintf("%s-%s@example.com", role, uuid.Must(uuid.NewV4())),
+		Name:                 "Test " + role,
+		Role:                 role,
+		Password:             "Test-Passw0rd!",
+		PasswordConfirmation: "Test-Passw0rd!",
+		EmailVerifiedAt:      nulls.NewTime(time.Now()),
+	}
+	verrs, err := user.Create(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+	deleteAfterTest(t, tx, "users", user.ID)
+	return user
+}
+
+// createTestHackathon creates a hackathon of owner with the given status and dates
+func createTestHackathon(t *testing.T, tx *pop.Connection, owner *models.User, status string, start, end time.Time) *models.Hackathon {
+	t.Helper()
+	hackathon := &models.Hackathon{
+		Title:        fmt.Sprintf("Test %s hackathon", status),
+		Description:  "Created by the tests",
+		StartDate:    start,
+		EndDate:      end,
+		Status:       status,
+		OwnerID:      owner.ID,
+		VotesPerUser: models.DefaultVotesPerUser,
+	}
+	verrs, err := tx.ValidateAndCreate(hackathon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+	return hackathon
+}
+
+// deleteAfterTest deletes a row created outside of a test transaction when the test ends. Deleting
+// a user deletes everything the user owns.
+func deleteAfterTest(t *testing.T, db *pop.Connection, table string, id interface{}) {
+	if db.TX != nil {
+		return
+	}
+	t.Cleanup(func() {
+		if err := db.RawQuery(fmt.Sprintf("DELETE FROM %s WHERE id = ?", table), id).Exec(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// createTestRow validates and creates a row
+func createTestRow(t *testing.T, tx *pop.Connection, row interface{}) {
+	t.Helper()
+	verrs, err := tx.ValidateAndCreate(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+}
+
+// createTestDomainRule adds an active allowed, or blocked, email domain rule
+func createTestDomainRule(t *testing.T, tx *pop.Connection, domain string, blocked bool) *models.CompanyAllowedDomain {
+	t.Helper()
+	rule := &models.CompanyAllowedDomain{Domain: domain, IsActive: true, IsBlocked: blocked}
+	verrs, err := tx.ValidateAndCreate(rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+	deleteAfterTest(t, tx, "company_allowed_domains", rule.ID)
+	return rule
+}
+
+// createTestAPIToken issues an API token with the given scopes to a user and returns it
+func createTestAPIToken(t *testing.T, tx *pop.Connection, user *models.User, scopes string) (*models.APIToken, string) {
+	t.Helper()
+	token := uuid.Must(uuid.NewV4()).String()
+	apiToken := &models.APIToken{
+		UserID:    user.ID,
+		Name:      "Test token",
+		Scopes:    scopes,
+		TokenHash: models.HashAPIToken(token),
+	}
+	verrs, err := tx.ValidateAndCreate(apiToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+	return apiToken, token
+}
+
+// setTestConfig changes the company configuration for the rest of the test
+func setTestConfig(t *testing.T, tx *pop.Connection, change func(*models.CompanyConfiguration)) {
+	t.Helper()
+	config := &models.CompanyConfiguration{}
+	if err := tx.First(config); err != nil {
+		t.Fatal(err)
+	}
+	original := *config
+	change(config)
+	if err := tx.Update(config); err != nil {
+		t.Fatal(err)
+	}
+	if tx.TX == nil {
+		t.Cleanup(func() {
+			if err := tx.Update(&original); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// testResponse is what the app answered a request of a testBrowser
+type testResponse struct {
+	Code     int
+	Location string
+	Body     string
+}
+
+// testBrowser sends requests to the app like a browser, keeping its cookies. It doesn't
+// follow redirects so tests can check where they point.
+type testBrowser struct {
+	t      *testing.T
+	server *httptest.Server
+	client *http.Client
+}
+
+func newTestBrowser(t *testing.T) *testBrowser {
+	t.Helper()
+	requireTestDB(t)
+	server := httptest.NewServer(App())
+	t.Cleanup(server.Close)
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{
+		Jar: jar,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return &testBrowser{t: t, server: server, client: client}
+}
+
+func (b *testBrowser) do(req *http.Request) testResponse {
+	b.t.Helper()
+	res, err := b.client.Do(req)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	return testResponse{Code: res.StatusCode, Location: res.Header.Get("Location"), Body: string(body)}
+}
+
+// get requests a path of the app
+func (b *testBrowser) get(path string) testResponse {
+	b.t.Helper()
+	req, err := http.NewRequest(http.MethodGet, b.server.URL+path, nil)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	return b.do(req)
+}
+
+// post submits a form to a path of the app
+func (b *testBrowser) post(path string, form url.Values) testResponse {
+	b.t.Helper()
+	req, err := http.NewRequest(http.MethodPost, b.server.URL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return b.do(req)
+}
+
+// api sends a JSON API request authenticated with a bearer token
+func (b *testBrowser) api(method, path, token string, body io.Reader) testResponse {
+	b.t.Helper()
+	req, err := http.NewRequest(method, b.server.URL+apiBasePath+path, body)
+	if err != nil {
+		b.t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return b.do(req)
+}
+
+// signIn signs in with the password of createTestUser
+func (b *testBrowser) signIn(user *models.User) {
+	b.t.Helper()
+	res := b.post("/signin", url.Values{"Email": {user.Email}, "Password": {"Test-Passw0rd!"}})
+	if res.Code != http.StatusFound || res.Location != "/" {
+		b.t.Fatalf("signing in as %s answered %d to %q", user.Email, res.Code, res.Location)
+	}
+}
@@ -2,17 +2,22 @@ package actions
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/arxdsilva/hackathon/models"
 
 	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gofrs/uuid"
 )
 
-// logAuditEvent logs a user action to the audit_logs table
+// logAuditEvent logs a user action to the audit_logs table.
+// Background jobs pass a nil context and a nil userID to record system actions.
 func logAuditEvent(tx *pop.Connection, c buffalo.Context, userID *uuid.UUID, action, resourceType string, resourceID interface{}, details string) {
 	var resourceIDStr *string
 	if resourceID != nil {
@@ -33,6 +38,8 @@ func logAuditEvent(tx *pop.Connection, c buffalo.Context, userID *uuid.UUID, act
 		case int:
 			str := fmt.Sprintf("%d", v)
 			resourceIDStr = &str
+		case *string:
+			resourceIDStr = v
 		case string:
 			resourceIDStr = &v
 		}
@@ -44,13 +51,20 @@ func logAuditEvent(tx *pop.Connection, c buffalo.Context, userID *uuid.UUID, act
 		ResourceType: resourceType,
 		ResourceID:   resourceIDStr,
 		Details:      details,
-		IPAddress:    c.Request().RemoteAddr,
-		UserAgent:    c.Request().UserAgent(),
+		UserAgent:    "system",
+	}
+	if c != nil {
+		auditLog.IPAddress = c.Request().RemoteAddr
+		auditLog.UserAgent = c.Request().UserAgent()
 	}
 
 	// Don't fail the main operation if audit logging fails
 	if err := tx.Create(auditLog); err != nil {
-		c.Logger().Errorf("Failed to create audit log: %v", err)
+		if c != nil {
+			c.Logger().Errorf("Failed to create audit log: %v", err)
+		} else {
+			log.Printf("Failed to create audit log: %v", err)
+		}
 	}
 }
 
@@ -85,6 +99,11 @@ func (a *MyApp) AdminIndex(c buffalo.Context) error {
 		return err
 	}
 
+	pendingReviewCount, err := repoManager.ProjectCountPendingReview()
+	if err != nil {
+		return err
+	}
+
 	// Get recent users
 	recentUsers, err := repoManager.UserGetRecent(5)
 	if err != nil {
@@ -115,6 +134,7 @@ func (a *MyApp) AdminIndex(c buffalo.Context) error {
 		"projects":           projectCount,
 		"activeProjects":     activeProjectCount,
 		"presentingProjects": presentingProjectCount,
+		"pendingReview":      pendingReviewCount,
 	})
 	c.Set("recentUsers", recentUsers)
 	c.Set("recentHackathons", recentHackathons)
@@ -172,8 +192,14 @@ func (a *MyApp) AdminUsersShow(c buffalo.Context) error {
 		return err
 	}
 
+	sessionCount, err := repoManager.UserSessionCountByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
 	c.Set("user", user)
 	c.Set("projects", projects)
+	c.Set("sessionCount", sessionCount)
 
 	c.Set("pageTitle", "User Details")
 	return c.Render(http.StatusOK, r.HTML("admin/users/show.plush.html", "admin/layout.plush.html"))
@@ -209,8 +235,11 @@ func (a *MyApp) AdminUsersUpdate(c buffalo.Context) error {
 	// Handle checkbox for force password reset (unchecked checkboxes don't send values)
 	user.ForcePasswordReset = c.Param("ForcePasswordReset") == "true"
 
+	// The role isn't bound with the rest of the form
+	user.Role = c.Param("Role")
+
 	// Validate role
-	if user.Role != models.RoleOwner && user.Role != models.RoleHacker {
+	if !models.IsValidRole(user.Role) {
 		c.Flash().Add("danger", "Invalid role specified")
 		return c.Redirect(http.StatusFound, c.Request().Referer())
 	}
@@ -243,6 +272,10 @@ func (a *MyApp) AdminUsersDestroy(c buffalo.Context) error {
 		return c.Redirect(http.StatusFound, "/admin/users")
 	}
 
+	if err := a.Repository(tx).UserSessionDeleteByUserID(user.ID); err != nil {
+		return err
+	}
+
 	if err := tx.Destroy(user); err != nil {
 		c.Logger().Errorf("Failed to delete user: %v", err)
 		c.Flash().Add("danger", "Failed to delete user")
@@ -258,6 +291,50 @@ func (a *MyApp) AdminUsersDestroy(c buffalo.Context) error {
 	return c.Redirect(http.StatusFound, "/admin/users")
 }
 
+// AdminUsersTwoFactorDestroy resets a user's two-factor authentication so they can enroll again
+func (a *MyApp) AdminUsersTwoFactorDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	user, err := repoManager.UserFindByID(c.Param("user_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := clearTwoFactor(tx, repoManager, user); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "reset_two_factor", "user", &user.ID, fmt.Sprintf("Reset two-factor authentication for %s (%s)", user.Name, user.Email))
+
+	c.Flash().Add("success", "Two-factor authentication has been reset. The user will need to enroll again.")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/users/%s", user.ID))
+}
+
+// AdminUsersVerifyEmail marks a user's email address as verified on their behalf
+func (a *MyApp) AdminUsersVerifyEmail(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	user, err := repoManager.UserFindByID(c.Param("user_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if !user.IsEmailVerified() {
+		user.EmailVerifiedAt = nulls.NewTime(time.Now())
+		if err := tx.UpdateColumns(user, "email_verified_at", "updated_at"); err != nil {
+			return err
+		}
+		logAuditEvent(tx, c, &currentUser.ID, "admin_verify_email", "user", &user.ID, fmt.Sprintf("Marked email as verified for %s (%s)", user.Name, user.Email))
+	}
+
+	c.Flash().Add("success", "Email address marked as verified")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/users/%s", user.ID))
+}
+
 // AdminUsersNew renders the form to create a new user
 func (a *MyApp) AdminUsersNew(c buffalo.Context) error {
 	c.Set("user", models.User{})
@@ -275,15 +352,17 @@ func (a *MyApp) AdminUsersCreate(c buffalo.Context) error {
 		return c.Redirect(http.StatusFound, "/admin/users/new")
 	}
 
-	// Set default role if not provided
-	if u.Role == "" {
+	// The role isn't bound with the rest of the form, hacker is the default
+	u.Role = c.Param("Role")
+	if !models.IsValidRole(u.Role) {
 		u.Role = models.RoleHacker
 	}
 
 	// Debug logging
 	c.Logger().Infof("Attempting to create user: Email=%s, Name=%s, Role=%s", u.Email, u.Name, u.Role)
 
-	// Skip domain validation for admin-created users
+	// Skip domain and email verification for admin-created users
+	u.EmailVerifiedAt = nulls.NewTime(time.Now())
 	verrs, err := tx.ValidateAndCreate(u)
 	if err != nil {
 		c.Flash().Add("danger", "Could not create user")
@@ -356,7 +435,13 @@ func (a *MyApp) AdminProjectsIndex(c buffalo.Context) error {
 		return err
 	}
 
+	pendingProjects, err := a.Repository(tx).ProjectFindPendingReview()
+	if err != nil {
+		return err
+	}
+
 	c.Set("projects", projects)
+	c.Set("pendingProjects", pendingProjects)
 	c.Set("pagination", q.Paginator)
 	c.Set("search", c.Param("search"))
 	c.Set("statusFilter", c.Param("status"))
@@ -365,6 +450,58 @@ func (a *MyApp) AdminProjectsIndex(c buffalo.Context) error {
 	return c.Render(http.StatusOK, r.HTML("admin/projects/index.plush.html", "admin/layout.plush.html"))
 }
 
+// AdminProjectsApprove makes a project waiting for review visible to everyone
+func (a *MyApp) AdminProjectsApprove(c buffalo.Context) error {
+	return a.reviewProject(c, models.ProjectReviewApproved)
+}
+
+// AdminProjectsReject turns down a project waiting for review, with a reason for the submitter
+func (a *MyApp) AdminProjectsReject(c buffalo.Context) error {
+	return a.reviewProject(c, models.ProjectReviewRejected)
+}
+
+// reviewProject records an organizer's decision on a project waiting for review
+func (a *MyApp) reviewProject(c buffalo.Context, decision string) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := a.Repository(tx).ProjectFindByID(c.Param("project_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if !project.IsPendingReview() {
+		c.Flash().Add("warning", "This project has already been reviewed.")
+		return c.Redirect(http.StatusSeeOther, "/admin/projects")
+	}
+
+	reason := strings.TrimSpace(c.Param("reason"))
+	if decision == models.ProjectReviewRejected && reason == "" {
+		c.Flash().Add("danger", "Please tell the team why their project was rejected.")
+		return c.Redirect(http.StatusSeeOther, "/admin/projects")
+	}
+
+	project.ReviewStatus = decision
+	project.ReviewReason = nulls.String{}
+	if reason != "" {
+		project.ReviewReason = nulls.NewString(reason)
+	}
+	project.ReviewedByID = nulls.NewUUID(currentUser.ID)
+	project.ReviewedAt = nulls.NewTime(time.Now())
+	if err := tx.UpdateColumns(project, "review_status", "review_reason", "reviewed_by_id", "reviewed_at", "updated_at"); err != nil {
+		return err
+	}
+
+	if decision == models.ProjectReviewApproved {
+		logAuditEvent(tx, c, &currentUser.ID, "approve_project", "project", project.ID, fmt.Sprintf("Project approved: %s", project.Name))
+		c.Flash().Add("success", fmt.Sprintf("%s is now visible to everyone.", project.Name))
+	} else {
+		logAuditEvent(tx, c, &currentUser.ID, "reject_project", "project", project.ID, fmt.Sprintf("Project rejected: %s (%s)", project.Name, reason))
+		c.Flash().Add("success", fmt.Sprintf("%s was rejected.", project.Name))
+	}
+	return c.Redirect(http.StatusSeeOther, "/admin/projects")
+}
+
 // AdminEmailsIndex manages allowed email domains
 func (a *MyApp) AdminEmailsIndex(c buffalo.Context) error {
 	c.Set("pageTitle", "Email Domains Management")
@@ -381,6 +518,7 @@ func (a *MyApp) AdminConfigIndex(c buffalo.Context) error {
 	}
 
 	c.Set("config", config)
+	c.Set("oidcRedirectURL", strings.TrimSuffix(a.Options.Host, "/")+oidcCallbackPath)
 	c.Set("pageTitle", "Company Configuration")
 	return c.Render(http.StatusOK, r.HTML("admin/config/index.plush.html", "admin/layout.plush.html"))
 }
@@ -395,6 +533,10 @@ func bindConfigBooleans(c buffalo.Context, config *models.CompanyConfiguration)
 	config.PasswordRequireNumbers = c.Param("password_require_numbers") == "true"
 	config.PasswordRequireSpecialChars = c.Param("password_require_special_chars") == "true"
 	config.TwoFactorRequired = c.Param("two_factor_required") == "true"
+	config.OIDCEnabled = c.Param("oidc_enabled") == "true"
+	config.PasswordLoginDisabled = c.Param("password_login_disabled") == "true"
+	config.LDAPEnabled = c.Param("ldap_enabled") == "true"
+	config.LDAPStartTLS = c.Param("ldap_start_tls") == "true"
 	config.FileUploadsEnabled = c.Param("file_uploads_enabled") == "true"
 	config.ProjectImagesEnabled = c.Param("project_images_enabled") == "true"
 	config.TeamFormationEnabled = c.Param("team_formation_enabled") == "true"
@@ -469,7 +611,16 @@ func (a *MyApp) AdminPasswordsIndex(c buffalo.Context) error {
 		return err
 	}
 
+	lockedAccounts := &models.LockedAccounts{}
+	if config.LoginLockoutThreshold > 0 {
+		lockedAccounts, err = a.Repository(tx).LoginAttemptFindLocked(time.Now().Add(-config.LoginLockoutDuration()), config.LoginLockoutThreshold)
+		if err != nil {
+			return err
+		}
+	}
+
 	c.Set("config", config)
+	c.Set("lockedAccounts", lockedAccounts)
 	c.Set("pageTitle", "Password Reset Management")
 	return c.Render(http.StatusOK, r.HTML("admin/passwords/index.plush.html", "admin/layout.plush.html"))
 }
@@ -517,6 +668,11 @@ func (a *MyApp) AdminPasswordsForceReset(c buffalo.Context) error {
 		return c.Error(http.StatusInternalServerError, err)
 	}
 
+	// Sign the user out so the reset applies right away
+	if err := a.Repository(tx).UserSessionDeleteByUserID(user.ID); err != nil {
+		return c.Error(http.StatusInternalServerError, err)
+	}
+
 	// Log the action
 	currentUser := c.Value("current_user").(models.User)
 	logAuditEvent(tx, c, &currentUser.ID, "force_password_reset", "user", &user.ID, fmt.Sprintf("Admin forced password reset for user %s (%s)", user.Name, user.Email))
@@ -578,6 +734,25 @@ func (a *MyApp) AdminDomainsIndex(c buffalo.Context) error {
 		return err
 	}
 
+	// The test tool checks an address the same way self-registration does
+	if address := strings.TrimSpace(c.Param("test_address")); address != "" {
+		config, err := models.GetDefaultConfig(tx)
+		if err != nil {
+			return err
+		}
+		domain := models.EmailDomain(address)
+		if domain == "" {
+			domain = models.NormalizeDomain(address)
+		}
+		check, err := repoManager.CompanyAllowedDomainCheckDomain(domain, config.AllowPublicRegistration)
+		if err != nil {
+			return err
+		}
+		c.Set("testAddress", address)
+		c.Set("domainCheck", check)
+		c.Set("publicRegistration", config.AllowPublicRegistration)
+	}
+
 	c.Set("domains", domains)
 	c.Set("pageTitle", "Allowed Domains Management")
 	return c.Render(http.StatusOK, r.HTML("admin/domains/index.plush.html", "admin/layout.plush.html"))
@@ -606,6 +781,8 @@ func (a *MyApp) AdminDomainsCreate(c buffalo.Context) error {
 	if domain.IsActive == false {
 		domain.IsActive = true // Default to active
 	}
+	domain.Domain = models.NormalizeDomain(domain.Domain)
+	domain.IsBlocked = c.Param("is_blocked") == "true"
 
 	verrs, err := tx.ValidateAndCreate(domain)
 	if err != nil {
@@ -637,6 +814,8 @@ func (a *MyApp) AdminDomainsUpdate(c buffalo.Context) error {
 		c.Flash().Add("danger", "Unable to read form input")
 		return c.Redirect(http.StatusFound, "/admin/domains")
 	}
+	domain.Domain = models.NormalizeDomain(domain.Domain)
+	domain.IsBlocked = c.Param("is_blocked") == "true"
 
 	verrs, err := tx.ValidateAndUpdate(domain)
 	if err != nil {
@@ -725,3 +904,325 @@ func (a *MyApp) AdminAuditLogsIndex(c buffalo.Context) error {
 	c.Set("pageTitle", "Audit Logs")
 	return c.Render(http.StatusOK, r.HTML("admin/audit_logs/index.plush.html", "admin/layout.plush.html"))
 }
+
+// renderAdminSCIM renders the SCIM provisioning page, with a token that was just created when there is one
+func (a *MyApp) renderAdminSCIM(c buffalo.Context, tx *pop.Connection, newToken string) error {
+	tokens, err := a.Repository(tx).SCIMTokenFindAll()
+	if err != nil {
+		return err
+	}
+
+	c.Set("tokens", tokens)
+	c.Set("newToken", newToken)
+	c.Set("scimBaseURL", strings.TrimSuffix(a.Options.Host, "/")+scimBasePath)
+	c.Set("pageTitle", "SCIM Provisioning")
+	return c.Render(http.StatusOK, r.HTML("admin/scim/index.plush.html", "admin/layout.plush.html"))
+}
+
+// AdminSCIMIndex lists the tokens identity providers provision users with
+func (a *MyApp) AdminSCIMIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	return a.renderAdminSCIM(c, tx, "")
+}
+
+// AdminSCIMTokensCreate issues a SCIM token. It is only shown on the page this renders.
+func (a *MyApp) AdminSCIMTokensCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		c.Flash().Add("danger", "Give the token a name, like the identity provider that will use it")
+		return c.Redirect(http.StatusSeeOther, "/admin/scim")
+	}
+
+	token, err := randomURLToken()
+	if err != nil {
+		return err
+	}
+	scimToken := &models.SCIMToken{
+		Name:        name,
+		TokenHash:   models.HashSCIMToken(token),
+		CreatedByID: nulls.NewUUID(currentUser.ID),
+	}
+	verrs, err := tx.ValidateAndCreate(scimToken)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.Error())
+		return c.Redirect(http.StatusSeeOther, "/admin/scim")
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "create_scim_token", "scim_token", &scimToken.ID, fmt.Sprintf("Created SCIM token %q", scimToken.Name))
+
+	c.Flash().Add("success", "SCIM token created. Copy it now, it won't be shown again.")
+	return a.renderAdminSCIM(c, tx, token)
+}
+
+// AdminSCIMTokensDestroy revokes a SCIM token
+func (a *MyApp) AdminSCIMTokensDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	scimToken := &models.SCIMToken{}
+	if err := tx.Find(scimToken, c.Param("token_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+	if err := tx.Destroy(scimToken); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_scim_token", "scim_token", &scimToken.ID, fmt.Sprintf("Revoked SCIM token %q", scimToken.Name))
+
+	c.Flash().Add("success", "SCIM token revoked")
+	return c.Redirect(http.StatusSeeOther, "/admin/scim")
+}
+
+// invitationExpiryDays are the lifetimes owners can pick for an invitation, 0 never expires
+var invitationExpiryDays = []int{1, 7, 30, 90, 0}
+
+// AdminInvitationsIndex lists the invitation links and their usage
+func (a *MyApp) AdminInvitationsIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	invitations, err := repoManager.InvitationFindAll()
+	if err != nil {
+		return err
+	}
+	hackathons, err := repoManager.HackathonFindUnfinished()
+	if err != nil {
+		return err
+	}
+
+	links := map[string]string{}
+	hackathonTitles := map[string]string{}
+	for _, hackathon := range *hackathons {
+		hackathonTitles[hackathon.ID] = hackathon.Title
+	}
+	for _, invitation := range *invitations {
+		links[invitation.ID.String()] = a.invitationURL(invitation)
+		if !invitation.HackathonID.Valid {
+			continue
+		}
+		// Completed hackathons aren't offered for new invitations but old ones still show them
+		hackathonID := invitation.HackathonID.String
+		if _, ok := hackathonTitles[hackathonID]; !ok {
+			if hackathon, err := repoManager.HackathonFindByID(hackathonID); err == nil {
+				hackathonTitles[hackathonID] = hackathon.Title
+			}
+		}
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+
+	c.Set("invitations", invitations)
+	c.Set("invitationLinks", links)
+	c.Set("hackathons", hackathons)
+	c.Set("hackathonTitles", hackathonTitles)
+	c.Set("expiryDays", invitationExpiryDays)
+	c.Set("publicRegistration", config.AllowPublicRegistration)
+	c.Set("now", time.Now())
+	c.Set("pageTitle", "Invitations")
+	return c.Render(http.StatusOK, r.HTML("admin/invitations/index.plush.html", "admin/layout.plush.html"))
+}
+
+// AdminInvitationsCreate creates an invitation link
+func (a *MyApp) AdminInvitationsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	maxUses, err := strconv.Atoi(c.Param("max_uses"))
+	if err != nil {
+		c.Flash().Add("danger", "Uses must be a number, use 0 for unlimited")
+		return c.Redirect(http.StatusSeeOther, "/admin/invitations")
+	}
+	expiresInDays, err := strconv.Atoi(c.Param("expires_in_days"))
+	if err != nil || expiresInDays < 0 {
+		c.Flash().Add("danger", "Pick when the invitation expires")
+		return c.Redirect(http.StatusSeeOther, "/admin/invitations")
+	}
+
+	invitation := &models.Invitation{
+		Note:        strings.TrimSpace(c.Param("note")),
+		Role:        c.Param("role"),
+		CompanyTeam: strings.TrimSpace(c.Param("company_team")),
+		MaxUses:     maxUses,
+		CreatedByID: nulls.NewUUID(currentUser.ID),
+	}
+	if expiresInDays > 0 {
+		invitation.ExpiresAt = nulls.NewTime(time.Now().AddDate(0, 0, expiresInDays))
+	}
+	if hackathonID := c.Param("hackathon_id"); hackathonID != "" {
+		hackathon, err := a.Repository(tx).HackathonFindByID(hackathonID)
+		if err != nil {
+			c.Flash().Add("danger", "That hackathon doesn't exist anymore")
+			return c.Redirect(http.StatusSeeOther, "/admin/invitations")
+		}
+		invitation.HackathonID = nulls.NewString(hackathon.ID)
+	}
+
+	verrs, err := tx.ValidateAndCreate(invitation)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.Error())
+		return c.Redirect(http.StatusSeeOther, "/admin/invitations")
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "create_invitation", "invitation", &invitation.ID, fmt.Sprintf("Created invitation for %s (uses: %d, note: %q)", invitation.Role, invitation.MaxUses, invitation.Note))
+
+	c.Flash().Add("success", "Invitation created. Copy its link from the table below.")
+	return c.Redirect(http.StatusSeeOther, "/admin/invitations")
+}
+
+// AdminInvitationsDestroy revokes an invitation, its link stops working right away
+func (a *MyApp) AdminInvitationsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	invitation := &models.Invitation{}
+	if err := tx.Find(invitation, c.Param("invitation_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+	if err := tx.Destroy(invitation); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_invitation", "invitation", &invitation.ID, fmt.Sprintf("Revoked invitation for %s after %d uses (note: %q)", invitation.Role, invitation.UseCount, invitation.Note))
+
+	c.Flash().Add("success", "Invitation revoked")
+	return c.Redirect(http.StatusSeeOther, "/admin/invitations")
+}
+
+// renderAdminAPITokens renders the API tokens page, with a token that was just created when there is one
+func (a *MyApp) renderAdminAPITokens(c buffalo.Context, tx *pop.Connection, newAPIToken *models.APIToken, newToken string) error {
+	repoManager := a.Repository(tx)
+
+	apiTokens, err := repoManager.APITokenFindAll()
+	if err != nil {
+		return err
+	}
+	serviceAccounts, err := repoManager.UserFindServiceAccounts()
+	if err != nil {
+		return err
+	}
+
+	c.Set("apiTokens", apiTokens)
+	c.Set("serviceAccounts", serviceAccounts)
+	c.Set("newAPIToken", newAPIToken)
+	c.Set("newToken", newToken)
+	c.Set("accessLevels", apiTokenAccess)
+	c.Set("expiryDays", apiTokenExpiryDays)
+	c.Set("apiURL", strings.TrimSuffix(a.Options.Host, "/")+apiBasePath)
+	c.Set("now", time.Now())
+	c.Set("pageTitle", "API Tokens")
+	return c.Render(http.StatusOK, r.HTML("admin/api_tokens/index.plush.html", "admin/layout.plush.html"))
+}
+
+// AdminAPITokensIndex lists every API token and the service accounts tokens can be issued for
+func (a *MyApp) AdminAPITokensIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	return a.renderAdminAPITokens(c, tx, nil, "")
+}
+
+// AdminAPITokensCreate issues an API token for a service account. It is only shown on the page this renders.
+func (a *MyApp) AdminAPITokensCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	serviceAccount, err := a.Repository(tx).UserFindByID(c.Param("user_id"))
+	if err != nil || !serviceAccount.IsServiceAccount {
+		c.Flash().Add("danger", "Pick a service account for the token")
+		return c.Redirect(http.StatusSeeOther, "/admin/api-tokens")
+	}
+
+	apiToken, token, verrs, err := issueAPIToken(c, tx, *serviceAccount, nulls.NewUUID(currentUser.ID))
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.Error())
+		return c.Redirect(http.StatusSeeOther, "/admin/api-tokens")
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "create_api_token", "api_token", apiToken.ID, fmt.Sprintf("Created API token %q with scopes %s for service account %s", apiToken.Name, apiToken.Scopes, serviceAccount.Name))
+
+	c.Flash().Add("success", "API token created. Copy it now, it won't be shown again.")
+	return a.renderAdminAPITokens(c, tx, apiToken, token)
+}
+
+// AdminAPITokensDestroy revokes any user's API token
+func (a *MyApp) AdminAPITokensDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+	repoManager := a.Repository(tx)
+
+	apiToken, err := repoManager.APITokenFindByID(c.Param("token_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+	if err := tx.Destroy(apiToken); err != nil {
+		return err
+	}
+
+	owner := apiToken.UserID.String()
+	if user, err := repoManager.UserFindByID(apiToken.UserID); err == nil {
+		owner = user.Email
+	}
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_api_token", "api_token", apiToken.ID, fmt.Sprintf("Revoked API token %q of %s", apiToken.Name, owner))
+
+	c.Flash().Add("success", fmt.Sprintf("API token %q revoked", apiToken.Name))
+	return c.Redirect(http.StatusSeeOther, "/admin/api-tokens")
+}
+
+// AdminServiceAccountsCreate creates an account for automation. It isn't tied to a person,
+// can't sign in and only acts through the API tokens issued for it.
+func (a *MyApp) AdminServiceAccountsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	name := strings.TrimSpace(c.Param("name"))
+	if name == "" {
+		c.Flash().Add("danger", "Give the service account a name, like the bot that will use it")
+		return c.Redirect(http.StatusSeeOther, "/admin/api-tokens")
+	}
+
+	// The password is never shown to anyone, service accounts can't sign in
+	password, err := randomPassword()
+	if err != nil {
+		return err
+	}
+	serviceAccount := &models.User{
+		Email:                fmt.Sprintf("service-%s@accounts.invalid", uuid.Must(uuid.NewV4()).String()[:8]),
+		Name:                 name,
+		Role:                 c.Param("role"),
+		Password:             password,
+		PasswordConfirmation: password,
+		EmailVerifiedAt:      nulls.NewTime(time.Now()),
+		IsServiceAccount:     true,
+	}
+	if !models.IsValidRole(serviceAccount.Role) {
+		c.Flash().Add("danger", "Pick a role for the service account")
+		return c.Redirect(http.StatusSeeOther, "/admin/api-tokens")
+	}
+
+	verrs, err := serviceAccount.Create(tx)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.Error())
+		return c.Redirect(http.StatusSeeOther, "/admin/api-tokens")
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "create_service_account", "user", &serviceAccount.ID, fmt.Sprintf("Created service account %s with role %s", serviceAccount.Name, serviceAccount.Role))
+
+	c.Flash().Add("success", fmt.Sprintf("Service account %s created. Issue it a token below.", serviceAccount.Name))
+	return c.Redirect(http.StatusSeeOther, "/admin/api-tokens")
+}
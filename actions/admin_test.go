@@ -0,0 +1,27 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/arxdsilva/hackathon/models"
+)
+
+func TestLogAuditEvent_StringResourceID(t *testing.T) {
+	tx := testTx(t)
+	project := models.Project{ID: "a1b2c3d4e5f6"}
+
+	for _, resourceID := range []interface{}{project.ID, &project.ID} {
+		logAuditEvent(tx, nil, nil, "approve_project", "project", resourceID, "Project approved")
+
+		auditLog := &models.AuditLog{}
+		if err := tx.Where("action = ?", "approve_project").Order("created_at desc").First(auditLog); err != nil {
+			t.Fatal(err)
+		}
+		if auditLog.ResourceID == nil || *auditLog.ResourceID != project.ID {
+			t.Errorf("resource_id of %T = %v, want %q", resourceID, auditLog.ResourceID, project.ID)
+		}
+		if err := tx.Destroy(auditLog); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,978 @@
+package actions
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+)
+
+// The JSON API lets scripts and integrations work with hackathons, projects and files. Clients
+// authenticate with an API token instead of a session, so requests skip CSRF checks. Tokens
+// belong to a user or a service account and their scopes limit what they can change.
+// Lists are paged with an opaque cursor: pass a page's next_cursor to get the page after it.
+
+const (
+	apiBasePath     = "/api/v1"
+	apiDefaultLimit = 50
+	apiMaxLimit     = 100
+
+	// apiMaxUploadSize is the largest file the API accepts, like the upload form
+	apiMaxUploadSize = 10 * 1024 * 1024
+)
+
+// apiProblem is a request the API refuses, it is returned to the client as an error object
+type apiProblem struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (p apiProblem) Error() string {
+	return p.Message
+}
+
+var (
+	errAPIHackathonNotFound = apiProblem{http.StatusNotFound, "not_found", "Hackathon not found"}
+	errAPIProjectNotFound   = apiProblem{http.StatusNotFound, "not_found", "Project not found"}
+	errAPISubmissionsClosed = apiProblem{http.StatusConflict, "submissions_closed", "Submissions are closed, ask an organizer for an extension"}
+)
+
+type apiErrorObject struct {
+	Status  int                 `json:"status"`
+	Code    string              `json:"code"`
+	Message string              `json:"message"`
+	Details map[string][]string `json:"details,omitempty"`
+}
+
+type apiErrorResponse struct {
+	Error apiErrorObject `json:"error"`
+}
+
+type apiResponse struct {
+	Data interface{} `json:"data"`
+}
+
+type apiListResponse struct {
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// apiUser is the public part of an account
+type apiUser struct {
+	ID              uuid.UUID  `json:"id"`
+	Email           string     `json:"email"`
+	Name            string     `json:"name"`
+	CompanyTeam     string     `json:"company_team"`
+	Role            string     `json:"role"`
+	TOTPEnabled     bool       `json:"totp_enabled"`
+	EmailVerifiedAt nulls.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func newAPIUser(user models.User) *apiUser {
+	return &apiUser{
+		ID:              user.ID,
+		Email:           user.Email,
+		Name:            user.Name,
+		CompanyTeam:     user.CompanyTeam,
+		Role:            user.Role,
+		TOTPEnabled:     user.TOTPEnabled,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		CreatedAt:       user.CreatedAt,
+	}
+}
+
+// apiProject leaves the image out of the project, clients download it from ImageURL.
+// The outer ImageData field hides the embedded one and is always empty.
+type apiProject struct {
+	models.Project
+	ImageData []byte `json:"image_data,omitempty" openapi:"-"`
+	ImageURL  string `json:"image_url,omitempty"`
+}
+
+func newAPIProject(project models.Project) apiProject {
+	project.Hackathon, project.User = nil, nil
+	p := apiProject{Project: project}
+	if len(project.ImageData) > 0 {
+		p.ImageURL = fmt.Sprintf("/hackathons/%s/projects/%s/image", project.HackathonID, project.ID)
+	}
+	return p
+}
+
+// apiFile is the metadata of an uploaded file, clients download the content from DownloadURL.
+// The outer Data field hides the embedded one and is always empty.
+type apiFile struct {
+	models.File
+	Data        []byte `json:"data,omitempty" openapi:"-"`
+	DownloadURL string `json:"download_url"`
+}
+
+func newAPIFile(file models.File) apiFile {
+	file.User, file.Hackathon, file.Project = nil, nil, nil
+	return apiFile{File: file, DownloadURL: fmt.Sprintf("/files/%s/download", file.ID)}
+}
+
+// apiMembership is a project membership with the member's public account details
+type apiMembership struct {
+	models.ProjectMembership
+	User *apiUser `json:"user,omitempty"`
+}
+
+func newAPIMembership(membership models.ProjectMembership, user *models.User) apiMembership {
+	m := apiMembership{ProjectMembership: membership}
+	if user != nil {
+		m.User = newAPIUser(*user)
+	}
+	return m
+}
+
+// apiFields are the top-level fields of a request body, updates only change the fields a client sent
+type apiFields map[string]json.RawMessage
+
+func (f apiFields) has(name string) bool {
+	_, ok := f[name]
+	return ok
+}
+
+// apiDecode reads a JSON object request body into v and returns the fields it contained
+func apiDecode(c buffalo.Context, v interface{}) (apiFields, error) {
+	invalid := apiProblem{http.StatusBadRequest, "invalid_json", "The request body must be a JSON object"}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, invalid
+	}
+	fields := apiFields{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, invalid
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, apiProblem{http.StatusBadRequest, "invalid_json", fmt.Sprintf("The request body is invalid: %v", err)}
+	}
+	return fields, nil
+}
+
+// apiRender writes a JSON response body
+func apiRender(c buffalo.Context, status int, v interface{}) error {
+	return c.Render(status, r.JSON(v))
+}
+
+// apiError writes an API error response
+func apiError(c buffalo.Context, status int, code, message string) error {
+	return apiRender(c, status, apiErrorResponse{Error: apiErrorObject{Status: status, Code: code, Message: message}})
+}
+
+// apiValidationError writes the validation errors of a model as an API error response
+func apiValidationError(c buffalo.Context, verrs *validate.Errors) error {
+	return apiRender(c, http.StatusUnprocessableEntity, apiErrorResponse{Error: apiErrorObject{
+		Status:  http.StatusUnprocessableEntity,
+		Code:    "validation_failed",
+		Message: "The request has invalid fields",
+		Details: verrs.Errors,
+	}})
+}
+
+// apiFailure sends refused requests back as API errors and passes other errors on
+func apiFailure(c buffalo.Context, err error) error {
+	var problem apiProblem
+	if errors.As(err, &problem) {
+		return apiError(c, problem.Status, problem.Code, problem.Message)
+	}
+	return err
+}
+
+// encodePageCursor returns the cursor a client sends to get the rows after the given one
+func encodePageCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+// apiPage reads the cursor and limit parameters of a list request
+func apiPage(c buffalo.Context) (*repository.PageCursor, int, error) {
+	limit := apiDefaultLimit
+	if param := c.Param("limit"); param != "" {
+		l, err := strconv.Atoi(param)
+		if err != nil || l < 1 || l > apiMaxLimit {
+			return nil, 0, apiProblem{http.StatusBadRequest, "invalid_limit", fmt.Sprintf("limit must be between 1 and %d", apiMaxLimit)}
+		}
+		limit = l
+	}
+
+	param := c.Param("cursor")
+	if param == "" {
+		return nil, limit, nil
+	}
+	invalid := apiProblem{http.StatusBadRequest, "invalid_cursor", "cursor must be the next_cursor of a previous page"}
+	raw, err := base64.RawURLEncoding.DecodeString(param)
+	if err != nil {
+		return nil, 0, invalid
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, 0, invalid
+	}
+	at, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, 0, invalid
+	}
+	return &repository.PageCursor{CreatedAt: at, ID: id}, limit, nil
+}
+
+// apiAudit records a change made through the API along with the token that made it
+func apiAudit(tx *pop.Connection, c buffalo.Context, action, resourceType string, resourceID interface{}, details string) {
+	currentUser := c.Value("current_user").(models.User)
+	apiToken := c.Value("api_token").(models.APIToken)
+	logAuditEvent(tx, c, &currentUser.ID, action, resourceType, resourceID, fmt.Sprintf("%s (API token %s %q)", details, apiToken.ID, apiToken.Name))
+}
+
+// canSeeHackathon returns true unless the hackathon is hidden from the user
+func canSeeHackathon(user models.User, hackathon *models.Hackathon) bool {
+	return hackathon.Status != models.HackathonStatusHidden || isHackathonOrganizer(user, hackathon)
+}
+
+// apiFindHackathon finds the hackathon of the request if the user can see it
+func apiFindHackathon(c buffalo.Context, repoManager repository.RepositoryInterface, user models.User) (*models.Hackathon, error) {
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil || !canSeeHackathon(user, hackathon) {
+		return nil, errAPIHackathonNotFound
+	}
+	return hackathon, nil
+}
+
+// apiFindProject finds the project of the request and its hackathon. Projects waiting for review
+// are only found for their team and the hackathon's organizers, like on the project page.
+func apiFindProject(c buffalo.Context, repoManager repository.RepositoryInterface, user models.User) (*models.Project, *models.Hackathon, error) {
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil {
+		return nil, nil, errAPIProjectNotFound
+	}
+	hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
+	if err != nil {
+		return nil, nil, errAPIProjectNotFound
+	}
+	visible, err := canSeeProject(repoManager, user, project, hackathon)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !visible {
+		return nil, nil, errAPIProjectNotFound
+	}
+	return project, hackathon, nil
+}
+
+// canSeeProject returns true when the project passed review, or the user is on its team or organizes its hackathon
+func canSeeProject(repoManager repository.RepositoryInterface, user models.User, project *models.Project, hackathon *models.Hackathon) (bool, error) {
+	if project.IsApproved() || isHackathonOrganizer(user, hackathon) {
+		return true, nil
+	}
+	return repoManager.ProjectMembershipIsUserMember(project.ID, user.ID)
+}
+
+// canSeeFile returns true when the user can see the hackathon and the project the file belongs to, if any
+func canSeeFile(repoManager repository.RepositoryInterface, user models.User, file *models.File) (bool, error) {
+	if file.ProjectID != nil {
+		project, err := repoManager.ProjectFindByID(*file.ProjectID)
+		if err != nil {
+			return false, nil
+		}
+		hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
+		if err != nil || !canSeeHackathon(user, hackathon) {
+			return false, nil
+		}
+		return canSeeProject(repoManager, user, project, hackathon)
+	}
+	if file.HackathonID != nil {
+		hackathon, err := repoManager.HackathonFindByID(*file.HackathonID)
+		if err != nil {
+			return false, nil
+		}
+		return canSeeHackathon(user, hackathon), nil
+	}
+	return true, nil
+}
+
+// RequireAPIToken authenticates API requests with a bearer token the user created on their profile
+func (a *MyApp) RequireAPIToken(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		tx := c.Value("tx").(*pop.Connection)
+		repoManager := a.Repository(tx)
+
+		scheme, token, _ := strings.Cut(c.Request().Header.Get("Authorization"), " ")
+		token = strings.TrimSpace(token)
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			c.Response().Header().Set("WWW-Authenticate", `Bearer realm="API"`)
+			return apiError(c, http.StatusUnauthorized, "unauthorized", "A bearer token is required")
+		}
+
+		now := time.Now()
+		apiToken, err := repoManager.APITokenFindByTokenHash(models.HashAPIToken(token))
+		if err != nil || apiToken.IsExpired(now) {
+			c.Response().Header().Set("WWW-Authenticate", `Bearer realm="API", error="invalid_token"`)
+			return apiError(c, http.StatusUnauthorized, "invalid_token", "The bearer token is invalid, expired or has been revoked")
+		}
+
+		user, err := repoManager.UserFindByID(apiToken.UserID)
+		if err != nil || user.IsDeactivated() {
+			c.Response().Header().Set("WWW-Authenticate", `Bearer realm="API", error="invalid_token"`)
+			return apiError(c, http.StatusUnauthorized, "invalid_token", "The account of this token has been deactivated")
+		}
+		if user.ForcePasswordReset {
+			return apiError(c, http.StatusForbidden, "password_reset_required", "Reset your password in the browser before using the API")
+		}
+
+		// Token use, reads included, is audited once a minute per token with the request that started the minute
+		if !apiToken.LastUsedAt.Valid || now.Sub(apiToken.LastUsedAt.Time) >= sessionTouchInterval {
+			if err := repoManager.APITokenTouch(apiToken.ID, now); err != nil {
+				c.Logger().Errorf("Failed to record API token use: %v", err)
+			} else {
+				req := c.Request()
+				logAuditEvent(tx, c, &user.ID, "use_api_token", "api_token", apiToken.ID, fmt.Sprintf("Used API token %s %q: %s %s", apiToken.ID, apiToken.Name, req.Method, req.URL.Path))
+			}
+		}
+
+		c.Set("current_user", *user)
+		c.Set("api_token", *apiToken)
+		return next(c)
+	}
+}
+
+// RequireAPIScope makes a route need a token with the given scope
+func (a *MyApp) RequireAPIScope(scope string) buffalo.MiddlewareFunc {
+	return func(next buffalo.Handler) buffalo.Handler {
+		return func(c buffalo.Context) error {
+			apiToken := c.Value("api_token").(models.APIToken)
+			if !apiToken.HasScope(scope) {
+				c.Response().Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="API", error="insufficient_scope", scope=%q`, scope))
+				return apiError(c, http.StatusForbidden, "insufficient_scope", fmt.Sprintf("This token needs the %s scope", scope))
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireAPIRoleOwner is RequireRoleOwner for the API
+func (a *MyApp) RequireAPIRoleOwner(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		user, ok := c.Value("current_user").(models.User)
+		if !ok || user.Role != models.RoleOwner {
+			return apiError(c, http.StatusForbidden, "forbidden", "You must have owner role to do that")
+		}
+		return next(c)
+	}
+}
+
+// RequireAPIHackathonOwner is RequireHackathonOwner for the API
+func (a *MyApp) RequireAPIHackathonOwner(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		user := c.Value("current_user").(models.User)
+		tx := c.Value("tx").(*pop.Connection)
+
+		hackathon, err := apiFindHackathon(c, a.Repository(tx), user)
+		if err != nil {
+			return apiFailure(c, err)
+		}
+		if hackathon.OwnerID != user.ID {
+			return apiError(c, http.StatusForbidden, "forbidden", "You must be the owner of this hackathon to do that")
+		}
+		return next(c)
+	}
+}
+
+// APIMe returns the account of the token
+func (a *MyApp) APIMe(c buffalo.Context) error {
+	currentUser := c.Value("current_user").(models.User)
+	return apiRender(c, http.StatusOK, apiResponse{Data: newAPIUser(currentUser)})
+}
+
+// apiHackathonInput are the fields clients can set on a hackathon
+type apiHackathonInput struct {
+	Title              string     `json:"title,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	Status             string     `json:"status,omitempty"`
+	StartDate          time.Time  `json:"start_date,omitempty"`
+	EndDate            time.Time  `json:"end_date,omitempty"`
+	VotesPerUser       int        `json:"votes_per_user,omitempty"`
+	OpenTeams          bool       `json:"open_teams,omitempty"`
+	MaxTeamSize        nulls.Int  `json:"max_team_size,omitempty"`
+	SubmissionDeadline nulls.Time `json:"submission_deadline,omitempty"`
+}
+
+// apply copies the fields the client sent to the hackathon
+func (in apiHackathonInput) apply(hackathon *models.Hackathon, fields apiFields) {
+	if fields.has("title") {
+		hackathon.Title = in.Title
+	}
+	if fields.has("description") {
+		hackathon.Description = in.Description
+	}
+	if fields.has("status") {
+		hackathon.Status = in.Status
+	}
+	if fields.has("start_date") {
+		hackathon.StartDate = in.StartDate
+	}
+	if fields.has("end_date") {
+		hackathon.EndDate = in.EndDate
+	}
+	if fields.has("votes_per_user") {
+		hackathon.VotesPerUser = in.VotesPerUser
+	}
+	if fields.has("open_teams") {
+		hackathon.OpenTeams = in.OpenTeams
+	}
+	if fields.has("max_team_size") {
+		hackathon.MaxTeamSize = in.MaxTeamSize
+	}
+	if fields.has("submission_deadline") {
+		hackathon.SubmissionDeadline = in.SubmissionDeadline
+	}
+}
+
+// APIHackathonsIndex lists the hackathons the user can see
+func (a *MyApp) APIHackathonsIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	after, limit, err := apiPage(c)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+
+	hackathons, err := repoManager.HackathonFindPage(currentUser.ID, currentUser.IsOwner(), after, limit+1)
+	if err != nil {
+		return err
+	}
+
+	next := ""
+	if len(*hackathons) > limit {
+		*hackathons = (*hackathons)[:limit]
+		last := (*hackathons)[limit-1]
+		next = encodePageCursor(last.CreatedAt, last.ID)
+	}
+	return apiRender(c, http.StatusOK, apiListResponse{Data: hackathons, NextCursor: next})
+}
+
+// APIHackathonsShow returns a hackathon
+func (a *MyApp) APIHackathonsShow(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	hackathon, err := apiFindHackathon(c, a.Repository(tx), currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	return apiRender(c, http.StatusOK, apiResponse{Data: hackathon})
+}
+
+// APIHackathonsCreate creates a hackathon owned by the user (owner-only)
+func (a *MyApp) APIHackathonsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	input := apiHackathonInput{}
+	fields, err := apiDecode(c, &input)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+
+	hackathon := &models.Hackathon{
+		Status:       models.HackathonStatusUpcoming,
+		VotesPerUser: models.DefaultVotesPerUser,
+		OwnerID:      currentUser.ID,
+	}
+	input.apply(hackathon, fields)
+
+	verrs, err := tx.ValidateAndCreate(hackathon)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return apiValidationError(c, verrs)
+	}
+
+	apiAudit(tx, c, "create", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon created: %s", hackathon.Title))
+
+	return apiRender(c, http.StatusCreated, apiResponse{Data: hackathon})
+}
+
+// APIHackathonsUpdate changes the fields of a hackathon the client sent (hackathon owner only)
+func (a *MyApp) APIHackathonsUpdate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	hackathon, err := a.Repository(tx).HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return apiFailure(c, errAPIHackathonNotFound)
+	}
+
+	input := apiHackathonInput{}
+	fields, err := apiDecode(c, &input)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	input.apply(hackathon, fields)
+
+	verrs, err := tx.ValidateAndUpdate(hackathon)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return apiValidationError(c, verrs)
+	}
+
+	apiAudit(tx, c, "update", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon updated: %s", hackathon.Title))
+
+	return apiRender(c, http.StatusOK, apiResponse{Data: hackathon})
+}
+
+// APIHackathonsDestroy deletes a hackathon (hackathon owner only)
+func (a *MyApp) APIHackathonsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	hackathon, err := a.Repository(tx).HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return apiFailure(c, errAPIHackathonNotFound)
+	}
+
+	apiAudit(tx, c, "delete", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon deleted: %s", hackathon.Title))
+
+	if err := tx.Destroy(hackathon); err != nil {
+		return err
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// apiProjectInput are the fields a project's owner can set
+type apiProjectInput struct {
+	Name          string `json:"name,omitempty"`
+	Description   string `json:"description,omitempty"`
+	RepositoryURL string `json:"repository_url,omitempty"`
+	DemoURL       string `json:"demo_url,omitempty"`
+	Status        string `json:"status,omitempty"`
+}
+
+// apply copies the fields the client sent to the project
+func (in apiProjectInput) apply(project *models.Project, fields apiFields) {
+	if fields.has("name") {
+		project.Name = in.Name
+	}
+	if fields.has("description") {
+		project.Description = in.Description
+	}
+	if fields.has("repository_url") {
+		project.RepositoryURL = in.RepositoryURL
+	}
+	if fields.has("demo_url") {
+		project.DemoURL = in.DemoURL
+	}
+	if fields.has("status") {
+		project.Status = in.Status
+	}
+}
+
+// APIProjectsIndex lists the projects of a hackathon the user can see
+func (a *MyApp) APIProjectsIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	hackathon, err := apiFindHackathon(c, repoManager, currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	after, limit, err := apiPage(c)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+
+	projects, err := repoManager.ProjectFindVisiblePage(hackathon.ID, currentUser.ID, isHackathonOrganizer(currentUser, hackathon), after, limit+1)
+	if err != nil {
+		return err
+	}
+
+	next := ""
+	if len(*projects) > limit {
+		*projects = (*projects)[:limit]
+		last := (*projects)[limit-1]
+		next = encodePageCursor(last.CreatedAt, last.ID)
+	}
+	data := make([]apiProject, len(*projects))
+	for i, project := range *projects {
+		data[i] = newAPIProject(project)
+	}
+	return apiRender(c, http.StatusOK, apiListResponse{Data: data, NextCursor: next})
+}
+
+// APIProjectsShow returns a project
+func (a *MyApp) APIProjectsShow(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, _, err := apiFindProject(c, a.Repository(tx), currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	return apiRender(c, http.StatusOK, apiResponse{Data: newAPIProject(*project)})
+}
+
+// APIProjectsCreate submits a project to a hackathon with the user as its owner
+func (a *MyApp) APIProjectsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	hackathon, err := apiFindHackathon(c, repoManager, currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+
+	input := apiProjectInput{}
+	fields, err := apiDecode(c, &input)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+
+	project := &models.Project{
+		HackathonID: hackathon.ID,
+		UserID:      &currentUser.ID,
+	}
+	input.apply(project, fields)
+
+	// No new projects once submissions close
+	if project.IsFrozen(*hackathon, time.Now()) {
+		return apiFailure(c, errAPISubmissionsClosed)
+	}
+
+	// Hold new submissions for an organizer's review when the platform requires it
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+	project.ReviewStatus = models.ProjectReviewApproved
+	if config.RequireProjectApproval {
+		project.ReviewStatus = models.ProjectReviewPending
+	}
+
+	verrs, err := tx.ValidateAndCreate(project)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return apiValidationError(c, verrs)
+	}
+
+	// Add the founder to project_memberships
+	membership := &models.ProjectMembership{
+		ProjectID: project.ID,
+		UserID:    currentUser.ID,
+	}
+	if err := tx.Create(membership); err != nil {
+		return err
+	}
+
+	apiAudit(tx, c, "create", "project", project.ID, fmt.Sprintf("Project created: %s", project.Name))
+
+	return apiRender(c, http.StatusCreated, apiResponse{Data: newAPIProject(*project)})
+}
+
+// APIProjectsUpdate changes the fields of a project the client sent (project owner only)
+func (a *MyApp) APIProjectsUpdate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, hackathon, err := apiFindProject(c, a.Repository(tx), currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	if project.UserID == nil || *project.UserID != currentUser.ID {
+		return apiError(c, http.StatusForbidden, "forbidden", "You can only edit your own projects")
+	}
+	if project.IsFrozen(*hackathon, time.Now()) {
+		return apiFailure(c, errAPISubmissionsClosed)
+	}
+
+	input := apiProjectInput{}
+	fields, err := apiDecode(c, &input)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	input.apply(project, fields)
+
+	// Editing a rejected project sends it back to the review queue
+	resubmitted := project.IsRejected()
+	if resubmitted {
+		project.ReviewStatus = models.ProjectReviewPending
+	}
+
+	verrs, err := tx.ValidateAndUpdate(project)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return apiValidationError(c, verrs)
+	}
+
+	apiAudit(tx, c, "update", "project", project.ID, fmt.Sprintf("Project updated: %s", project.Name))
+	if resubmitted {
+		apiAudit(tx, c, "resubmit_project", "project", project.ID, fmt.Sprintf("Project resubmitted for review: %s", project.Name))
+	}
+
+	return apiRender(c, http.StatusOK, apiResponse{Data: newAPIProject(*project)})
+}
+
+// APIProjectMembersIndex lists the members of a project
+func (a *MyApp) APIProjectMembersIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, _, err := apiFindProject(c, repoManager, currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	after, limit, err := apiPage(c)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+
+	memberships, err := repoManager.ProjectMembershipFindPageByProjectID(project.ID, after, limit+1)
+	if err != nil {
+		return err
+	}
+
+	next := ""
+	if len(*memberships) > limit {
+		*memberships = (*memberships)[:limit]
+		last := (*memberships)[limit-1]
+		next = encodePageCursor(last.CreatedAt, last.ID.String())
+	}
+	data := make([]apiMembership, len(*memberships))
+	for i, membership := range *memberships {
+		data[i] = newAPIMembership(membership, membership.User)
+	}
+	return apiRender(c, http.StatusOK, apiListResponse{Data: data, NextCursor: next})
+}
+
+// APIProjectMembersCreate joins the user to a project when the hackathon has open teams
+func (a *MyApp) APIProjectMembersCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, hackathon, err := apiFindProject(c, repoManager, currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	if !hackathon.OpenTeams {
+		return apiError(c, http.StatusForbidden, "invite_only", "Teams in this hackathon are invite-only, send the owner a join request instead")
+	}
+
+	membership, err := addProjectMember(tx, repoManager, project.ID, currentUser.ID)
+	switch {
+	case errors.Is(err, errAlreadyMember):
+		return apiError(c, http.StatusConflict, "already_member", "You are already a member of this project")
+	case errors.Is(err, errTeamFull):
+		return apiError(c, http.StatusConflict, "team_full", fmt.Sprintf("%s is full", project.Name))
+	case errors.Is(err, errSubmissionsClosed):
+		return apiFailure(c, errAPISubmissionsClosed)
+	case err != nil:
+		return err
+	}
+
+	apiAudit(tx, c, "join", "project_membership", &membership.ID, fmt.Sprintf("User joined project: %s", project.Name))
+
+	return apiRender(c, http.StatusCreated, apiResponse{Data: newAPIMembership(*membership, &currentUser)})
+}
+
+// APIProjectMembersDestroy lets the user leave a project, members can only remove themselves
+func (a *MyApp) APIProjectMembersDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, hackathon, err := apiFindProject(c, repoManager, currentUser)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+	if c.Param("user_id") != currentUser.ID.String() {
+		return apiError(c, http.StatusForbidden, "forbidden", "You can only remove yourself from a project")
+	}
+	if project.UserID != nil && *project.UserID == currentUser.ID {
+		return apiError(c, http.StatusForbidden, "forbidden", "You cannot leave a project you own")
+	}
+	if project.IsFrozen(*hackathon, time.Now()) {
+		return apiFailure(c, errAPISubmissionsClosed)
+	}
+
+	membership, err := repoManager.ProjectMembershipFindByProjectIDAndUserID(project.ID, currentUser.ID)
+	if err != nil {
+		return apiError(c, http.StatusNotFound, "not_found", "You are not a member of this project")
+	}
+	if err := tx.Destroy(membership); err != nil {
+		return err
+	}
+
+	apiAudit(tx, c, "leave", "project_membership", &membership.ID, fmt.Sprintf("User left project: %s", project.Name))
+
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// APIFilesIndex lists file metadata, optionally of one hackathon or project. Files of hackathons
+// and projects the user can't see are left out.
+func (a *MyApp) APIFilesIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	if c.Param("hackathon_id") != "" {
+		if _, err := apiFindHackathon(c, repoManager, currentUser); err != nil {
+			return apiFailure(c, err)
+		}
+	}
+	if c.Param("project_id") != "" {
+		_, hackathon, err := apiFindProject(c, repoManager, currentUser)
+		if err == nil && !canSeeHackathon(currentUser, hackathon) {
+			err = errAPIProjectNotFound
+		}
+		if err != nil {
+			return apiFailure(c, err)
+		}
+	}
+	after, limit, err := apiPage(c)
+	if err != nil {
+		return apiFailure(c, err)
+	}
+
+	files, err := repoManager.FileFindVisiblePage(c.Param("hackathon_id"), c.Param("project_id"), currentUser.ID, currentUser.IsOwner(), after, limit+1)
+	if err != nil {
+		return err
+	}
+
+	next := ""
+	if len(*files) > limit {
+		*files = (*files)[:limit]
+		last := (*files)[limit-1]
+		next = encodePageCursor(last.CreatedAt, last.ID)
+	}
+	data := make([]apiFile, len(*files))
+	for i, file := range *files {
+		data[i] = newAPIFile(file)
+	}
+	return apiRender(c, http.StatusOK, apiListResponse{Data: data, NextCursor: next})
+}
+
+// APIFilesShow returns the metadata of a file of a hackathon and project the user can see
+func (a *MyApp) APIFilesShow(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	file, err := repoManager.FileFindByID(c.Param("file_id"))
+	if err != nil {
+		return apiError(c, http.StatusNotFound, "not_found", "File not found")
+	}
+	visible, err := canSeeFile(repoManager, currentUser, file)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return apiError(c, http.StatusNotFound, "not_found", "File not found")
+	}
+	return apiRender(c, http.StatusOK, apiResponse{Data: newAPIFile(*file)})
+}
+
+// apiFileUpload are the fields of a multipart file upload
+type apiFileUpload struct {
+	File        []byte `json:"file" openapi:"binary"`
+	HackathonID string `json:"hackathon_id,omitempty"`
+	ProjectID   string `json:"project_id,omitempty"`
+}
+
+// APIFilesCreate uploads a file from a multipart form, optionally to a hackathon or to a project
+// of the user's team. Project files belong to the submission and are frozen with it.
+func (a *MyApp) APIFilesCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	req := c.Request()
+	req.Body = http.MaxBytesReader(c.Response(), req.Body, apiMaxUploadSize+1024*1024)
+	uploadedFile, fileHeader, err := req.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		return apiError(c, http.StatusRequestEntityTooLarge, "file_too_large", "Files can be at most 10MB")
+	case err != nil:
+		return apiError(c, http.StatusBadRequest, "invalid_upload", "The request must be a multipart form with the content in a file field")
+	}
+	defer uploadedFile.Close()
+	if fileHeader.Size > apiMaxUploadSize {
+		return apiError(c, http.StatusRequestEntityTooLarge, "file_too_large", "Files can be at most 10MB")
+	}
+
+	data, err := io.ReadAll(uploadedFile)
+	if err != nil {
+		return err
+	}
+	file := &models.File{
+		Filename:    fileHeader.Filename,
+		Data:        data,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        len(data),
+		UserID:      currentUser.ID,
+	}
+
+	if projectID := req.FormValue("project_id"); projectID != "" {
+		project, err := repoManager.ProjectFindByID(projectID)
+		if err != nil {
+			return apiFailure(c, errAPIProjectNotFound)
+		}
+		hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
+		if err != nil {
+			return apiFailure(c, errAPIProjectNotFound)
+		}
+		isMember, err := repoManager.ProjectMembershipIsUserMember(project.ID, currentUser.ID)
+		if err != nil {
+			return err
+		}
+		if !isMember && !isHackathonOrganizer(currentUser, hackathon) {
+			return apiError(c, http.StatusForbidden, "forbidden", "Only the team can add files to a project")
+		}
+		if project.IsFrozen(*hackathon, time.Now()) {
+			return apiFailure(c, errAPISubmissionsClosed)
+		}
+		file.ProjectID = &project.ID
+		file.HackathonID = &hackathon.ID
+	} else if hackathonID := req.FormValue("hackathon_id"); hackathonID != "" {
+		hackathon, err := repoManager.HackathonFindByID(hackathonID)
+		if err != nil || !canSeeHackathon(currentUser, hackathon) {
+			return apiFailure(c, errAPIHackathonNotFound)
+		}
+		file.HackathonID = &hackathon.ID
+	}
+
+	verrs, err := tx.ValidateAndCreate(file)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return apiValidationError(c, verrs)
+	}
+
+	apiAudit(tx, c, "upload", "file", file.ID, fmt.Sprintf("File uploaded: %s", file.Filename))
+
+	return apiRender(c, http.StatusCreated, apiResponse{Data: newAPIFile(*file)})
+}
@@ -0,0 +1,440 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gofrs/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+// testParamContext is a buffalo.Context that only knows its request parameters
+type testParamContext struct {
+	buffalo.Context
+	params url.Values
+}
+
+func (c testParamContext) Param(key string) string {
+	return c.params.Get(key)
+}
+
+func TestAPIPage(t *testing.T) {
+	at := time.Date(2026, 10, 18, 9, 30, 0, 123456789, time.UTC)
+	cursor := encodePageCursor(at, "abc123")
+	encode := base64.RawURLEncoding.EncodeToString
+
+	tests := []struct {
+		name      string
+		params    url.Values
+		wantLimit int
+		wantAfter *repository.PageCursor
+		wantCode  string
+	}{
+		{name: "defaults", params: url.Values{}, wantLimit: apiDefaultLimit},
+		{name: "smallest limit", params: url.Values{"limit": {"1"}}, wantLimit: 1},
+		{name: "largest limit", params: url.Values{"limit": {fmt.Sprint(apiMaxLimit)}}, wantLimit: apiMaxLimit},
+		{name: "limit over the cap", params: url.Values{"limit": {fmt.Sprint(apiMaxLimit + 1)}}, wantCode: "invalid_limit"},
+		{name: "zero limit", params: url.Values{"limit": {"0"}}, wantCode: "invalid_limit"},
+		{name: "negative limit", params: url.Values{"limit": {"-5"}}, wantCode: "invalid_limit"},
+		{name: "limit not a number", params: url.Values{"limit": {"ten"}}, wantCode: "invalid_limit"},
+		{name: "cursor of a page", params: url.Values{"cursor": {cursor}, "limit": {"10"}}, wantLimit: 10, wantAfter: &repository.PageCursor{CreatedAt: at, ID: "abc123"}},
+		{name: "cursor not base64", params: url.Values{"cursor": {"not a cursor!"}}, wantCode: "invalid_cursor"},
+		{name: "padded base64 cursor", params: url.Values{"cursor": {cursor + "=="}}, wantCode: "invalid_cursor"},
+		{name: "cursor without separator", params: url.Values{"cursor": {encode([]byte(at.Format(time.RFC3339Nano)))}}, wantCode: "invalid_cursor"},
+		{name: "cursor without id", params: url.Values{"cursor": {encode([]byte(at.Format(time.RFC3339Nano) + "|"))}}, wantCode: "invalid_cursor"},
+		{name: "cursor with a bad time", params: url.Values{"cursor": {encode([]byte("yesterday|abc123"))}}, wantCode: "invalid_cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after, limit, err := apiPage(testParamContext{params: tt.params})
+			if tt.wantCode != "" {
+				problem, ok := err.(apiProblem)
+				if !ok || problem.Code != tt.wantCode || problem.Status != http.StatusBadRequest {
+					t.Errorf("got %v, %d, %v; want a %s problem", after, limit, err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if limit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", limit, tt.wantLimit)
+			}
+			switch {
+			case tt.wantAfter == nil && after != nil:
+				t.Errorf("after = %+v, want none", after)
+			case tt.wantAfter != nil && (after == nil || !after.CreatedAt.Equal(tt.wantAfter.CreatedAt) || after.ID != tt.wantAfter.ID):
+				t.Errorf("after = %+v, want %+v", after, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestCanSeeProjectAndFile(t *testing.T) {
+	owner := models.User{ID: uuid.Must(uuid.NewV4()), Role: models.RoleOwner}
+	organizer := models.User{ID: uuid.Must(uuid.NewV4()), Role: models.RoleHacker}
+	member := models.User{ID: uuid.Must(uuid.NewV4()), Role: models.RoleHacker}
+	outsider := models.User{ID: uuid.Must(uuid.NewV4()), Role: models.RoleJudge}
+
+	hackathons := map[string]*models.Hackathon{
+		"open":   {ID: "open", Status: models.HackathonStatusActive, OwnerID: organizer.ID},
+		"hidden": {ID: "hidden", Status: models.HackathonStatusHidden, OwnerID: organizer.ID},
+	}
+	projects := map[string]*models.Project{
+		"approved":  {ID: "approved", HackathonID: "open", ReviewStatus: models.ProjectReviewApproved},
+		"pending":   {ID: "pending", HackathonID: "open", ReviewStatus: models.ProjectReviewPending},
+		"rejected":  {ID: "rejected", HackathonID: "open", ReviewStatus: models.ProjectReviewRejected},
+		"in-hidden": {ID: "in-hidden", HackathonID: "hidden", ReviewStatus: models.ProjectReviewApproved},
+	}
+
+	ctrl := gomock.NewController(t)
+	repoManager := repository.NewMockRepositoryInterface(ctrl)
+	repoManager.EXPECT().HackathonFindByID(gomock.Any()).AnyTimes().DoAndReturn(func(id interface{}) (*models.Hackathon, error) {
+		if hackathon, ok := hackathons[id.(string)]; ok {
+			return hackathon, nil
+		}
+		return nil, fmt.Errorf("hackathon %s not found", id)
+	})
+	repoManager.EXPECT().ProjectFindByID(gomock.Any()).AnyTimes().DoAndReturn(func(id interface{}) (*models.Project, error) {
+		if project, ok := projects[id.(string)]; ok {
+			return project, nil
+		}
+		return nil, fmt.Errorf("project %s not found", id)
+	})
+	// The member is on the team of every project
+	repoManager.EXPECT().ProjectMembershipIsUserMember(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(projectID, userID interface{}) (bool, error) {
+		return userID == member.ID, nil
+	})
+
+	str := func(s string) *string { return &s }
+	users := []models.User{owner, organizer, member, outsider}
+	names := []string{"owner", "organizer", "member", "outsider"}
+	tests := []struct {
+		name string
+		file models.File
+		// visible is whether owner, organizer, member and outsider see the file
+		visible [4]bool
+	}{
+		{name: "approved project", file: models.File{ProjectID: str("approved")}, visible: [4]bool{true, true, true, true}},
+		{name: "project waiting for review", file: models.File{ProjectID: str("pending")}, visible: [4]bool{true, true, true, false}},
+		{name: "rejected project", file: models.File{ProjectID: str("rejected")}, visible: [4]bool{true, true, true, false}},
+		{name: "project of a hidden hackathon", file: models.File{ProjectID: str("in-hidden"), HackathonID: str("hidden")}, visible: [4]bool{true, true, false, false}},
+		{name: "open hackathon", file: models.File{HackathonID: str("open")}, visible: [4]bool{true, true, true, true}},
+		{name: "hidden hackathon", file: models.File{HackathonID: str("hidden")}, visible: [4]bool{true, true, false, false}},
+		{name: "missing project", file: models.File{ProjectID: str("gone"), HackathonID: str("open")}, visible: [4]bool{false, false, false, false}},
+		{name: "missing hackathon", file: models.File{HackathonID: str("gone")}, visible: [4]bool{false, false, false, false}},
+		{name: "not attached", file: models.File{}, visible: [4]bool{true, true, true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, user := range users {
+				visible, err := canSeeFile(repoManager, user, &tt.file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if visible != tt.visible[i] {
+					t.Errorf("the %s sees it: %v, want %v", names[i], visible, tt.visible[i])
+				}
+			}
+		})
+	}
+
+	for i, user := range users {
+		visible, err := canSeeProject(repoManager, user, projects["pending"], hackathons["open"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := user.ID != outsider.ID; visible != want {
+			t.Errorf("the %s sees a project waiting for review: %v, want %v", names[i], visible, want)
+		}
+	}
+}
+
+// testAPIList is a page of a list endpoint
+type testAPIList struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// testAPIErrorCode returns the code of an API error response
+func testAPIErrorCode(t *testing.T, res testResponse) string {
+	t.Helper()
+	var body apiErrorResponse
+	if err := json.Unmarshal([]byte(res.Body), &body); err != nil {
+		t.Fatalf("answer %d isn't an error object: %s", res.Code, res.Body)
+	}
+	return body.Error.Code
+}
+
+func TestAPIHackathonsIndex_Pages(t *testing.T) {
+	db := requireTestDB(t)
+	b := newTestBrowser(t)
+	organizer := createTestUser(t, db, models.RoleHacker)
+	hacker := createTestUser(t, db, models.RoleHacker)
+	_, token := createTestAPIToken(t, db, hacker, models.APIScopeRead)
+
+	now := time.Now()
+	want := []string{}
+	for i := 0; i < 5; i++ {
+		want = append(want, createTestHackathon(t, db, organizer, models.HackathonStatusActive, now, now.Add(time.Hour)).ID)
+	}
+	hidden := createTestHackathon(t, db, organizer, models.HackathonStatusHidden, now, now.Add(time.Hour))
+	// Sharing a creation time makes the ID decide the order, a cursor mustn't skip or repeat ties
+	sharedAt := time.Date(2001, 2, 3, 4, 5, 6, int(now.UnixNano()%1e9), time.UTC)
+	if err := db.RawQuery("UPDATE hackathons SET created_at = ? WHERE owner_id = ?", sharedAt, organizer.ID).Exec(); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(want)
+
+	got := []string{}
+	path := "/hackathons?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 1000 {
+			t.Fatal("paging didn't end")
+		}
+		res := b.api(http.MethodGet, path, token, nil)
+		if res.Code != http.StatusOK {
+			t.Fatalf("%s answered %d: %s", path, res.Code, res.Body)
+		}
+		var page testAPIList
+		if err := json.Unmarshal([]byte(res.Body), &page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Data) > 2 {
+			t.Fatalf("%s returned %d hackathons", path, len(page.Data))
+		}
+		for _, hackathon := range page.Data {
+			if hackathon.ID == hidden.ID {
+				t.Error("a hidden hackathon of another user was listed")
+			}
+			for _, id := range want {
+				if hackathon.ID == id {
+					got = append(got, id)
+				}
+			}
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		path = "/hackathons?limit=2&cursor=" + url.QueryEscape(page.NextCursor)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("paged through %v, want %v", got, want)
+	}
+
+	for path, code := range map[string]string{
+		fmt.Sprintf("/hackathons?limit=%d", apiMaxLimit+1): "invalid_limit",
+		"/hackathons?cursor=not-a-cursor":                  "invalid_cursor",
+	} {
+		res := b.api(http.MethodGet, path, token, nil)
+		if res.Code != http.StatusBadRequest || testAPIErrorCode(t, res) != code {
+			t.Errorf("%s answered %d: %s, want a %s error", path, res.Code, res.Body, code)
+		}
+	}
+	if res := b.api(http.MethodGet, fmt.Sprintf("/hackathons?limit=%d", apiMaxLimit), token, nil); res.Code != http.StatusOK {
+		t.Errorf("the largest page answered %d: %s", res.Code, res.Body)
+	}
+}
+
+func TestAPIVisibility(t *testing.T) {
+	db := requireTestDB(t)
+	b := newTestBrowser(t)
+	organizer := createTestUser(t, db, models.RoleHacker)
+	member := createTestUser(t, db, models.RoleHacker)
+	outsider := createTestUser(t, db, models.RoleHacker)
+	_, organizerToken := createTestAPIToken(t, db, organizer, models.APIScopeRead)
+	_, memberToken := createTestAPIToken(t, db, member, models.APIScopeRead)
+	_, outsiderToken := createTestAPIToken(t, db, outsider, models.APIScopeRead)
+
+	now := time.Now()
+	open := createTestHackathon(t, db, organizer, models.HackathonStatusActive, now, now.Add(time.Hour))
+	hidden := createTestHackathon(t, db, organizer, models.HackathonStatusHidden, now, now.Add(time.Hour))
+	pending := &models.Project{Name: "Pending", Description: "Waiting for review", HackathonID: open.ID, UserID: &member.ID, ReviewStatus: models.ProjectReviewPending}
+	createTestRow(t, db, pending)
+	createTestRow(t, db, &models.ProjectMembership{ProjectID: pending.ID, UserID: member.ID})
+	createFile := func(hackathonID, projectID *string) *models.File {
+		t.Helper()
+		file := &models.File{Filename: "notes.txt", Data: []byte("notes"), ContentType: "text/plain", Size: 5, UserID: organizer.ID, HackathonID: hackathonID, ProjectID: projectID}
+		createTestRow(t, db, file)
+		return file
+	}
+	pendingFile := createFile(&open.ID, &pending.ID)
+	hiddenFile := createFile(&hidden.ID, nil)
+
+	tests := []struct {
+		path string
+		// codes are what the organizer, the member and the outsider are answered
+		codes [3]int
+	}{
+		{"/hackathons/" + hidden.ID, [3]int{http.StatusOK, http.StatusNotFound, http.StatusNotFound}},
+		{"/hackathons/" + hidden.ID + "/projects", [3]int{http.StatusOK, http.StatusNotFound, http.StatusNotFound}},
+		{"/files/" + hiddenFile.ID, [3]int{http.StatusOK, http.StatusNotFound, http.StatusNotFound}},
+		{"/files?hackathon_id=" + hidden.ID, [3]int{http.StatusOK, http.StatusNotFound, http.StatusNotFound}},
+		{"/projects/" + pending.ID, [3]int{http.StatusOK, http.StatusOK, http.StatusNotFound}},
+		{"/projects/" + pending.ID + "/members", [3]int{http.StatusOK, http.StatusOK, http.StatusNotFound}},
+		{"/files/" + pendingFile.ID, [3]int{http.StatusOK, http.StatusOK, http.StatusNotFound}},
+		{"/files?project_id=" + pending.ID, [3]int{http.StatusOK, http.StatusOK, http.StatusNotFound}},
+	}
+	for _, tt := range tests {
+		for i, token := range []string{organizerToken, memberToken, outsiderToken} {
+			if res := b.api(http.MethodGet, tt.path, token, nil); res.Code != tt.codes[i] {
+				t.Errorf("%s answered %d to user %d of organizer, member and outsider, want %d", tt.path, res.Code, i, tt.codes[i])
+			}
+		}
+	}
+
+	// Lists leave out what the user can't see instead of failing
+	for _, path := range []string{"/hackathons?limit=100", "/hackathons/" + open.ID + "/projects", "/files?hackathon_id=" + open.ID, "/files?limit=100"} {
+		res := b.api(http.MethodGet, path, outsiderToken, nil)
+		var page testAPIList
+		if err := json.Unmarshal([]byte(res.Body), &page); err != nil || res.Code != http.StatusOK {
+			t.Fatalf("%s answered %d: %s", path, res.Code, res.Body)
+		}
+		for _, row := range page.Data {
+			if row.ID == hidden.ID || row.ID == pending.ID || row.ID == pendingFile.ID || row.ID == hiddenFile.ID {
+				t.Errorf("%s listed %s to an outsider", path, row.ID)
+			}
+		}
+	}
+}
+
+func TestAPIFilesCreate(t *testing.T) {
+	db := requireTestDB(t)
+	b := newTestBrowser(t)
+	organizer := createTestUser(t, db, models.RoleHacker)
+	member := createTestUser(t, db, models.RoleHacker)
+	outsider := createTestUser(t, db, models.RoleHacker)
+	_, organizerToken := createTestAPIToken(t, db, organizer, models.APIScopeProjectsWrite)
+	_, memberToken := createTestAPIToken(t, db, member, models.APIScopeProjectsWrite)
+	_, outsiderToken := createTestAPIToken(t, db, outsider, models.APIScopeProjectsWrite)
+
+	now := time.Now()
+	open := createTestHackathon(t, db, organizer, models.HackathonStatusActive, now.Add(-time.Hour), now.Add(time.Hour))
+	ended := createTestHackathon(t, db, organizer, models.HackathonStatusActive, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	hidden := createTestHackathon(t, db, organizer, models.HackathonStatusHidden, now.Add(-time.Hour), now.Add(time.Hour))
+	createProject := func(hackathon *models.Hackathon) *models.Project {
+		t.Helper()
+		project := &models.Project{Name: "Uploads", Description: "Has files", HackathonID: hackathon.ID, UserID: &member.ID}
+		createTestRow(t, db, project)
+		createTestRow(t, db, &models.ProjectMembership{ProjectID: project.ID, UserID: member.ID})
+		return project
+	}
+	project := createProject(open)
+	frozen := createProject(ended)
+
+	// upload sends content as the file field of a multipart form, with fields
+	upload := func(token string, fields map[string]string, content []byte) testResponse {
+		t.Helper()
+		body := &bytes.Buffer{}
+		form := multipart.NewWriter(body)
+		for name, value := range fields {
+			form.WriteField(name, value)
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="notes.txt"`)
+		header.Set("Content-Type", "text/plain")
+		part, err := form.CreatePart(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write(content)
+		form.Close()
+
+		req, err := http.NewRequest(http.MethodPost, b.server.URL+apiBasePath+"/files", body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		return b.do(req)
+	}
+
+	content := []byte("Meeting notes\n")
+	tests := []struct {
+		name    string
+		token   string
+		fields  map[string]string
+		content []byte
+		code    int
+		error   string
+	}{
+		{name: "team member to their project", token: memberToken, fields: map[string]string{"project_id": project.ID}, content: content, code: http.StatusCreated},
+		{name: "organizer to a project", token: organizerToken, fields: map[string]string{"project_id": project.ID}, content: content, code: http.StatusCreated},
+		{name: "outsider to a project", token: outsiderToken, fields: map[string]string{"project_id": project.ID}, content: content, code: http.StatusForbidden, error: "forbidden"},
+		{name: "project after submissions closed", token: memberToken, fields: map[string]string{"project_id": frozen.ID}, content: content, code: http.StatusConflict, error: "submissions_closed"},
+		{name: "missing project", token: memberToken, fields: map[string]string{"project_id": "missing"}, content: content, code: http.StatusNotFound, error: "not_found"},
+		{name: "open hackathon", token: outsiderToken, fields: map[string]string{"hackathon_id": open.ID}, content: content, code: http.StatusCreated},
+		{name: "hidden hackathon", token: outsiderToken, fields: map[string]string{"hackathon_id": hidden.ID}, content: content, code: http.StatusNotFound, error: "not_found"},
+		{name: "empty file", token: outsiderToken, content: nil, code: http.StatusUnprocessableEntity, error: "validation_failed"},
+		{name: "file over the limit", token: outsiderToken, content: make([]byte, apiMaxUploadSize+1), code: http.StatusRequestEntityTooLarge, error: "file_too_large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := upload(tt.token, tt.fields, tt.content)
+			if res.Code != tt.code {
+				t.Fatalf("answered %d, want %d: %s", res.Code, tt.code, res.Body)
+			}
+			if tt.error != "" {
+				if code := testAPIErrorCode(t, res); code != tt.error {
+					t.Errorf("error code %q, want %q", code, tt.error)
+				}
+				return
+			}
+
+			var created struct {
+				Data apiFile `json:"data"`
+			}
+			if err := json.Unmarshal([]byte(res.Body), &created); err != nil {
+				t.Fatal(err)
+			}
+			stored := &models.File{}
+			if err := db.Find(stored, created.Data.ID); err != nil {
+				t.Fatal(err)
+			}
+			if string(stored.Data) != string(tt.content) || stored.Filename != "notes.txt" || stored.ContentType != "text/plain" {
+				t.Errorf("stored %s of type %s with %q", stored.Filename, stored.ContentType, stored.Data)
+			}
+			wantHackathon := tt.fields["hackathon_id"]
+			if projectID := tt.fields["project_id"]; projectID != "" {
+				if stored.ProjectID == nil || *stored.ProjectID != projectID {
+					t.Errorf("stored on project %v, want %s", stored.ProjectID, projectID)
+				}
+				wantHackathon = open.ID
+			}
+			if stored.HackathonID == nil || *stored.HackathonID != wantHackathon {
+				t.Errorf("stored on hackathon %v, want %s", stored.HackathonID, wantHackathon)
+			}
+			count, err := db.Where("action = ? AND resource_id = ?", "upload", stored.ID).Count(&models.AuditLog{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 1 {
+				t.Errorf("%d upload audit events, want 1", count)
+			}
+		})
+	}
+
+	res := b.api(http.MethodPost, "/files", memberToken, strings.NewReader(`{"project_id": "`+project.ID+`"}`))
+	if res.Code != http.StatusBadRequest || testAPIErrorCode(t, res) != "invalid_upload" {
+		t.Errorf("a JSON upload answered %d: %s, want an invalid_upload error", res.Code, res.Body)
+	}
+}
@@ -0,0 +1,108 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+)
+
+// apiTokenAccess are the kinds of access a token can be created with, as the scopes they grant
+var apiTokenAccess = []struct {
+	Scopes string
+	Label  string
+}{
+	{models.APIScopeRead, "Read only"},
+	{models.APIScopeRead + " " + models.APIScopeProjectsWrite, "Read and write projects"},
+	{models.APIScopeAdmin, "Admin"},
+}
+
+// apiTokenExpiryDays are the lifetimes a token can be created with, 0 never expires
+var apiTokenExpiryDays = []int{30, 90, 365, 0}
+
+// issueAPIToken creates an API token for a user from the name, scopes and expires_in_days
+// parameters. The token is returned once, only its hash is stored.
+func issueAPIToken(c buffalo.Context, tx *pop.Connection, user models.User, createdByID nulls.UUID) (*models.APIToken, string, *validate.Errors, error) {
+	verrs := validate.NewErrors()
+	apiToken := &models.APIToken{
+		UserID:      user.ID,
+		Name:        strings.TrimSpace(c.Param("name")),
+		Scopes:      strings.Join(strings.Fields(c.Param("scopes")), " "),
+		CreatedByID: createdByID,
+	}
+	if apiToken.HasScope(models.APIScopeAdmin) && !user.IsOwner() {
+		verrs.Add("scopes", "Only owners can have admin tokens")
+		return nil, "", verrs, nil
+	}
+
+	expiresInDays, err := strconv.Atoi(c.Param("expires_in_days"))
+	if err != nil || expiresInDays < 0 {
+		verrs.Add("expires_in_days", "Pick when the token expires")
+		return nil, "", verrs, nil
+	}
+	if expiresInDays > 0 {
+		apiToken.ExpiresAt = nulls.NewTime(time.Now().AddDate(0, 0, expiresInDays))
+	}
+
+	token, err := randomURLToken()
+	if err != nil {
+		return nil, "", nil, err
+	}
+	apiToken.TokenHash = models.HashAPIToken(token)
+
+	verrs, err = tx.ValidateAndCreate(apiToken)
+	if err != nil || verrs.HasAny() {
+		return nil, "", verrs, err
+	}
+	return apiToken, token, verrs, nil
+}
+
+// ProfileAPITokensCreate issues an API token for the current user. It is only shown on the page this renders.
+func (a *MyApp) ProfileAPITokensCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	apiToken, token, verrs, err := issueAPIToken(c, tx, currentUser, nulls.UUID{})
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.Error())
+		return c.Redirect(http.StatusSeeOther, "/profile")
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "create_api_token", "api_token", apiToken.ID, fmt.Sprintf("Created API token %q with scopes %s", apiToken.Name, apiToken.Scopes))
+
+	c.Set("apiToken", apiToken)
+	c.Set("token", token)
+	c.Set("apiURL", strings.TrimSuffix(a.Options.Host, "/")+apiBasePath)
+	c.Flash().Add("success", "API token created. Copy it now, it won't be shown again.")
+	return c.Render(http.StatusOK, r.HTML("profile/api_token.plush.html"))
+}
+
+// ProfileAPITokensDestroy revokes one of the current user's API tokens
+func (a *MyApp) ProfileAPITokensDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+
+	apiToken, err := a.Repository(tx).APITokenFindByID(c.Param("token_id"))
+	if err != nil || apiToken.UserID != currentUser.ID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("API token not found"))
+	}
+	if err := tx.Destroy(apiToken); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_api_token", "api_token", apiToken.ID, fmt.Sprintf("Revoked API token %q", apiToken.Name))
+
+	c.Flash().Add("success", fmt.Sprintf("API token %q revoked", apiToken.Name))
+	return c.Redirect(http.StatusSeeOther, "/profile")
+}
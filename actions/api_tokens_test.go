@@ -0,0 +1,161 @@
+package actions
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func TestRequireAPIScope(t *testing.T) {
+	db := requireTestDB(t)
+	b := newTestBrowser(t)
+	owner := createTestUser(t, db, models.RoleOwner)
+	hacker := createTestUser(t, db, models.RoleHacker)
+	now := time.Now()
+	hackathon := createTestHackathon(t, db, owner, models.HackathonStatusActive, now, now.Add(time.Hour))
+
+	_, ownerRead := createTestAPIToken(t, db, owner, models.APIScopeRead)
+	_, ownerWrite := createTestAPIToken(t, db, owner, models.APIScopeRead+" "+models.APIScopeProjectsWrite)
+	_, ownerAdmin := createTestAPIToken(t, db, owner, models.APIScopeAdmin)
+	_, hackerRead := createTestAPIToken(t, db, hacker, models.APIScopeRead)
+	_, hackerWrite := createTestAPIToken(t, db, hacker, models.APIScopeRead+" "+models.APIScopeProjectsWrite)
+	// Tokens of people who are no longer owners keep their admin scope but not the owner routes
+	_, hackerAdmin := createTestAPIToken(t, db, hacker, models.APIScopeAdmin)
+
+	// An empty title passes the scope and role checks and fails validation instead
+	invalid := `{"title": ""}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+		error  string
+	}{
+		{"read-only token reads", http.MethodGet, "/hackathons/" + hackathon.ID, hackerRead, http.StatusOK, ""},
+		{"read-only token submits a project", http.MethodPost, "/hackathons/" + hackathon.ID + "/projects", hackerRead, http.StatusForbidden, "insufficient_scope"},
+		{"read-only token uploads a file", http.MethodPost, "/files", hackerRead, http.StatusForbidden, "insufficient_scope"},
+		{"read-only token of an owner creates a hackathon", http.MethodPost, "/hackathons", ownerRead, http.StatusForbidden, "insufficient_scope"},
+		{"projects token submits a project", http.MethodPost, "/hackathons/" + hackathon.ID + "/projects", hackerWrite, http.StatusUnprocessableEntity, "validation_failed"},
+		{"projects token of an owner creates a hackathon", http.MethodPost, "/hackathons", ownerWrite, http.StatusForbidden, "insufficient_scope"},
+		{"projects token of an owner changes a hackathon", http.MethodPatch, "/hackathons/" + hackathon.ID, ownerWrite, http.StatusForbidden, "insufficient_scope"},
+		{"admin token of an owner creates a hackathon", http.MethodPost, "/hackathons", ownerAdmin, http.StatusUnprocessableEntity, "validation_failed"},
+		{"admin token of an owner changes a hackathon", http.MethodPatch, "/hackathons/" + hackathon.ID, ownerAdmin, http.StatusUnprocessableEntity, "validation_failed"},
+		{"admin token of a hacker creates a hackathon", http.MethodPost, "/hackathons", hackerAdmin, http.StatusForbidden, "forbidden"},
+		{"admin token of a hacker changes a hackathon", http.MethodPatch, "/hackathons/" + hackathon.ID, hackerAdmin, http.StatusForbidden, "forbidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.method != http.MethodGet {
+				body = strings.NewReader(invalid)
+			}
+			res := b.api(tt.method, tt.path, tt.token, body)
+			if res.Code != tt.code {
+				t.Fatalf("answered %d, want %d: %s", res.Code, tt.code, res.Body)
+			}
+			if tt.error != "" {
+				if code := testAPIErrorCode(t, res); code != tt.error {
+					t.Errorf("error code %q, want %q", code, tt.error)
+				}
+			}
+		})
+	}
+}
+
+func TestProfileAPITokensCreate_AdminScope(t *testing.T) {
+	db := requireTestDB(t)
+	for _, role := range []string{models.RoleHacker, models.RoleJudge, models.RoleOwner} {
+		user := createTestUser(t, db, role)
+		b := newTestBrowser(t)
+		b.signIn(user)
+		b.post("/profile/api-tokens", url.Values{"name": {"Automation"}, "scopes": {models.APIScopeAdmin}, "expires_in_days": {"30"}})
+
+		count, err := db.Where("user_id = ? AND scopes = ?", user.ID, models.APIScopeAdmin).Count(&models.APIToken{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := role == models.RoleOwner; (count == 1) != want {
+			t.Errorf("a %s asking for an admin token got %d", role, count)
+		}
+	}
+}
+
+func TestRequireAPIToken_Rejected(t *testing.T) {
+	db := requireTestDB(t)
+	b := newTestBrowser(t)
+	user := createTestUser(t, db, models.RoleHacker)
+
+	expired, expiredToken := createTestAPIToken(t, db, user, models.APIScopeRead)
+	expired.ExpiresAt = nulls.NewTime(time.Now().Add(-time.Minute))
+	if err := db.UpdateColumns(expired, "expires_at"); err != nil {
+		t.Fatal(err)
+	}
+	revoked, revokedToken := createTestAPIToken(t, db, user, models.APIScopeRead)
+	if res := b.api(http.MethodGet, "/me", revokedToken, nil); res.Code != http.StatusOK {
+		t.Fatalf("the token before it was revoked answered %d: %s", res.Code, res.Body)
+	}
+	b.signIn(user)
+	if res := b.post("/profile/api-tokens/"+revoked.ID.String(), url.Values{"_method": {http.MethodDelete}}); res.Code != http.StatusSeeOther {
+		t.Fatalf("revoking the token answered %d: %s", res.Code, res.Body)
+	}
+
+	for name, token := range map[string]string{
+		"expired token": expiredToken,
+		"revoked token": revokedToken,
+		"unknown token": "not-a-token",
+	} {
+		res := b.api(http.MethodGet, "/me", token, nil)
+		if res.Code != http.StatusUnauthorized || testAPIErrorCode(t, res) != "invalid_token" {
+			t.Errorf("%s answered %d: %s, want an invalid_token error", name, res.Code, res.Body)
+		}
+	}
+}
+
+func TestRequireAPIToken_AuditsUse(t *testing.T) {
+	db := requireTestDB(t)
+	b := newTestBrowser(t)
+	user := createTestUser(t, db, models.RoleHacker)
+	apiToken, token := createTestAPIToken(t, db, user, models.APIScopeRead)
+
+	uses := func() []models.AuditLog {
+		t.Helper()
+		logs := []models.AuditLog{}
+		if err := db.Where("action = ? AND resource_id = ?", "use_api_token", apiToken.ID.String()).Order("created_at asc").All(&logs); err != nil {
+			t.Fatal(err)
+		}
+		return logs
+	}
+
+	for _, path := range []string{"/me", "/hackathons", "/me"} {
+		if res := b.api(http.MethodGet, path, token, nil); res.Code != http.StatusOK {
+			t.Fatalf("%s answered %d: %s", path, res.Code, res.Body)
+		}
+	}
+	logs := uses()
+	if len(logs) != 1 {
+		t.Fatalf("%d use audit events within a minute, want 1", len(logs))
+	}
+	if logs[0].UserID == nil || *logs[0].UserID != user.ID || !strings.Contains(logs[0].Details, apiToken.ID.String()) || !strings.Contains(logs[0].Details, "GET /api/v1/me") {
+		t.Errorf("use audit event %+v, want the token's user, the token and the request", logs[0])
+	}
+
+	// A minute later the next read is audited again
+	if err := db.RawQuery("UPDATE api_tokens SET last_used_at = ? WHERE id = ?", time.Now().Add(-2*sessionTouchInterval), apiToken.ID).Exec(); err != nil {
+		t.Fatal(err)
+	}
+	if res := b.api(http.MethodGet, "/hackathons", token, nil); res.Code != http.StatusOK {
+		t.Fatalf("listing hackathons answered %d: %s", res.Code, res.Body)
+	}
+	if logs := uses(); len(logs) != 2 || !strings.Contains(logs[1].Details, "GET /api/v1/hackathons") {
+		t.Errorf("use audit events %+v, want a second one for the later read", logs)
+	}
+}
@@ -79,17 +79,39 @@ func App() *buffalo.App {
 		// Load the current user into context and protect routes.
 		myApp.Use(myApp.SetCurrentUser)
 		myApp.Use(myApp.RequirePasswordReset)
+		myApp.Use(myApp.RequireEmailVerification)
+		myApp.Use(myApp.RequireTwoFactorEnrollment)
 		myApp.Use(myApp.Authorize)
 
 		myApp.GET("/", myApp.HomeHandler)
 		myApp.GET("/about", myApp.AboutHandler)
 		myApp.GET("/hackathons", myApp.RequireLogin(myApp.HackathonsIndex))
+		myApp.GET("/schedule", myApp.RequireLogin(myApp.ScheduleIndex))
 		myApp.GET("/hackathons/new", myApp.RequireRoleOwner(myApp.HackathonsNew))
 		myApp.POST("/hackathons", myApp.RequireRoleOwner(myApp.HackathonsCreate))
 		myApp.GET("/hackathons/{hackathon_id}", myApp.HackathonsShow)
+		myApp.GET("/hackathons/{hackathon_id}/calendar.ics", myApp.HackathonsCalendar)
 		myApp.GET("/hackathons/{hackathon_id}/edit", myApp.RequireHackathonOwner(myApp.HackathonsEdit))
 		myApp.PUT("/hackathons/{hackathon_id}", myApp.RequireHackathonOwner(myApp.HackathonsUpdate))
 		myApp.DELETE("/hackathons/{hackathon_id}", myApp.RequireHackathonOwner(myApp.HackathonsDestroy))
+		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/extension", myApp.RequireHackathonOwner(myApp.ProjectExtensionsCreate))
+		myApp.DELETE("/hackathons/{hackathon_id}/projects/{project_id}/extension", myApp.RequireHackathonOwner(myApp.ProjectExtensionsDestroy))
+		myApp.POST("/hackathons/{hackathon_id}/schedule", myApp.RequireHackathonOwner(myApp.ScheduleItemsCreate))
+		myApp.PUT("/hackathons/{hackathon_id}/schedule/{item_id}", myApp.RequireHackathonOwner(myApp.ScheduleItemsUpdate))
+		myApp.DELETE("/hackathons/{hackathon_id}/schedule/{item_id}", myApp.RequireHackathonOwner(myApp.ScheduleItemsDestroy))
+		myApp.GET("/hackathons/{hackathon_id}/judging", myApp.RequireHackathonOwner(myApp.JudgingIndex))
+		myApp.POST("/hackathons/{hackathon_id}/judging/criteria", myApp.RequireHackathonOwner(myApp.JudgingCriteriaCreate))
+		myApp.DELETE("/hackathons/{hackathon_id}/judging/criteria/{criterion_id}", myApp.RequireHackathonOwner(myApp.JudgingCriteriaDestroy))
+		myApp.POST("/hackathons/{hackathon_id}/judging/assignments", myApp.RequireHackathonOwner(myApp.JudgeAssignmentsCreate))
+		myApp.DELETE("/hackathons/{hackathon_id}/judging/assignments/{assignment_id}", myApp.RequireHackathonOwner(myApp.JudgeAssignmentsDestroy))
+		myApp.POST("/hackathons/{hackathon_id}/judging/publish", myApp.RequireHackathonOwner(myApp.JudgingTogglePublish))
+		myApp.POST("/hackathons/{hackathon_id}/voting", myApp.RequireHackathonOwner(myApp.VotingToggle))
+		myApp.GET("/hackathons/{hackathon_id}/awards", myApp.RequireHackathonOwner(myApp.AwardsIndex))
+		myApp.POST("/hackathons/{hackathon_id}/awards", myApp.RequireHackathonOwner(myApp.AwardsCreate))
+		myApp.POST("/hackathons/{hackathon_id}/awards/publish", myApp.RequireHackathonOwner(myApp.AwardsTogglePublish))
+		myApp.DELETE("/hackathons/{hackathon_id}/awards/{award_id}", myApp.RequireHackathonOwner(myApp.AwardsDestroy))
+		myApp.POST("/hackathons/{hackathon_id}/awards/{award_id}/winners", myApp.RequireHackathonOwner(myApp.AwardWinnersCreate))
+		myApp.DELETE("/hackathons/{hackathon_id}/awards/{award_id}/winners/{winner_id}", myApp.RequireHackathonOwner(myApp.AwardWinnersDestroy))
 		myApp.GET("/hackathons/{hackathon_id}/projects", myApp.ProjectsIndex)
 		myApp.GET("/hackathons/{hackathon_id}/projects/new", myApp.ProjectsNew)
 		myApp.POST("/hackathons/{hackathon_id}/projects", myApp.ProjectsCreate)
@@ -99,12 +121,33 @@ func App() *buffalo.App {
 		myApp.GET("/hackathons/{hackathon_id}/projects/{project_id}/edit", myApp.ProjectsEdit)
 		myApp.PUT("/hackathons/{hackathon_id}/projects/{project_id}", myApp.ProjectsUpdate)
 		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/toggle-presenting", myApp.ProjectsTogglePresenting)
+		myApp.GET("/hackathons/{hackathon_id}/projects/{project_id}/score", myApp.JudgeScoresEdit)
+		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/score", myApp.JudgeScoresUpdate)
+		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/vote", myApp.VotesCreate)
+		myApp.DELETE("/hackathons/{hackathon_id}/projects/{project_id}/vote", myApp.VotesDestroy)
 		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/join", myApp.ProjectMembershipsCreate)
 		myApp.DELETE("/hackathons/{hackathon_id}/projects/{project_id}/leave", myApp.ProjectMembershipsDestroy)
+		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/join-requests", myApp.JoinRequestsCreate)
+		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/join-requests/{request_id}/approve", myApp.JoinRequestsApprove)
+		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/join-requests/{request_id}/decline", myApp.JoinRequestsDecline)
+		myApp.POST("/hackathons/{hackathon_id}/projects/{project_id}/invitations", myApp.ProjectInvitationsCreate)
+		myApp.DELETE("/hackathons/{hackathon_id}/projects/{project_id}/invitations/{invitation_id}", myApp.ProjectInvitationsDestroy)
+		myApp.POST("/invitations/{invitation_id}/accept", myApp.ProjectInvitationsAccept)
+		myApp.POST("/invitations/{invitation_id}/decline", myApp.ProjectInvitationsDecline)
 		myApp.GET("/profile", myApp.ProfileShow)
 		myApp.GET("/profile/edit", myApp.ProfileEdit)
 		myApp.PUT("/profile", myApp.ProfileUpdate)
 		myApp.POST("/profile/change-password", myApp.ProfileChangePassword)
+		myApp.POST("/profile/calendar-token", myApp.ProfileCalendarTokenCreate)
+		myApp.GET("/profile/two-factor", myApp.TwoFactorShow)
+		myApp.POST("/profile/two-factor", myApp.TwoFactorCreate)
+		myApp.DELETE("/profile/two-factor", myApp.TwoFactorDestroy)
+		myApp.POST("/profile/two-factor/recovery-codes", myApp.TwoFactorRecoveryCodesCreate)
+		myApp.DELETE("/profile/sessions", myApp.ProfileSessionsDestroyAll)
+		myApp.DELETE("/profile/sessions/{session_id}", myApp.ProfileSessionsDestroy)
+		myApp.POST("/profile/api-tokens", myApp.ProfileAPITokensCreate)
+		myApp.DELETE("/profile/api-tokens/{token_id}", myApp.ProfileAPITokensDestroy)
+		myApp.GET("/calendar/{token}.ics", myApp.CalendarFeed)
 		myApp.GET("/users/new", myApp.UsersNew)
 		myApp.POST("/users", myApp.UsersCreate)
 		myApp.GET("/users/{user_id}/edit", myApp.RequireRoleOwner(myApp.UsersEdit)).Name("userEditPath")
@@ -117,12 +160,33 @@ func App() *buffalo.App {
 		myApp.DELETE("/files/{file_id}", myApp.RequireLogin(myApp.FilesDestroy))
 		myApp.GET("/signin", myApp.AuthNew)
 		myApp.POST("/signin", myApp.AuthCreate)
+		myApp.GET("/signin/two-factor", myApp.AuthTwoFactorNew)
+		myApp.POST("/signin/two-factor", myApp.AuthTwoFactorCreate)
+		myApp.GET("/signin/sso", myApp.AuthOIDCNew)
+		myApp.GET("/signin/sso/callback", myApp.AuthOIDCCallback)
 		myApp.DELETE("/signout", myApp.AuthDestroy)
 		myApp.GET("/reset-password", myApp.ResetPasswordNew)
 		myApp.POST("/reset-password", myApp.ResetPasswordCreate)
+		myApp.GET("/forgot-password", myApp.ForgotPasswordNew)
+		myApp.POST("/forgot-password", myApp.ForgotPasswordCreate)
+		myApp.GET("/forgot-password/{token}", myApp.ForgotPasswordEdit)
+		myApp.POST("/forgot-password/{token}", myApp.ForgotPasswordUpdate)
+		myApp.GET("/verify-email", myApp.EmailVerificationShow)
+		myApp.POST("/verify-email", myApp.EmailVerificationCreate)
+		myApp.GET("/verify-email/{token}", myApp.EmailVerificationConfirm)
+		myApp.GET("/invitations/{token}", myApp.InvitationsShow)
 
 		// Allow unauthenticated access to Home, About, and Auth endpoints
-		myApp.Middleware.Skip(myApp.Authorize, myApp.HomeHandler, myApp.AboutHandler, myApp.UsersNew, myApp.UsersCreate, myApp.AuthNew, myApp.AuthCreate, myApp.ResetPasswordNew, myApp.ResetPasswordCreate)
+		myApp.Middleware.Skip(myApp.Authorize, myApp.HomeHandler, myApp.AboutHandler, myApp.UsersNew, myApp.UsersCreate, myApp.AuthNew, myApp.AuthCreate, myApp.AuthTwoFactorNew, myApp.AuthTwoFactorCreate, myApp.AuthOIDCNew, myApp.AuthOIDCCallback, myApp.ResetPasswordNew, myApp.ResetPasswordCreate, myApp.ForgotPasswordNew, myApp.ForgotPasswordCreate, myApp.ForgotPasswordEdit, myApp.ForgotPasswordUpdate)
+
+		// Calendar clients authenticate personal feeds with the secret token in the URL
+		myApp.Middleware.Skip(myApp.Authorize, myApp.CalendarFeed)
+
+		// Verification links work without a session so they can be opened on another device
+		myApp.Middleware.Skip(myApp.Authorize, myApp.EmailVerificationConfirm)
+
+		// Invitation links are how new people get an account when public registration is closed
+		myApp.Middleware.Skip(myApp.Authorize, myApp.InvitationsShow)
 
 		// Admin routes
 		admin := myApp.Group("/admin")
@@ -135,20 +199,91 @@ func App() *buffalo.App {
 		admin.GET("/users/{user_id}/edit", myApp.AdminUsersEdit)
 		admin.PUT("/users/{user_id}", myApp.AdminUsersUpdate)
 		admin.DELETE("/users/{user_id}", myApp.AdminUsersDestroy)
+		admin.DELETE("/users/{user_id}/two-factor", myApp.AdminUsersTwoFactorDestroy)
+		admin.POST("/users/{user_id}/verify-email", myApp.AdminUsersVerifyEmail)
+		admin.DELETE("/users/{user_id}/sessions", myApp.AdminUsersSessionsDestroy)
 		admin.GET("/hackathons", myApp.AdminHackathonsIndex)
 		admin.GET("/projects", myApp.AdminProjectsIndex)
+		admin.POST("/projects/{project_id}/approve", myApp.AdminProjectsApprove)
+		admin.POST("/projects/{project_id}/reject", myApp.AdminProjectsReject)
 		admin.GET("/emails", myApp.AdminEmailsIndex)
 		admin.GET("/config", myApp.AdminConfigIndex)
 		admin.PUT("/config", myApp.AdminConfigUpdate).Name("adminConfigPath")
 		admin.GET("/passwords", myApp.AdminPasswordsIndex)
 		admin.GET("/passwords/search", myApp.AdminPasswordsSearch)
 		admin.POST("/passwords/force-reset", myApp.AdminPasswordsForceReset)
+		admin.POST("/passwords/unlock", myApp.AdminPasswordsUnlock)
 		admin.POST("/passwords/update-policy", myApp.AdminPasswordsUpdatePolicy)
 		admin.GET("/domains", myApp.AdminDomainsIndex)
 		admin.POST("/domains", myApp.AdminDomainsCreate)
 		admin.PUT("/domains/{domain_id}", myApp.AdminDomainsUpdate)
 		admin.DELETE("/domains/{domain_id}", myApp.AdminDomainsDestroy)
 		admin.GET("/audit-logs", myApp.AdminAuditLogsIndex)
+		admin.GET("/scim", myApp.AdminSCIMIndex)
+		admin.POST("/scim/tokens", myApp.AdminSCIMTokensCreate)
+		admin.DELETE("/scim/tokens/{token_id}", myApp.AdminSCIMTokensDestroy)
+		admin.GET("/invitations", myApp.AdminInvitationsIndex)
+		admin.POST("/invitations", myApp.AdminInvitationsCreate)
+		admin.DELETE("/invitations/{invitation_id}", myApp.AdminInvitationsDestroy)
+		admin.GET("/api-tokens", myApp.AdminAPITokensIndex)
+		admin.POST("/api-tokens", myApp.AdminAPITokensCreate)
+		admin.DELETE("/api-tokens/{token_id}", myApp.AdminAPITokensDestroy)
+		admin.POST("/service-accounts", myApp.AdminServiceAccountsCreate)
+
+		// SCIM provisioning for identity providers. They authenticate with a bearer token
+		// instead of a session, so none of the browser middleware applies.
+		scim := myApp.Group(scimBasePath)
+		scim.Middleware.Clear()
+		scim.Use(myApp.forceSSL())
+		scim.Use(popmw.Transaction(models.DB))
+		scim.Use(myApp.RequireSCIMToken)
+		scim.GET("/ServiceProviderConfig", myApp.SCIMServiceProviderConfig)
+		scim.GET("/Users", myApp.SCIMUsersIndex)
+		scim.POST("/Users", myApp.SCIMUsersCreate)
+		scim.GET("/Users/{user_id}", myApp.SCIMUsersShow)
+		scim.PUT("/Users/{user_id}", myApp.SCIMUsersUpdate)
+		scim.PATCH("/Users/{user_id}", myApp.SCIMUsersPatch)
+		scim.DELETE("/Users/{user_id}", myApp.SCIMUsersDestroy)
+		scim.GET("/Groups", myApp.SCIMGroupsIndex)
+		scim.GET("/Groups/{group_id}", myApp.SCIMGroupsShow)
+		scim.PUT("/Groups/{group_id}", myApp.SCIMGroupsUpdate)
+		scim.PATCH("/Groups/{group_id}", myApp.SCIMGroupsPatch)
+
+		// JSON API for scripts and integrations. Clients authenticate with an API token
+		// instead of a session, so like SCIM none of the browser middleware applies.
+		// Every token can read, changes need the scope that covers them.
+		api := myApp.Group(apiBasePath)
+		api.Middleware.Clear()
+		api.Use(myApp.forceSSL())
+		apiTransaction := popmw.Transaction(models.DB)
+		api.Use(apiTransaction)
+		api.Use(myApp.RequireAPIToken)
+		api.Middleware.Skip(apiTransaction, myApp.APIOpenAPI)
+		api.Middleware.Skip(myApp.RequireAPIToken, myApp.APIOpenAPI)
+		adminScope := myApp.RequireAPIScope(models.APIScopeAdmin)
+		projectsWriteScope := myApp.RequireAPIScope(models.APIScopeProjectsWrite)
+		api.GET("/openapi.json", myApp.APIOpenAPI)
+		api.GET("/me", myApp.APIMe)
+		api.GET("/hackathons", myApp.APIHackathonsIndex)
+		api.POST("/hackathons", adminScope(myApp.RequireAPIRoleOwner(myApp.APIHackathonsCreate)))
+		api.GET("/hackathons/{hackathon_id}", myApp.APIHackathonsShow)
+		api.PATCH("/hackathons/{hackathon_id}", adminScope(myApp.RequireAPIHackathonOwner(myApp.APIHackathonsUpdate)))
+		api.DELETE("/hackathons/{hackathon_id}", adminScope(myApp.RequireAPIHackathonOwner(myApp.APIHackathonsDestroy)))
+		api.GET("/hackathons/{hackathon_id}/projects", myApp.APIProjectsIndex)
+		api.POST("/hackathons/{hackathon_id}/projects", projectsWriteScope(myApp.APIProjectsCreate))
+		api.GET("/projects/{project_id}", myApp.APIProjectsShow)
+		api.PATCH("/projects/{project_id}", projectsWriteScope(myApp.APIProjectsUpdate))
+		api.GET("/projects/{project_id}/members", myApp.APIProjectMembersIndex)
+		api.POST("/projects/{project_id}/members", projectsWriteScope(myApp.APIProjectMembersCreate))
+		api.DELETE("/projects/{project_id}/members/{user_id}", projectsWriteScope(myApp.APIProjectMembersDestroy))
+		api.GET("/files", myApp.APIFilesIndex)
+		api.POST("/files", projectsWriteScope(myApp.APIFilesCreate))
+		api.GET("/files/{file_id}", myApp.APIFilesShow)
+
+		// Background jobs run on Buffalo's worker alongside the web server.
+		if !myApp.WorkerOff {
+			myApp.registerWorkers()
+		}
 
 		myApp.ServeFiles("/", http.FS(public.FS())) // serve files from the public directory
 	})
@@ -1,9 +1,11 @@
 package actions
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/arxdsilva/hackathon/models"
 
@@ -12,7 +14,10 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-const sessionCurrentUserID = "current_user_id"
+const (
+	sessionCurrentUserID = "current_user_id"
+	sessionID            = "session_id"
+)
 
 // SetCurrentUser loads the current user from the session and attaches it to the context.
 func (a *MyApp) SetCurrentUser(next buffalo.Handler) buffalo.Handler {
@@ -20,9 +25,9 @@ func (a *MyApp) SetCurrentUser(next buffalo.Handler) buffalo.Handler {
 		if uid, ok := c.Session().Get(sessionCurrentUserID).(string); ok && uid != "" {
 			tx, ok := c.Value("tx").(*pop.Connection)
 			if ok {
-				var u models.User
-				if err := tx.Find(&u, uid); err == nil {
-					c.Set("current_user", u)
+				if u, session, ok := a.activeSession(c, tx, uid); ok {
+					c.Set("current_user", *u)
+					c.Set("current_session", *session)
 				}
 			}
 		}
@@ -69,6 +74,14 @@ func (a *MyApp) RequirePasswordReset(next buffalo.Handler) buffalo.Handler {
 
 // AuthNew renders the sign-in form.
 func (a *MyApp) AuthNew(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+
+	c.Set("config", config)
 	c.Set("user", models.User{})
 	return c.Render(http.StatusOK, r.HTML("auth/new.plush.html"))
 }
@@ -85,40 +98,84 @@ func (a *MyApp) AuthCreate(c buffalo.Context) error {
 	u.Email = strings.ToLower(strings.TrimSpace(u.Email))
 
 	repoManager := a.Repository(tx)
-	dbUser, err := repoManager.UserFindByEmail(u.Email)
+
+	if redirected, err := ssoOnly(c, tx); redirected || err != nil {
+		return err
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+
+	// Wait out earlier failures before the password is even checked
+	ip := clientIP(c)
+	wait, locked, err := loginThrottle(repoManager, config, u.Email, ip, time.Now())
 	if err != nil {
-		c.Flash().Add("danger", "Invalid email or password")
+		return err
+	}
+	if wait > 0 {
+		c.Flash().Add("danger", loginThrottleMessage(wait, locked))
 		return c.Redirect(http.StatusFound, "/signin")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(dbUser.PasswordHash), []byte(u.Password)); err != nil {
+	dbUser, err := a.authenticatePassword(c, tx, config, u.Email, u.Password)
+	if err != nil {
+		return err
+	}
+	if dbUser == nil {
 		// Log failed login attempt
 		logAuditEvent(tx, c, nil, "login_failed", "user", nil, fmt.Sprintf("Failed login attempt for email: %s", u.Email))
 
-		// Find an admin to contact for password reset (order by creation date to get the first admin)
-		adminUser := &models.User{}
-		if err := tx.Where("role = ?", models.RoleOwner).Order("created_at ASC").First(adminUser); err == nil {
-			c.Flash().Add("danger", fmt.Sprintf("Invalid email or password. If you've forgotten your password, please contact the administrator at %s to reset it.", adminUser.Email))
-		} else {
-			c.Flash().Add("danger", "Invalid email or password")
+		// Unknown addresses count too so a lockout doesn't reveal which accounts exist
+		locked, err := a.recordLoginFailure(c, tx, config, u.Email, ip)
+		if err != nil {
+			return err
+		}
+		if locked {
+			c.Flash().Add("danger", loginThrottleMessage(config.LoginLockoutDuration(), true))
+			return c.Redirect(http.StatusFound, "/signin")
 		}
+
+		c.Flash().Add("danger", "Invalid email or password. If you've forgotten your password, you can reset it below.")
 		return c.Redirect(http.StatusFound, "/signin")
 	}
 
-	// Check if user needs to reset password
-	if dbUser.ForcePasswordReset {
-		// Clear session and set user for password reset flow
-		c.Session().Clear()
-		c.Session().Set(sessionCurrentUserID, dbUser.ID.String())
-		return c.Redirect(http.StatusFound, "/reset-password?required=true")
-	}
+	return beginSignIn(c, tx, dbUser)
+}
 
-	// Log successful login
-	logAuditEvent(tx, c, &dbUser.ID, "login", "user", &dbUser.ID, fmt.Sprintf("User logged in: %s (%s)", dbUser.Name, dbUser.Email))
+// authenticatePassword returns the account an email and password sign in to, or nil when they don't match.
+// When LDAP is set up the directory is asked first, local passwords only apply to emails it doesn't know
+// or while it can't be reached, and never to accounts that came from it.
+func (a *MyApp) authenticatePassword(c buffalo.Context, tx *pop.Connection, config *models.CompanyConfiguration, email, password string) (*models.User, error) {
+	if config.LDAPConfigured() {
+		identity, err := ldapAuthenticate(config, email, password)
+		switch {
+		case err == nil:
+			user, err := a.ldapUser(c, tx, identity)
+			if err != nil {
+				c.Logger().Errorf("LDAP sign-in for %s failed: %v", email, err)
+				return nil, nil
+			}
+			return user, nil
+		case errors.Is(err, errLDAPInvalidCredentials):
+			return nil, nil
+		case !errors.Is(err, errLDAPUserNotFound):
+			c.Logger().Errorf("LDAP directory unavailable, falling back to local passwords: %v", err)
+		}
+	}
 
-	c.Session().Set(sessionCurrentUserID, dbUser.ID.String())
-	c.Flash().Add("success", "Welcome back!")
-	return c.Redirect(http.StatusFound, "/")
+	user, err := a.Repository(tx).UserFindByEmail(email)
+	if err != nil {
+		return nil, nil
+	}
+	if user.LDAPDN.Valid {
+		return nil, nil
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, nil
+	}
+	return user, nil
 }
 
 // AuthDestroy signs the user out.
@@ -128,6 +185,11 @@ func (a *MyApp) AuthDestroy(c buffalo.Context) error {
 		// Log logout
 		logAuditEvent(tx, c, &currentUser.ID, "logout", "user", &currentUser.ID, fmt.Sprintf("User logged out: %s (%s)", currentUser.Name, currentUser.Email))
 	}
+	if session, ok := c.Value("current_session").(models.UserSession); ok {
+		if err := tx.Destroy(&session); err != nil {
+			return err
+		}
+	}
 
 	c.Session().Clear()
 	c.Flash().Add("success", "Signed out")
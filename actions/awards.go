@@ -0,0 +1,211 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// AwardsIndex renders the award categories and winners of a hackathon (owner-only)
+func (a *MyApp) AwardsIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	awards, err := repoManager.AwardFindByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	projects, err := repoManager.ProjectFindByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	c.Set("hackathon", hackathon)
+	c.Set("awards", awards)
+	c.Set("projects", projects)
+	return c.Render(http.StatusOK, r.HTML("awards/index.plush.html"))
+}
+
+// AwardsCreate adds an award category to a hackathon (owner-only)
+func (a *MyApp) AwardsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	hackathonID := c.Param("hackathon_id")
+
+	award := &models.Award{
+		HackathonID: hackathonID,
+		Name:        strings.TrimSpace(c.Param("name")),
+	}
+	if description := strings.TrimSpace(c.Param("description")); description != "" {
+		award.Description = nulls.NewString(description)
+	}
+	if prize := strings.TrimSpace(c.Param("prize")); prize != "" {
+		award.Prize = nulls.NewString(prize)
+	}
+
+	verrs, err := tx.ValidateAndCreate(award)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.String())
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathonID)
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "create", "award", &award.ID, fmt.Sprintf("Award category created: %s", award.Name))
+
+	c.Flash().Add("success", "Award category added!")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathonID)
+}
+
+// AwardsDestroy removes an award category and its winners (owner-only)
+func (a *MyApp) AwardsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	hackathonID := c.Param("hackathon_id")
+
+	award, err := repoManager.AwardFindByID(c.Param("award_id"))
+	if err != nil || award.HackathonID != hackathonID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("award not found"))
+	}
+
+	if err := tx.Destroy(award); err != nil {
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "delete", "award", &award.ID, fmt.Sprintf("Award category deleted: %s", award.Name))
+
+	c.Flash().Add("success", "Award category removed.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathonID)
+}
+
+// AwardWinnersCreate marks a project as a winner of an award (owner-only)
+func (a *MyApp) AwardWinnersCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	hackathonID := c.Param("hackathon_id")
+
+	award, err := repoManager.AwardFindByID(c.Param("award_id"))
+	if err != nil || award.HackathonID != hackathonID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("award not found"))
+	}
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != hackathonID {
+		c.Flash().Add("danger", "Project not found in this hackathon.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathonID)
+	}
+
+	winner := &models.AwardWinner{
+		AwardID:   award.ID,
+		ProjectID: project.ID,
+	}
+	if err := tx.Create(winner); err != nil {
+		if isDuplicateError(err) {
+			c.Flash().Add("warning", "This project already won the award.")
+			return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathonID)
+		}
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "assign_award", "award", &award.ID, fmt.Sprintf("Project %s selected as winner of: %s", project.Name, award.Name))
+
+	c.Flash().Add("success", fmt.Sprintf("%s wins %s!", project.Name, award.Name))
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathonID)
+}
+
+// AwardWinnersDestroy removes a winning project from an award (owner-only)
+func (a *MyApp) AwardWinnersDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	hackathonID := c.Param("hackathon_id")
+
+	award, err := repoManager.AwardFindByID(c.Param("award_id"))
+	if err != nil || award.HackathonID != hackathonID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("award not found"))
+	}
+
+	winner, err := repoManager.AwardFindWinnerByID(c.Param("winner_id"))
+	if err != nil || winner.AwardID != award.ID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("award winner not found"))
+	}
+
+	if err := tx.Destroy(winner); err != nil {
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "unassign_award", "award", &award.ID, fmt.Sprintf("Project %s removed from winners of: %s", winner.ProjectID, award.Name))
+
+	c.Flash().Add("success", "Winner removed.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathonID)
+}
+
+// AwardsTogglePublish announces or retracts the award winners of a hackathon (owner-only)
+func (a *MyApp) AwardsTogglePublish(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	awards, err := repoManager.AwardFindByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	hackathon.AwardsPublished = !hackathon.AwardsPublished
+	if err := tx.Update(hackathon); err != nil {
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	if hackathon.AwardsPublished {
+		logAuditEvent(tx, c, &currentUser.ID, "announce_awards", "hackathon", hackathon.ID, fmt.Sprintf("Winners announced for %s: %s", hackathon.Title, awardAnnouncement(*awards)))
+		c.Flash().Add("success", "Winners announced!")
+	} else {
+		logAuditEvent(tx, c, &currentUser.ID, "retract_awards", "hackathon", hackathon.ID, fmt.Sprintf("Winner announcement retracted: %s", hackathon.Title))
+		c.Flash().Add("success", "Winner announcement retracted.")
+	}
+
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/awards", hackathon.ID)
+}
+
+// awardAnnouncement summarizes the winners of each award for the audit log
+func awardAnnouncement(awards models.Awards) string {
+	parts := make([]string, 0, len(awards))
+	for _, award := range awards {
+		names := make([]string, 0, len(award.Winners))
+		for _, winner := range award.Winners {
+			if winner.Project != nil {
+				names = append(names, winner.Project.Name)
+			} else {
+				names = append(names, winner.ProjectID)
+			}
+		}
+		if len(names) == 0 {
+			names = append(names, "no winner")
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", award.Name, strings.Join(names, ", ")))
+	}
+	if len(parts) == 0 {
+		return "no award categories"
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,243 @@
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// calendarEvent is a single VEVENT of an iCalendar feed
+type calendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+}
+
+// hackathonCalendarEvents returns the events of a hackathon: the event itself plus its schedule items
+func hackathonCalendarEvents(host string, hackathon models.Hackathon, items models.ScheduleItems) []calendarEvent {
+	hackathonURL := fmt.Sprintf("%s/hackathons/%s", host, hackathon.ID)
+	events := []calendarEvent{{
+		UID:         fmt.Sprintf("hackathon-%s@hackathon", hackathon.ID),
+		Summary:     hackathon.Title,
+		Description: hackathon.Description,
+		URL:         hackathonURL,
+		Start:       hackathon.StartDate,
+		End:         hackathon.EndDate,
+	}}
+
+	for _, item := range items {
+		event := calendarEvent{
+			UID:     fmt.Sprintf("schedule-%s@hackathon", item.ID),
+			Summary: fmt.Sprintf("%s: %s", hackathon.Title, item.Title),
+			URL:     hackathonURL,
+			Start:   item.StartsAt,
+		}
+		if item.EndsAt.Valid {
+			event.End = item.EndsAt.Time
+		}
+		if item.Location.Valid {
+			event.Location = item.Location.String
+		}
+		if item.Link.Valid {
+			event.URL = item.Link.String
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// writeCalendar writes events as an RFC 5545 VCALENDAR
+func writeCalendar(w io.Writer, name string, events []calendarEvent) error {
+	var b strings.Builder
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	writeCalendarLine(&b, "BEGIN:VCALENDAR")
+	writeCalendarLine(&b, "VERSION:2.0")
+	writeCalendarLine(&b, "PRODID:-//arxdsilva//hackathon//EN")
+	writeCalendarLine(&b, "CALSCALE:GREGORIAN")
+	writeCalendarLine(&b, "METHOD:PUBLISH")
+	writeCalendarLine(&b, "X-WR-CALNAME:"+escapeCalendarText(name))
+	for _, event := range events {
+		writeCalendarLine(&b, "BEGIN:VEVENT")
+		writeCalendarLine(&b, "UID:"+event.UID)
+		writeCalendarLine(&b, "DTSTAMP:"+stamp)
+		writeCalendarLine(&b, "DTSTART:"+event.Start.UTC().Format("20060102T150405Z"))
+		if event.End.After(event.Start) {
+			writeCalendarLine(&b, "DTEND:"+event.End.UTC().Format("20060102T150405Z"))
+		}
+		writeCalendarLine(&b, "SUMMARY:"+escapeCalendarText(event.Summary))
+		if event.Description != "" {
+			writeCalendarLine(&b, "DESCRIPTION:"+escapeCalendarText(event.Description))
+		}
+		if event.Location != "" {
+			writeCalendarLine(&b, "LOCATION:"+escapeCalendarText(event.Location))
+		}
+		if event.URL != "" {
+			writeCalendarLine(&b, "URL:"+event.URL)
+		}
+		writeCalendarLine(&b, "END:VEVENT")
+	}
+	writeCalendarLine(&b, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeCalendarLine writes a content line, folded at 75 octets and terminated by CRLF
+func writeCalendarLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		// never split a multi-byte UTF-8 sequence
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading space of a continuation line counts towards the limit
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+// escapeCalendarText escapes a TEXT property value
+func escapeCalendarText(s string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+		"\r", "",
+	).Replace(s)
+}
+
+// renderCalendar renders events as a text/calendar response
+func renderCalendar(c buffalo.Context, filename, name string, events []calendarEvent) error {
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	return c.Render(http.StatusOK, r.Func("text/calendar; charset=utf-8", func(w io.Writer, d render.Data) error {
+		return writeCalendar(w, name, events)
+	}))
+}
+
+// generateCalendarToken returns a random secret for a personal calendar feed URL
+func generateCalendarToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// calendarFeedURL returns the subscription URL of a user's personal calendar feed
+func (a *MyApp) calendarFeedURL(user models.User) string {
+	if !user.CalendarToken.Valid {
+		return ""
+	}
+	return fmt.Sprintf("%s/calendar/%s.ics", strings.TrimSuffix(a.Options.Host, "/"), user.CalendarToken.String)
+}
+
+// HackathonsCalendar exports a hackathon and its schedule as an iCalendar file
+func (a *MyApp) HackathonsCalendar(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	items, err := repoManager.ScheduleItemFindByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	events := hackathonCalendarEvents(strings.TrimSuffix(a.Options.Host, "/"), *hackathon, *items)
+	return renderCalendar(c, fmt.Sprintf("hackathon-%s.ics", hackathon.ID), hackathon.Title, events)
+}
+
+// CalendarFeed serves a user's personal iCalendar feed, authenticated by the secret token in the URL
+func (a *MyApp) CalendarFeed(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	token := c.Param("token")
+	if token == "" {
+		return c.Error(http.StatusNotFound, fmt.Errorf("calendar not found"))
+	}
+
+	user, err := repoManager.UserFindByCalendarToken(token)
+	if err != nil {
+		return c.Error(http.StatusNotFound, fmt.Errorf("calendar not found"))
+	}
+
+	ownedHackathons, projects, err := loadUserParticipation(tx, repoManager, *user)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	ids := []interface{}{}
+	for _, hackathon := range *ownedHackathons {
+		if !seen[hackathon.ID] {
+			seen[hackathon.ID] = true
+			ids = append(ids, hackathon.ID)
+		}
+	}
+	for _, project := range projects {
+		if !seen[project.HackathonID] {
+			seen[project.HackathonID] = true
+			ids = append(ids, project.HackathonID)
+		}
+	}
+
+	hackathons, err := repoManager.HackathonFindByIDsWithSchedule(ids)
+	if err != nil {
+		return err
+	}
+
+	host := strings.TrimSuffix(a.Options.Host, "/")
+	events := []calendarEvent{}
+	for _, hackathon := range *hackathons {
+		events = append(events, hackathonCalendarEvents(host, hackathon, hackathon.ScheduleItems)...)
+	}
+
+	return renderCalendar(c, "hackathons.ics", "My Hackathons", events)
+}
+
+// ProfileCalendarTokenCreate issues a new personal calendar feed URL, revoking the previous one
+func (a *MyApp) ProfileCalendarTokenCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	user := c.Value("current_user").(models.User)
+
+	token, err := generateCalendarToken()
+	if err != nil {
+		return err
+	}
+
+	user.CalendarToken = nulls.NewString(token)
+	if err := tx.UpdateColumns(&user, "calendar_token", "updated_at"); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &user.ID, "regenerate_calendar_token", "user", &user.ID, "Personal calendar feed URL regenerated")
+
+	c.Flash().Add("success", "Your personal calendar link is ready. Any previous link no longer works.")
+	return c.Redirect(http.StatusSeeOther, "/profile")
+}
@@ -0,0 +1,168 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/mailers"
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+const (
+	emailVerificationPurpose  = "email_verification"
+	sessionVerificationSentAt = "email_verification_sent_at"
+
+	// emailVerificationTTL is how long a verification link can be used
+	emailVerificationTTL = 24 * time.Hour
+	// verificationResendInterval keeps users from flooding their inbox with resends
+	verificationResendInterval = time.Minute
+)
+
+// RequireEmailVerification keeps signed-in users on the verification page until they confirm their address.
+func (a *MyApp) RequireEmailVerification(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		currentUser, ok := c.Value("current_user").(models.User)
+		if !ok || currentUser.IsEmailVerified() {
+			return next(c)
+		}
+
+		path := c.Request().URL.Path
+		for _, allowed := range []string{"/verify-email", "/signout", "/reset-password", "/assets/"} {
+			if strings.HasPrefix(path, allowed) {
+				return next(c)
+			}
+		}
+
+		tx, ok := c.Value("tx").(*pop.Connection)
+		if !ok {
+			return next(c)
+		}
+		config, err := models.GetDefaultConfig(tx)
+		if err != nil {
+			return err
+		}
+		if !config.RequireEmailVerification {
+			return next(c)
+		}
+
+		return c.Redirect(http.StatusFound, "/verify-email")
+	}
+}
+
+// emailVerificationToken signs a user's ID and the link expiry. The token is bound to the
+// current email address so changing it invalidates links sent earlier.
+func emailVerificationToken(user models.User, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%s:%d", user.ID, expiresAt.Unix())
+	return signToken(emailVerificationPurpose, payload, strings.ToLower(user.Email))
+}
+
+// sendEmailVerification emails a fresh verification link to a user
+func (a *MyApp) sendEmailVerification(user models.User) error {
+	expiresAt := time.Now().Add(emailVerificationTTL)
+	verifyURL := fmt.Sprintf("%s/verify-email/%s", strings.TrimSuffix(a.Options.Host, "/"), emailVerificationToken(user, expiresAt))
+	return mailers.SendEmailVerification(user, verifyURL, expiresAt)
+}
+
+// EmailVerificationShow asks a signed-in user to confirm their email address.
+func (a *MyApp) EmailVerificationShow(c buffalo.Context) error {
+	user := c.Value("current_user").(models.User)
+	if user.IsEmailVerified() {
+		return c.Redirect(http.StatusFound, "/")
+	}
+
+	c.Set("user", user)
+	return c.Render(http.StatusOK, r.HTML("auth/verify_email.plush.html"))
+}
+
+// EmailVerificationCreate sends the signed-in user a new verification link.
+func (a *MyApp) EmailVerificationCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	user := c.Value("current_user").(models.User)
+
+	if user.IsEmailVerified() {
+		return c.Redirect(http.StatusSeeOther, "/")
+	}
+
+	if sentAt, ok := c.Session().Get(sessionVerificationSentAt).(int64); ok && time.Since(time.Unix(sentAt, 0)) < verificationResendInterval {
+		c.Flash().Add("warning", "A verification email was just sent. Please wait a minute before requesting another one.")
+		return c.Redirect(http.StatusSeeOther, "/verify-email")
+	}
+
+	if err := a.sendEmailVerification(user); err != nil {
+		c.Logger().Errorf("Failed to send verification email to %s: %v", user.Email, err)
+		c.Flash().Add("danger", "We couldn't send the verification email. Please try again later.")
+		return c.Redirect(http.StatusSeeOther, "/verify-email")
+	}
+	c.Session().Set(sessionVerificationSentAt, time.Now().Unix())
+
+	logAuditEvent(tx, c, &user.ID, "resend_email_verification", "user", &user.ID, fmt.Sprintf("Verification email sent to %s", user.Email))
+
+	c.Flash().Add("success", fmt.Sprintf("A new verification link is on its way to %s.", user.Email))
+	return c.Redirect(http.StatusSeeOther, "/verify-email")
+}
+
+// EmailVerificationConfirm marks an email address as verified from the emailed link.
+func (a *MyApp) EmailVerificationConfirm(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	_, signedIn := c.Value("current_user").(models.User)
+	retryPath := "/signin"
+	if signedIn {
+		retryPath = "/verify-email"
+	}
+
+	token := c.Param("token")
+	payload, err := tokenPayload(token)
+	if err != nil {
+		c.Flash().Add("danger", "This verification link is invalid.")
+		return c.Redirect(http.StatusFound, retryPath)
+	}
+
+	userID, expiry, _ := strings.Cut(payload, ":")
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		c.Flash().Add("danger", "This verification link is invalid.")
+		return c.Redirect(http.StatusFound, retryPath)
+	}
+
+	user, err := repoManager.UserFindByID(userID)
+	if err != nil || !tokenSignatureValid(emailVerificationPurpose, token, strings.ToLower(user.Email)) {
+		c.Flash().Add("danger", "This verification link is invalid.")
+		return c.Redirect(http.StatusFound, retryPath)
+	}
+
+	if user.IsEmailVerified() {
+		c.Flash().Add("info", "Your email address is already verified.")
+		return c.Redirect(http.StatusFound, "/")
+	}
+
+	if time.Now().After(time.Unix(expiresAt, 0)) {
+		if signedIn {
+			c.Flash().Add("warning", "This verification link has expired. Request a new one below.")
+		} else {
+			c.Flash().Add("warning", "This verification link has expired. Sign in to request a new one.")
+		}
+		return c.Redirect(http.StatusFound, retryPath)
+	}
+
+	user.EmailVerifiedAt = nulls.NewTime(time.Now())
+	if err := tx.UpdateColumns(user, "email_verified_at", "updated_at"); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &user.ID, "verify_email", "user", &user.ID, fmt.Sprintf("Email address verified: %s", user.Email))
+
+	c.Flash().Add("success", "Thanks! Your email address is verified.")
+	if signedIn {
+		return c.Redirect(http.StatusFound, "/")
+	}
+	return c.Redirect(http.StatusFound, "/signin")
+}
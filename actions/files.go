@@ -105,6 +105,19 @@ func (a *MyApp) FilesCreate(c buffalo.Context) error {
 
 	if projectID := c.Request().FormValue("project_id"); projectID != "" {
 		fileRecord.ProjectID = &projectID
+
+		// Project files count as part of the submission and freeze with it
+		repoManager := a.Repository(tx)
+		project, err := repoManager.ProjectFindByID(projectID)
+		if err != nil {
+			c.Flash().Add("danger", "Project not found")
+			return c.Redirect(http.StatusFound, "/files/new")
+		}
+		if closed, err := submissionsClosed(c, repoManager, project); err != nil {
+			return err
+		} else if closed {
+			return c.Redirect(http.StatusFound, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+		}
 	}
 
 	verrs, err := tx.ValidateAndCreate(fileRecord)
@@ -157,6 +170,18 @@ func (a *MyApp) FilesDestroy(c buffalo.Context) error {
 		return c.Error(http.StatusForbidden, fmt.Errorf("not authorized"))
 	}
 
+	// Participants cannot pull files from a frozen submission
+	if file.ProjectID != nil && !user.IsOwner() {
+		repoManager := a.Repository(tx)
+		if project, err := repoManager.ProjectFindByID(*file.ProjectID); err == nil {
+			if closed, err := submissionsClosed(c, repoManager, project); err != nil {
+				return err
+			} else if closed {
+				return c.Redirect(http.StatusFound, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+			}
+		}
+	}
+
 	if err := tx.Destroy(file); err != nil {
 		c.Flash().Add("danger", "Failed to delete file record")
 		return c.Redirect(http.StatusFound, "/files")
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/arxdsilva/hackathon/models"
@@ -48,7 +47,7 @@ func (a *MyApp) HackathonsShow(c buffalo.Context) error {
 	}
 
 	projects := &models.Projects{}
-	q := tx.Where("hackathon_id = ?", hackathon.ID).Order("created_at desc").Paginate(page, 20)
+	q := scopeVisibleProjects(c, tx.Where("hackathon_id = ?", hackathon.ID), hackathon).Order("created_at desc").Paginate(page, 20)
 	if err := q.Eager("User").All(projects); err != nil {
 		return err
 	}
@@ -82,9 +81,9 @@ func (a *MyApp) HackathonsShow(c buffalo.Context) error {
 	}
 
 	// Load presenting projects ordered by presentation_order
-	presentingProjects := &models.Projects{}
-	if err := tx.Where("hackathon_id = ? AND presenting = ?", hackathon.ID, true).
-		Order("presentation_order asc").Eager("User").All(presentingProjects); err != nil {
+	repoManager := a.Repository(tx)
+	presentingProjects, err := repoManager.ProjectFindPresentingByHackathonID(hackathon.ID)
+	if err != nil {
 		return err
 	}
 
@@ -125,9 +124,77 @@ func (a *MyApp) HackathonsShow(c buffalo.Context) error {
 		progressPercentage = (activeProjects * 100) / totalProjects
 	}
 
+	// Judging results stay hidden from everyone but the organizer until published
+	isHackathonOwner := hackathon.OwnerID == currentUser.ID
+	judgingResults := models.ProjectResults{}
+	if hackathon.ResultsPublished || isHackathonOwner {
+		results, err := loadJudgingResults(repoManager, hackathon.ID)
+		if err != nil {
+			return err
+		}
+		judgingResults = results
+	}
+
+	// Projects the current user has been assigned to judge
+	judgeAssignments, err := repoManager.JudgingFindAssignmentsByHackathonIDAndJudgeID(hackathon.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+
+	// People's-choice votes of the current user and, once voting closed, the tally
+	userVotes, err := repoManager.VoteFindByHackathonIDAndUserID(hackathon.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	votedProjects := make(map[string]bool)
+	for _, vote := range *userVotes {
+		votedProjects[vote.ProjectID] = true
+	}
+
+	voteCounts := make(map[string]int)
+	totalVotes := 0
+	votingResults := models.Projects{}
+	if hackathon.VotingClosed() {
+		voteCounts, totalVotes, err = loadVoteTally(repoManager, hackathon.ID)
+		if err != nil {
+			return err
+		}
+		votingResults = sortProjectsByVotes(*presentingProjects, voteCounts)
+	}
+
+	// Award winners switch the page to a results view once announced
+	awards := &models.Awards{}
+	if hackathon.AwardsPublished {
+		awards, err = repoManager.AwardFindByHackathonID(hackathon.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	scheduleItems, err := repoManager.ScheduleItemFindByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+
 	c.Set("hackathon", hackathon)
 	c.Set("projects", projects)
 	c.Set("presentingProjects", presentingProjects)
+	c.Set("teamSizeLimit", hackathon.TeamSizeLimit(config))
+	c.Set("scheduleItems", scheduleItems)
+	c.Set("awards", awards)
+	c.Set("votedProjects", votedProjects)
+	c.Set("votesRemaining", hackathon.VotesPerUser-len(*userVotes))
+	c.Set("voteCounts", voteCounts)
+	c.Set("totalVotes", totalVotes)
+	c.Set("votingResults", votingResults)
+	c.Set("isHackathonOwner", isHackathonOwner)
+	c.Set("judgingResults", judgingResults)
+	c.Set("judgeAssignments", judgeAssignments)
 	c.Set("pagination", q.Paginator)
 	c.Set("memberCounts", memberCounts)
 	c.Set("userMemberships", userMemberships)
@@ -141,7 +208,7 @@ func (a *MyApp) HackathonsShow(c buffalo.Context) error {
 
 // HackathonsNew renders the form for creating a new hackathon (owner-only)
 func (a *MyApp) HackathonsNew(c buffalo.Context) error {
-	c.Set("hackathon", &models.Hackathon{})
+	c.Set("hackathon", &models.Hackathon{VotesPerUser: models.DefaultVotesPerUser})
 	return c.Render(http.StatusOK, r.HTML("hackathons/new.plush.html"))
 }
 
@@ -153,12 +220,6 @@ func (a *MyApp) HackathonsCreate(c buffalo.Context) error {
 	hackathon.Title = c.Params().Get("Title")
 	hackathon.Description = c.Params().Get("Description")
 	hackathon.Status = c.Params().Get("Status")
-	schedule := strings.TrimSpace(c.Params().Get("Schedule"))
-	if schedule == "" {
-		hackathon.Schedule = nulls.String{}
-	} else {
-		hackathon.Schedule = nulls.NewString(schedule)
-	}
 
 	// Parse dates
 	if startStr := c.Params().Get("StartDate"); startStr != "" {
@@ -172,6 +233,20 @@ func (a *MyApp) HackathonsCreate(c buffalo.Context) error {
 		}
 	}
 
+	hackathon.VotesPerUser = models.DefaultVotesPerUser
+	if votes, err := strconv.Atoi(c.Params().Get("VotesPerUser")); err == nil {
+		hackathon.VotesPerUser = votes
+	}
+	hackathon.OpenTeams = c.Params().Get("OpenTeams") == "true"
+	hackathon.SubmissionDeadline = nulls.Time{}
+	if deadline, err := time.Parse("2006-01-02T15:04", c.Params().Get("SubmissionDeadline")); err == nil {
+		hackathon.SubmissionDeadline = nulls.NewTime(deadline)
+	}
+	hackathon.MaxTeamSize = nulls.Int{}
+	if size, err := strconv.Atoi(c.Params().Get("MaxTeamSize")); err == nil {
+		hackathon.MaxTeamSize = nulls.NewInt(size)
+	}
+
 	// Set the owner to current user
 	currentUser := c.Value("current_user").(models.User)
 	hackathon.OwnerID = currentUser.ID
@@ -189,7 +264,7 @@ func (a *MyApp) HackathonsCreate(c buffalo.Context) error {
 	}
 
 	// Log hackathon creation
-	logAuditEvent(tx, c, &currentUser.ID, "create", "hackathon", &hackathon.ID, fmt.Sprintf("Hackathon created: %s", hackathon.Title))
+	logAuditEvent(tx, c, &currentUser.ID, "create", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon created: %s", hackathon.Title))
 
 	c.Flash().Add("success", "Hackathon created successfully!")
 	return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
@@ -204,7 +279,15 @@ func (a *MyApp) HackathonsEdit(c buffalo.Context) error {
 		return c.Error(http.StatusNotFound, err)
 	}
 
+	scheduleItems, err := a.Repository(tx).ScheduleItemFindByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
 	c.Set("hackathon", hackathon)
+	c.Set("scheduleItems", scheduleItems)
+	c.Set("scheduleItemTypes", models.ScheduleItemTypes)
+	c.Set("newScheduleItem", models.ScheduleItem{ItemType: models.ScheduleItemWorkshop, StartsAt: hackathon.StartDate})
 	return c.Render(http.StatusOK, r.HTML("hackathons/edit.plush.html"))
 }
 
@@ -221,12 +304,6 @@ func (a *MyApp) HackathonsUpdate(c buffalo.Context) error {
 	hackathon.Title = c.Params().Get("Title")
 	hackathon.Description = c.Params().Get("Description")
 	hackathon.Status = c.Params().Get("Status")
-	schedule := strings.TrimSpace(c.Params().Get("Schedule"))
-	if schedule == "" {
-		hackathon.Schedule = nulls.String{}
-	} else {
-		hackathon.Schedule = nulls.NewString(schedule)
-	}
 
 	// Parse dates
 	if startStr := c.Params().Get("StartDate"); startStr != "" {
@@ -240,20 +317,41 @@ func (a *MyApp) HackathonsUpdate(c buffalo.Context) error {
 		}
 	}
 
+	if votes, err := strconv.Atoi(c.Params().Get("VotesPerUser")); err == nil {
+		hackathon.VotesPerUser = votes
+	}
+	hackathon.OpenTeams = c.Params().Get("OpenTeams") == "true"
+	hackathon.SubmissionDeadline = nulls.Time{}
+	if deadline, err := time.Parse("2006-01-02T15:04", c.Params().Get("SubmissionDeadline")); err == nil {
+		hackathon.SubmissionDeadline = nulls.NewTime(deadline)
+	}
+	hackathon.MaxTeamSize = nulls.Int{}
+	if size, err := strconv.Atoi(c.Params().Get("MaxTeamSize")); err == nil {
+		hackathon.MaxTeamSize = nulls.NewInt(size)
+	}
+
 	verrs, err := tx.ValidateAndUpdate(hackathon)
 	if err != nil {
 		return err
 	}
 
 	if verrs.HasAny() {
+		scheduleItems, err := a.Repository(tx).ScheduleItemFindByHackathonID(hackathon.ID)
+		if err != nil {
+			return err
+		}
+
 		c.Set("hackathon", hackathon)
+		c.Set("scheduleItems", scheduleItems)
+		c.Set("scheduleItemTypes", models.ScheduleItemTypes)
+		c.Set("newScheduleItem", models.ScheduleItem{ItemType: models.ScheduleItemWorkshop, StartsAt: hackathon.StartDate})
 		c.Set("errors", verrs)
 		return c.Render(http.StatusUnprocessableEntity, r.HTML("hackathons/edit.plush.html"))
 	}
 
 	// Log hackathon update
 	currentUser := c.Value("current_user").(models.User)
-	logAuditEvent(tx, c, &currentUser.ID, "update", "hackathon", &hackathon.ID, fmt.Sprintf("Hackathon updated: %s", hackathon.Title))
+	logAuditEvent(tx, c, &currentUser.ID, "update", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon updated: %s", hackathon.Title))
 
 	c.Flash().Add("success", "Hackathon updated successfully!")
 	return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
@@ -270,7 +368,7 @@ func (a *MyApp) HackathonsDestroy(c buffalo.Context) error {
 
 	// Log hackathon deletion
 	currentUser := c.Value("current_user").(models.User)
-	logAuditEvent(tx, c, &currentUser.ID, "delete", "hackathon", &hackathon.ID, fmt.Sprintf("Hackathon deleted: %s", hackathon.Title))
+	logAuditEvent(tx, c, &currentUser.ID, "delete", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon deleted: %s", hackathon.Title))
 
 	if err := tx.Destroy(hackathon); err != nil {
 		return err
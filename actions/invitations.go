@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v6"
+)
+
+const invitationPurpose = "invitation"
+
+// errInvitationUnusable covers invitation links that are malformed, revoked, expired or used up
+var errInvitationUnusable = errors.New("invitation can't be used")
+
+// invitationToken signs the ID of an invitation. Revoking deletes the row, so the token
+// doesn't have to be stored and owners can copy the link again at any time.
+func invitationToken(invitation models.Invitation) string {
+	return signToken(invitationPurpose, invitation.ID.String())
+}
+
+// invitationURL returns the registration link of an invitation
+func (a *MyApp) invitationURL(invitation models.Invitation) string {
+	return fmt.Sprintf("%s/invitations/%s", strings.TrimSuffix(a.Options.Host, "/"), invitationToken(invitation))
+}
+
+// usableInvitation returns the invitation of a link and the hackathon it is scoped to, if any.
+// It returns errInvitationUnusable when the link can't create accounts anymore.
+func (a *MyApp) usableInvitation(tx *pop.Connection, token string) (*models.Invitation, *models.Hackathon, error) {
+	if !tokenSignatureValid(invitationPurpose, token) {
+		return nil, nil, errInvitationUnusable
+	}
+	invitationID, err := tokenPayload(token)
+	if err != nil {
+		return nil, nil, errInvitationUnusable
+	}
+
+	repoManager := a.Repository(tx)
+	invitation, err := repoManager.InvitationFindByID(invitationID)
+	if err != nil || !invitation.IsUsable(time.Now()) {
+		return nil, nil, errInvitationUnusable
+	}
+	if !invitation.HackathonID.Valid {
+		return invitation, nil, nil
+	}
+
+	hackathon, err := repoManager.HackathonFindByID(invitation.HackathonID.String)
+	if err != nil || hackathon.Status == models.HackathonStatusCompleted {
+		return nil, nil, errInvitationUnusable
+	}
+	return invitation, hackathon, nil
+}
+
+// setInvitation makes the invitation available to the sign-up form
+func setInvitation(c buffalo.Context, token string, invitation *models.Invitation, hackathon *models.Hackathon) {
+	c.Set("invitationToken", token)
+	c.Set("invitation", invitation)
+	if hackathon != nil {
+		c.Set("invitationHackathon", hackathon)
+	}
+}
+
+// InvitationsShow renders the sign-up form for an invitation link
+func (a *MyApp) InvitationsShow(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	if _, signedIn := c.Value("current_user").(models.User); signedIn {
+		c.Flash().Add("info", "You're already signed in, invitations are for creating new accounts.")
+		return c.Redirect(http.StatusFound, "/")
+	}
+	if redirected, err := ssoOnly(c, tx); redirected || err != nil {
+		return err
+	}
+
+	token := c.Param("token")
+	invitation, hackathon, err := a.usableInvitation(tx, token)
+	if err != nil {
+		c.Flash().Add("danger", "This invitation link is invalid, expired or has already been used. Ask for a new one.")
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+
+	setInvitation(c, token, invitation, hackathon)
+	c.Set("user", models.User{CompanyTeam: invitation.CompanyTeam})
+	return c.Render(http.StatusOK, r.HTML("users/new.plush.html"))
+}
@@ -0,0 +1,382 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+)
+
+// loadJudgingResults aggregates all scores of a hackathon into ranked project results.
+// Projects waiting for review or rejected by an organizer aren't ranked.
+func loadJudgingResults(repoManager repository.RepositoryInterface, hackathonID string) (models.ProjectResults, error) {
+	projects, err := repoManager.ProjectFindApprovedByHackathonID(hackathonID)
+	if err != nil {
+		return nil, err
+	}
+
+	criteria, err := repoManager.JudgingFindCriteriaByHackathonID(hackathonID)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := repoManager.JudgingFindScoresByHackathonID(hackathonID)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.RankProjects(*projects, *criteria, *scores), nil
+}
+
+// JudgingIndex renders the judging dashboard of a hackathon (owner-only)
+func (a *MyApp) JudgingIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	criteria, err := repoManager.JudgingFindCriteriaByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	assignments, err := repoManager.JudgingFindAssignmentsByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	projects, err := repoManager.ProjectFindByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	judges, err := repoManager.UserFindByRole(models.RoleJudge)
+	if err != nil {
+		return err
+	}
+
+	results, err := loadJudgingResults(repoManager, hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	totalWeight := 0
+	for _, criterion := range *criteria {
+		totalWeight += criterion.Weight
+	}
+
+	c.Set("hackathon", hackathon)
+	c.Set("criteria", criteria)
+	c.Set("totalWeight", totalWeight)
+	c.Set("assignments", assignments)
+	c.Set("projects", projects)
+	c.Set("judges", judges)
+	c.Set("results", results)
+	return c.Render(http.StatusOK, r.HTML("judging/index.plush.html"))
+}
+
+// JudgingCriteriaCreate adds a rubric criterion to a hackathon (owner-only)
+func (a *MyApp) JudgingCriteriaCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	hackathonID := c.Param("hackathon_id")
+
+	criterion := &models.JudgingCriterion{
+		HackathonID: hackathonID,
+		Name:        strings.TrimSpace(c.Param("name")),
+		Weight:      1,
+	}
+	if description := strings.TrimSpace(c.Param("description")); description != "" {
+		criterion.Description = nulls.NewString(description)
+	}
+	if weight, err := strconv.Atoi(c.Param("weight")); err == nil {
+		criterion.Weight = weight
+	}
+
+	verrs, err := tx.ValidateAndCreate(criterion)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.String())
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "create", "judging_criterion", &criterion.ID, fmt.Sprintf("Judging criterion created: %s (weight %d)", criterion.Name, criterion.Weight))
+
+	c.Flash().Add("success", "Criterion added!")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+}
+
+// JudgingCriteriaDestroy removes a rubric criterion and its scores (owner-only)
+func (a *MyApp) JudgingCriteriaDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	hackathonID := c.Param("hackathon_id")
+
+	criterion := &models.JudgingCriterion{}
+	if err := tx.Where("hackathon_id = ?", hackathonID).Find(criterion, c.Param("criterion_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(criterion); err != nil {
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "delete", "judging_criterion", &criterion.ID, fmt.Sprintf("Judging criterion deleted: %s", criterion.Name))
+
+	c.Flash().Add("success", "Criterion removed.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+}
+
+// JudgeAssignmentsCreate assigns a judge to a project (owner-only)
+func (a *MyApp) JudgeAssignmentsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	hackathonID := c.Param("hackathon_id")
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != hackathonID {
+		c.Flash().Add("danger", "Project not found in this hackathon.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+	}
+
+	judge, err := repoManager.UserFindByID(c.Param("judge_id"))
+	if err != nil || !judge.IsJudge() {
+		c.Flash().Add("danger", "Only users with the judge role can be assigned.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+	}
+
+	assigned, err := repoManager.JudgingIsJudgeAssigned(project.ID, judge.ID)
+	if err != nil {
+		return err
+	}
+	if assigned {
+		c.Flash().Add("warning", "This judge is already assigned to the project.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+	}
+
+	assignment := &models.JudgeAssignment{
+		HackathonID: hackathonID,
+		ProjectID:   project.ID,
+		JudgeID:     judge.ID,
+	}
+	if err := tx.Create(assignment); err != nil {
+		if isDuplicateError(err) {
+			c.Flash().Add("warning", "This judge is already assigned to the project.")
+			return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+		}
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "assign_judge", "judge_assignment", &assignment.ID, fmt.Sprintf("Judge %s assigned to project: %s", judge.Email, project.Name))
+
+	c.Flash().Add("success", "Judge assigned!")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+}
+
+// JudgeAssignmentsDestroy removes a judge from a project (owner-only)
+func (a *MyApp) JudgeAssignmentsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	hackathonID := c.Param("hackathon_id")
+
+	assignment := &models.JudgeAssignment{}
+	if err := tx.Where("hackathon_id = ?", hackathonID).Find(assignment, c.Param("assignment_id")); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := tx.Destroy(assignment); err != nil {
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "unassign_judge", "judge_assignment", &assignment.ID, fmt.Sprintf("Judge %s unassigned from project %s", assignment.JudgeID, assignment.ProjectID))
+
+	c.Flash().Add("success", "Judge unassigned.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathonID)
+}
+
+// JudgingTogglePublish publishes or hides the judging results of a hackathon (owner-only)
+func (a *MyApp) JudgingTogglePublish(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	hackathon.ResultsPublished = !hackathon.ResultsPublished
+	if err := tx.Update(hackathon); err != nil {
+		return err
+	}
+
+	action := "publish_results"
+	message := "Judging results published!"
+	if !hackathon.ResultsPublished {
+		action = "unpublish_results"
+		message = "Judging results hidden."
+	}
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, action, "hackathon", hackathon.ID, fmt.Sprintf("Judging results visibility changed: %s", hackathon.Title))
+
+	c.Flash().Add("success", message)
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/judging", hackathon.ID)
+}
+
+// JudgeScoresEdit renders the scoring form of a project for an assigned judge
+func (a *MyApp) JudgeScoresEdit(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	// The judge assignment is checked against the project's own hackathon, so the URL has to name it
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != c.Param("hackathon_id") {
+		return c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+	}
+
+	assigned, err := repoManager.JudgingIsJudgeAssigned(project.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		c.Flash().Add("danger", "You are not assigned to judge this project.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+	}
+
+	hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	criteria, err := repoManager.JudgingFindCriteriaByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	scores, err := repoManager.JudgingFindScoresByProjectIDAndJudgeID(project.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+
+	// Index existing scores by criterion so the form can be pre-filled
+	scoreValues := make(map[string]string)
+	scoreComments := make(map[string]string)
+	for _, score := range *scores {
+		scoreValues[score.CriterionID.String()] = strconv.Itoa(score.Score)
+		scoreComments[score.CriterionID.String()] = score.Comment.String
+	}
+
+	c.Set("hackathon", hackathon)
+	c.Set("project", project)
+	c.Set("criteria", criteria)
+	c.Set("scoreValues", scoreValues)
+	c.Set("scoreComments", scoreComments)
+	c.Set("scoreMin", models.JudgeScoreMin)
+	c.Set("scoreMax", models.JudgeScoreMax)
+	return c.Render(http.StatusOK, r.HTML("judging/score.plush.html"))
+}
+
+// JudgeScoresUpdate saves the scores an assigned judge gave a project
+func (a *MyApp) JudgeScoresUpdate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != c.Param("hackathon_id") {
+		return c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+	}
+
+	assigned, err := repoManager.JudgingIsJudgeAssigned(project.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	if !assigned {
+		c.Flash().Add("danger", "You are not assigned to judge this project.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+	}
+
+	hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+	if hackathon.ResultsPublished {
+		c.Flash().Add("danger", "Results have been published; scores can no longer be changed.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s/score", project.HackathonID, project.ID)
+	}
+
+	criteria, err := repoManager.JudgingFindCriteriaByHackathonID(hackathon.ID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := repoManager.JudgingFindScoresByProjectIDAndJudgeID(project.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	existingByCriterion := make(map[uuid.UUID]models.JudgeScore)
+	for _, score := range *existing {
+		existingByCriterion[score.CriterionID] = score
+	}
+
+	// Every score is checked before any is saved so a judge never ends up with half their scores changed
+	scores := make(models.JudgeScores, 0, len(*criteria))
+	details := make([]string, 0, len(*criteria))
+	for _, criterion := range *criteria {
+		value, err := strconv.Atoi(c.Param("score_" + criterion.ID.String()))
+		if err != nil {
+			c.Flash().Add("danger", fmt.Sprintf("A score is required for %s", criterion.Name))
+			return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s/score", project.HackathonID, project.ID)
+		}
+
+		score, found := existingByCriterion[criterion.ID]
+		if !found {
+			score = models.JudgeScore{
+				ProjectID:   project.ID,
+				CriterionID: criterion.ID,
+				JudgeID:     currentUser.ID,
+			}
+		}
+		score.Score = value
+		score.Comment = nulls.String{}
+		if comment := strings.TrimSpace(c.Param("comment_" + criterion.ID.String())); comment != "" {
+			score.Comment = nulls.NewString(comment)
+		}
+
+		verrs, err := score.Validate(tx)
+		if err != nil {
+			return err
+		}
+		if verrs.HasAny() {
+			c.Flash().Add("danger", fmt.Sprintf("%s: %s", criterion.Name, verrs.String()))
+			return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s/score", project.HackathonID, project.ID)
+		}
+		scores = append(scores, score)
+		details = append(details, fmt.Sprintf("%s=%d", criterion.Name, value))
+	}
+
+	for i := range scores {
+		if err := tx.Save(&scores[i]); err != nil {
+			return err
+		}
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "score", "project", project.ID, fmt.Sprintf("Judge scored project %s: %s", project.Name, strings.Join(details, ", ")))
+
+	c.Flash().Add("success", "Scores saved!")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+}
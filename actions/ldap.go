@@ -0,0 +1,253 @@
+package actions
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// ldapTimeout bounds each connection and request to the directory
+const ldapTimeout = 10 * time.Second
+
+var (
+	// errLDAPUserNotFound means the directory has no entry for the email, so the local password applies
+	errLDAPUserNotFound = errors.New("no directory entry for this email")
+	// errLDAPInvalidCredentials means the directory rejected the password or the user isn't in an allowed group
+	errLDAPInvalidCredentials = errors.New("invalid directory credentials")
+)
+
+// ldapIdentity is a directory entry whose password checked out. Name and Team are empty when
+// their attribute isn't configured or the entry doesn't have it.
+type ldapIdentity struct {
+	DN    string
+	Email string
+	Name  string
+	Team  string
+	Owner bool
+	// OwnerMapped is true when an owner group is configured, Owner then decides the role
+	OwnerMapped bool
+}
+
+// ldapConnect opens a connection to the directory and signs in with the service account
+func ldapConnect(config *models.CompanyConfiguration) (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(config.LDAPURL, ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(ldapTimeout)
+
+	if config.LDAPStartTLS {
+		serverURL, err := url.Parse(config.LDAPURL)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		if err := conn.StartTLS(&tls.Config{ServerName: serverURL.Hostname()}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if err := ldapBindServiceAccount(conn, config); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// ldapBindServiceAccount signs in with the account used for searches, or anonymously when there is none
+func ldapBindServiceAccount(conn *ldap.Conn, config *models.CompanyConfiguration) error {
+	if config.LDAPBindDN == "" {
+		return conn.UnauthenticatedBind("")
+	}
+	return conn.Bind(config.LDAPBindDN, config.LDAPBindPassword)
+}
+
+// ldapIsMember returns true when a group entry lists the DN as a member
+func ldapIsMember(conn *ldap.Conn, groupDN, memberDN string) (bool, error) {
+	escaped := ldap.EscapeFilter(memberDN)
+	result, err := conn.Search(ldap.NewSearchRequest(
+		groupDN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, int(ldapTimeout.Seconds()), false,
+		fmt.Sprintf("(|(member=%s)(uniqueMember=%s))", escaped, escaped),
+		[]string{"dn"}, nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(result.Entries) > 0, nil
+}
+
+// ldapAuthenticate checks an email and password against the directory. It finds the entry with
+// the service account, binds as that entry with the password and then reads its groups.
+func ldapAuthenticate(config *models.CompanyConfiguration, email, password string) (*ldapIdentity, error) {
+	conn, err := ldapConnect(config)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	attributes := []string{"mail"}
+	if config.LDAPNameAttribute != "" {
+		attributes = append(attributes, config.LDAPNameAttribute)
+	}
+	if config.LDAPTeamAttribute != "" {
+		attributes = append(attributes, config.LDAPTeamAttribute)
+	}
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		config.LDAPBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(ldapTimeout.Seconds()), false,
+		strings.ReplaceAll(config.LDAPUserFilter, "%s", ldap.EscapeFilter(email)),
+		attributes, nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Entries) == 0 {
+		return nil, errLDAPUserNotFound
+	}
+	if len(result.Entries) > 1 {
+		return nil, fmt.Errorf("the LDAP user filter matches more than one entry for %s", email)
+	}
+	entry := result.Entries[0]
+
+	// An empty password would be an unauthenticated bind, which many servers accept
+	if password == "" {
+		return nil, errLDAPInvalidCredentials
+	}
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errLDAPInvalidCredentials
+		}
+		return nil, err
+	}
+
+	identity := &ldapIdentity{
+		DN:    entry.DN,
+		Email: strings.ToLower(strings.TrimSpace(entry.GetAttributeValue("mail"))),
+		Name:  strings.TrimSpace(entry.GetAttributeValue(config.LDAPNameAttribute)),
+		Team:  strings.TrimSpace(entry.GetAttributeValue(config.LDAPTeamAttribute)),
+	}
+	if identity.Email == "" {
+		identity.Email = email
+	}
+	identity.OwnerMapped = config.LDAPOwnerGroupDN != ""
+
+	if config.LDAPOwnerGroupDN == "" && config.LDAPHackerGroupDN == "" {
+		return identity, nil
+	}
+
+	// Group entries are read as the service account, users often can't see them
+	if err := ldapBindServiceAccount(conn, config); err != nil {
+		return nil, err
+	}
+	if config.LDAPOwnerGroupDN != "" {
+		if identity.Owner, err = ldapIsMember(conn, config.LDAPOwnerGroupDN, entry.DN); err != nil {
+			return nil, err
+		}
+	}
+	if config.LDAPHackerGroupDN != "" && !identity.Owner {
+		member, err := ldapIsMember(conn, config.LDAPHackerGroupDN, entry.DN)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			return nil, errLDAPInvalidCredentials
+		}
+	}
+	return identity, nil
+}
+
+// ldapUser finds the account of a directory entry, linking it by email or creating it on
+// first sign-in, and keeps its name, team and owner role in step with the directory.
+// Attributes the entry doesn't have leave the account's values alone.
+func (a *MyApp) ldapUser(c buffalo.Context, tx *pop.Connection, identity *ldapIdentity) (*models.User, error) {
+	repoManager := a.Repository(tx)
+
+	user, err := repoManager.UserFindByLDAPDN(identity.DN)
+	if err != nil {
+		user, err = repoManager.UserFindByEmail(identity.Email)
+		if err == nil {
+			if user.LDAPDN.Valid {
+				return nil, fmt.Errorf("%s is already linked to the directory entry %s", user.Email, user.LDAPDN.String)
+			}
+			user.LDAPDN = nulls.NewString(identity.DN)
+			if err := tx.UpdateColumns(user, "ldap_dn", "updated_at"); err != nil {
+				return nil, err
+			}
+			logAuditEvent(tx, c, &user.ID, "link_ldap", "user", &user.ID, fmt.Sprintf("Linked directory entry %s to %s", identity.DN, user.Email))
+		} else {
+			// The password is never shown to anyone, the directory checks the real one
+			password, err := randomPassword()
+			if err != nil {
+				return nil, err
+			}
+			name := identity.Name
+			if name == "" {
+				name, _, _ = strings.Cut(identity.Email, "@")
+			}
+			user = &models.User{
+				Email:                identity.Email,
+				Name:                 name,
+				CompanyTeam:          identity.Team,
+				Password:             password,
+				PasswordConfirmation: password,
+				EmailVerifiedAt:      nulls.NewTime(time.Now()),
+				LDAPDN:               nulls.NewString(identity.DN),
+			}
+			verrs, err := user.Create(tx)
+			if err != nil {
+				return nil, err
+			}
+			if verrs.HasAny() {
+				return nil, fmt.Errorf("could not create an account for %s: %s", identity.Email, verrs.Error())
+			}
+			logAuditEvent(tx, c, &user.ID, "register", "user", &user.ID, fmt.Sprintf("User registered through LDAP: %s (%s)", user.Name, user.Email))
+		}
+	}
+
+	nameChanged := identity.Name != "" && user.Name != identity.Name
+	teamChanged := identity.Team != "" && user.CompanyTeam != identity.Team
+	if nameChanged || teamChanged || !user.IsEmailVerified() {
+		if nameChanged {
+			user.Name = identity.Name
+		}
+		if teamChanged {
+			user.CompanyTeam = identity.Team
+		}
+		if !user.IsEmailVerified() {
+			user.EmailVerifiedAt = nulls.NewTime(time.Now())
+		}
+		if err := tx.UpdateColumns(user, "name", "company_team", "email_verified_at", "updated_at"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Like the single sign-on claim, the owner group decides the role when it is configured
+	if identity.OwnerMapped {
+		changed, err := a.setOwnerRole(tx, user, identity.Owner)
+		if err != nil {
+			return nil, err
+		}
+		if changed && identity.Owner {
+			logAuditEvent(tx, c, &user.ID, "ldap_grant_owner", "user", &user.ID, fmt.Sprintf("Owner role granted by LDAP group to %s", user.Email))
+		} else if changed {
+			logAuditEvent(tx, c, &user.ID, "ldap_revoke_owner", "user", &user.ID, fmt.Sprintf("Owner role removed from %s, who is no longer in the LDAP owner group", user.Email))
+		}
+	}
+
+	return user, nil
+}
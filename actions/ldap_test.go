@@ -0,0 +1,392 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/arxdsilva/hackathon/internal/mockldap"
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gofrs/uuid"
+)
+
+const (
+	testLDAPBindDN       = "cn=service,dc=example,dc=com"
+	testLDAPBindPassword = "service-password"
+	testLDAPOwnersDN     = "cn=owners,ou=groups,dc=example,dc=com"
+	testLDAPHackersDN    = "cn=hackers,ou=groups,dc=example,dc=com"
+)
+
+// testLDIF is the directory of the LDAP tests, every password is "directory-password". Ada owns
+// the app, Grace and Bare are hackers, Eve is in no group and the twins share an email address.
+// Bare has no name or team.
+func testLDIF(domain string) string {
+	return strings.ReplaceAll(`
+dn: ou=people,dc=example,dc=com
+ou: people
+
+dn: ou=groups,dc=example,dc=com
+ou: groups
+
+dn: uid=ada,ou=people,dc=example,dc=com
+cn: Ada Lovelace
+mail: Ada@DOMAIN
+departmentNumber: Platform
+userPassword: directory-password
+
+dn: uid=grace,ou=people,dc=example,dc=com
+cn: Grace Hopper
+mail: grace@DOMAIN
+departmentNumber: Compilers
+userPassword: directory-password
+
+dn: uid=bare,ou=people,dc=example,dc=com
+mail: bare@DOMAIN
+userPassword: directory-password
+
+dn: uid=eve,ou=people,dc=example,dc=com
+cn: Eve Outsider
+mail: eve@DOMAIN
+userPassword: directory-password
+
+dn: uid=twin1,ou=people,dc=example,dc=com
+mail: twin@DOMAIN
+userPassword: directory-password
+
+dn: uid=twin2,ou=people,dc=example,dc=com
+mail: twin@DOMAIN
+userPassword: directory-password
+
+dn: cn=owners,ou=groups,dc=example,dc=com
+member: uid=ada,ou=people,dc=example,dc=com
+
+dn: cn=hackers,ou=groups,dc=example,dc=com
+member: uid=grace,ou=people,dc=example,dc=com
+member: uid=bare,ou=people,dc=example,dc=com
+`, "DOMAIN", domain)
+}
+
+// testLDAPConfig serves testLDIF from an in-process directory and returns the settings to use it,
+// without group mapping
+func testLDAPConfig(t *testing.T, domain string) *models.CompanyConfiguration {
+	t.Helper()
+	directory, err := mockldap.NewDirectory(strings.NewReader(testLDIF(domain)), testLDAPBindDN, testLDAPBindPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go directory.Serve(listener)
+
+	return &models.CompanyConfiguration{
+		LDAPEnabled:       true,
+		LDAPURL:           "ldap://" + listener.Addr().String(),
+		LDAPBindDN:        testLDAPBindDN,
+		LDAPBindPassword:  testLDAPBindPassword,
+		LDAPBaseDN:        "ou=people,dc=example,dc=com",
+		LDAPUserFilter:    "(mail=%s)",
+		LDAPNameAttribute: "cn",
+		LDAPTeamAttribute: "departmentNumber",
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	const domain = "directory.example.com"
+	config := testLDAPConfig(t, domain)
+	grouped := *config
+	grouped.LDAPOwnerGroupDN = testLDAPOwnersDN
+	grouped.LDAPHackerGroupDN = testLDAPHackersDN
+	ownersOnly := *config
+	ownersOnly.LDAPOwnerGroupDN = testLDAPOwnersDN
+	wrongService := *config
+	wrongService.LDAPBindPassword = "wrong"
+
+	tests := []struct {
+		name     string
+		config   *models.CompanyConfiguration
+		email    string
+		password string
+		want     *ldapIdentity
+		wantErr  error
+		// anyErr is set when the error isn't one of the sentinel errors
+		anyErr bool
+	}{
+		{
+			name:     "entry with every attribute",
+			config:   config,
+			email:    "ada@" + domain,
+			password: "directory-password",
+			want:     &ldapIdentity{DN: "uid=ada,ou=people,dc=example,dc=com", Email: "ada@" + domain, Name: "Ada Lovelace", Team: "Platform"},
+		},
+		{
+			name:     "entry without name or team",
+			config:   config,
+			email:    "bare@" + domain,
+			password: "directory-password",
+			want:     &ldapIdentity{DN: "uid=bare,ou=people,dc=example,dc=com", Email: "bare@" + domain},
+		},
+		{
+			name:     "wrong password",
+			config:   config,
+			email:    "ada@" + domain,
+			password: "guess",
+			wantErr:  errLDAPInvalidCredentials,
+		},
+		{
+			// The directory accepts unauthenticated binds, so the empty password has to be refused before binding
+			name:     "empty password",
+			config:   config,
+			email:    "ada@" + domain,
+			password: "",
+			wantErr:  errLDAPInvalidCredentials,
+		},
+		{
+			name:     "unknown email",
+			config:   config,
+			email:    "nobody@" + domain,
+			password: "directory-password",
+			wantErr:  errLDAPUserNotFound,
+		},
+		{
+			name:     "email of two entries",
+			config:   config,
+			email:    "twin@" + domain,
+			password: "directory-password",
+			anyErr:   true,
+		},
+		{
+			name:     "service account rejected",
+			config:   &wrongService,
+			email:    "ada@" + domain,
+			password: "directory-password",
+			anyErr:   true,
+		},
+		{
+			name:     "owner group member",
+			config:   &grouped,
+			email:    "ada@" + domain,
+			password: "directory-password",
+			want:     &ldapIdentity{DN: "uid=ada,ou=people,dc=example,dc=com", Email: "ada@" + domain, Name: "Ada Lovelace", Team: "Platform", Owner: true, OwnerMapped: true},
+		},
+		{
+			name:     "hacker group member",
+			config:   &grouped,
+			email:    "grace@" + domain,
+			password: "directory-password",
+			want:     &ldapIdentity{DN: "uid=grace,ou=people,dc=example,dc=com", Email: "grace@" + domain, Name: "Grace Hopper", Team: "Compilers", OwnerMapped: true},
+		},
+		{
+			name:     "in no allowed group",
+			config:   &grouped,
+			email:    "eve@" + domain,
+			password: "directory-password",
+			wantErr:  errLDAPInvalidCredentials,
+		},
+		{
+			name:     "only an owner group configured",
+			config:   &ownersOnly,
+			email:    "eve@" + domain,
+			password: "directory-password",
+			want:     &ldapIdentity{DN: "uid=eve,ou=people,dc=example,dc=com", Email: "eve@" + domain, Name: "Eve Outsider", OwnerMapped: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := ldapAuthenticate(tt.config, tt.email, tt.password)
+			switch {
+			case tt.anyErr:
+				if err == nil || errors.Is(err, errLDAPInvalidCredentials) || errors.Is(err, errLDAPUserNotFound) {
+					t.Errorf("got %+v, %v; want a directory error", identity, err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("got %+v, %v; want %v", identity, err, tt.wantErr)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case *identity != *tt.want:
+				t.Errorf("got %+v, want %+v", identity, tt.want)
+			}
+		})
+	}
+
+	unreachable := *config
+	unreachable.LDAPURL = "ldap://127.0.0.1:1"
+	if _, err := ldapAuthenticate(&unreachable, "ada@"+domain, "directory-password"); err == nil || errors.Is(err, errLDAPUserNotFound) || errors.Is(err, errLDAPInvalidCredentials) {
+		t.Errorf("an unreachable directory returned %v, want a connection error", err)
+	}
+}
+
+func TestLDAPUser(t *testing.T) {
+	tx := testTx(t)
+	a := &MyApp{}
+	createTestUser(t, tx, models.RoleOwner)
+	domain := fmt.Sprintf("ldap-%s.example.com", uuid.Must(uuid.NewV4()).String()[:8])
+
+	// A new entry gets an account, named after its email when the entry has no name
+	created, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=bare", Email: "bare@" + domain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Name != "bare" || created.CompanyTeam != "" || created.LDAPDN.String != "uid=bare" || created.Role != models.RoleHacker || !created.IsEmailVerified() {
+		t.Errorf("created %+v, want a verified hacker named bare linked to uid=bare", created)
+	}
+
+	// An account with the same email is linked and keeps what the entry doesn't have
+	existing := createTestUser(t, tx, models.RoleHacker)
+	existing.Email = "existing@" + domain
+	existing.Name = "Kept Name"
+	existing.CompanyTeam = "Kept Team"
+	if err := tx.UpdateColumns(existing, "email", "name", "company_team"); err != nil {
+		t.Fatal(err)
+	}
+	linked, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=existing", Email: existing.Email})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linked.ID != existing.ID || linked.LDAPDN.String != "uid=existing" || linked.Name != "Kept Name" || linked.CompanyTeam != "Kept Team" {
+		t.Errorf("linked %+v, want %s linked to uid=existing with its name and team", linked, existing.ID)
+	}
+
+	// Attributes the entry has replace the account's values
+	updated, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=existing", Email: existing.Email, Name: "Directory Name", Team: "Directory Team"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := &models.User{}
+	if err := tx.Find(stored, updated.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != "Directory Name" || stored.CompanyTeam != "Directory Team" {
+		t.Errorf("stored name %q and team %q, want the directory's", stored.Name, stored.CompanyTeam)
+	}
+
+	// An account linked to another entry isn't taken over
+	if _, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=impostor", Email: existing.Email}); err == nil {
+		t.Error("an account linked to another entry was linked again")
+	}
+
+	// Without an owner group configured the role is left alone
+	promoted := createTestUser(t, tx, models.RoleOwner)
+	unmapped, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=promoted", Email: promoted.Email})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unmapped.Role != models.RoleOwner {
+		t.Errorf("role without an owner group is %s, want %s", unmapped.Role, models.RoleOwner)
+	}
+
+	// The owner group grants and takes away the owner role
+	owner, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=bare", Email: "bare@" + domain, Owner: true, OwnerMapped: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner.Role != models.RoleOwner {
+		t.Errorf("role in the owner group is %s, want %s", owner.Role, models.RoleOwner)
+	}
+	hacker, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=bare", Email: "bare@" + domain, OwnerMapped: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hacker.Role != models.RoleHacker {
+		t.Errorf("role outside the owner group is %s, want %s", hacker.Role, models.RoleHacker)
+	}
+	for action, userID := range map[string]uuid.UUID{"ldap_grant_owner": created.ID, "ldap_revoke_owner": created.ID, "link_ldap": existing.ID} {
+		count, err := tx.Where("action = ? AND user_id = ?", action, userID).Count(&models.AuditLog{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 1 {
+			t.Errorf("%d %s audit events, want 1", count, action)
+		}
+	}
+
+	// The last active owner keeps the role when they leave the owner group
+	if err := tx.RawQuery("UPDATE users SET role = ? WHERE role = ? AND id <> ?", models.RoleHacker, models.RoleOwner, promoted.ID).Exec(); err != nil {
+		t.Fatal(err)
+	}
+	last, err := a.ldapUser(nil, tx, &ldapIdentity{DN: "uid=promoted", Email: promoted.Email, OwnerMapped: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Role != models.RoleOwner {
+		t.Errorf("the last owner was demoted to %s", last.Role)
+	}
+}
+
+func TestAuthCreate_LDAP(t *testing.T) {
+	db := requireTestDB(t)
+	domain := fmt.Sprintf("ldap-%s.example.com", uuid.Must(uuid.NewV4()).String()[:8])
+	directory := testLDAPConfig(t, domain)
+	setTestConfig(t, db, func(config *models.CompanyConfiguration) {
+		config.LDAPEnabled = true
+		config.LDAPURL = directory.LDAPURL
+		config.LDAPBindDN = directory.LDAPBindDN
+		config.LDAPBindPassword = directory.LDAPBindPassword
+		config.LDAPBaseDN = directory.LDAPBaseDN
+		config.LDAPUserFilter = directory.LDAPUserFilter
+		config.LDAPNameAttribute = directory.LDAPNameAttribute
+		config.LDAPTeamAttribute = directory.LDAPTeamAttribute
+		config.LDAPOwnerGroupDN = testLDAPOwnersDN
+		config.LDAPHackerGroupDN = testLDAPHackersDN
+	})
+	t.Cleanup(func() {
+		for _, table := range []string{"users", "login_attempts"} {
+			if err := db.RawQuery(fmt.Sprintf("DELETE FROM %s WHERE email LIKE ?", table), "%@"+domain).Exec(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	// Another owner keeps Ada's owner role from being the last one
+	createTestUser(t, db, models.RoleOwner)
+
+	signIn := func(email, password string) (testResponse, bool) {
+		b := newTestBrowser(t)
+		res := b.post("/signin", url.Values{"Email": {email}, "Password": {password}})
+		return res, b.get("/hackathons").Code == http.StatusOK
+	}
+
+	res, signedIn := signIn("ada@"+domain, "directory-password")
+	if res.Location != "/" || !signedIn {
+		t.Fatalf("signing in with the directory password answered %d to %q", res.Code, res.Location)
+	}
+	ada := &models.User{}
+	if err := db.Where("email = ?", "ada@"+domain).First(ada); err != nil {
+		t.Fatal(err)
+	}
+	if ada.Name != "Ada Lovelace" || ada.CompanyTeam != "Platform" || ada.Role != models.RoleOwner || ada.LDAPDN.String != "uid=ada,ou=people,dc=example,dc=com" {
+		t.Errorf("created %+v, want Ada Lovelace of Platform as an owner linked to her entry", ada)
+	}
+
+	for name, credentials := range map[string][2]string{
+		"wrong password":      {"ada@" + domain, "guess"},
+		"in no allowed group": {"eve@" + domain, "directory-password"},
+		"local password":      {"ada@" + domain, "Test-Passw0rd!"},
+	} {
+		if res, signedIn := signIn(credentials[0], credentials[1]); res.Location != "/signin" || signedIn {
+			t.Errorf("%s answered %d to %q, want a redirect to /signin", name, res.Code, res.Location)
+		}
+	}
+	count, err := db.Where("email = ?", "eve@"+domain).Count(&models.User{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Error("an account was created for an entry in no allowed group")
+	}
+
+	// Accounts the directory doesn't know keep signing in with their local password
+	local := createTestUser(t, db, models.RoleHacker)
+	if res, signedIn := signIn(local.Email, "Test-Passw0rd!"); res.Location != "/" || !signedIn {
+		t.Errorf("signing in with a local password answered %d to %q", res.Code, res.Location)
+	}
+}
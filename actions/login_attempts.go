@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v6"
+)
+
+const (
+	// loginThrottleWindow is the period failed sign-ins count towards the backoff below
+	loginThrottleWindow = time.Hour
+	// loginFreeAttemptsPerAccount and loginFreeAttemptsPerIP are the failures allowed before
+	// each further one doubles the wait. Addresses get more room since offices share them.
+	loginFreeAttemptsPerAccount = 3
+	loginFreeAttemptsPerIP      = 10
+	loginMaxBackoff             = 15 * time.Minute
+)
+
+// loginBackoffRemaining returns how long a client has to wait after its failed sign-ins, newest first.
+// The wait starts at one second once the free attempts are used up and doubles with every failure.
+func loginBackoffRemaining(attempts models.LoginAttempts, since time.Time, free int, now time.Time) time.Duration {
+	failures := 0
+	for _, attempt := range attempts {
+		if !attempt.CreatedAt.Before(since) {
+			failures++
+		}
+	}
+	if failures <= free {
+		return 0
+	}
+
+	backoff := loginMaxBackoff
+	if shift := failures - free - 1; shift < 20 {
+		backoff = min(time.Second<<shift, loginMaxBackoff)
+	}
+	return attempts[0].CreatedAt.Add(backoff).Sub(now)
+}
+
+// loginLockRemaining returns how long an email address stays locked given its failed sign-ins, newest first
+func loginLockRemaining(attempts models.LoginAttempts, config *models.CompanyConfiguration, now time.Time) time.Duration {
+	threshold := config.LoginLockoutThreshold
+	if threshold <= 0 || len(attempts) < threshold {
+		return 0
+	}
+	return attempts[threshold-1].CreatedAt.Add(config.LoginLockoutDuration()).Sub(now)
+}
+
+// loginThrottle returns how long a sign-in for an email address from an IP address has to wait,
+// and whether that is because the account is locked. Unknown addresses are treated the same way.
+func loginThrottle(repoManager repository.RepositoryInterface, config *models.CompanyConfiguration, email, ip string, now time.Time) (time.Duration, bool, error) {
+	windowStart := now.Add(-loginThrottleWindow)
+	since := windowStart
+	if lockoutStart := now.Add(-config.LoginLockoutDuration()); lockoutStart.Before(since) {
+		since = lockoutStart
+	}
+
+	emailAttempts, err := repoManager.LoginAttemptFindByEmailSince(email, since)
+	if err != nil {
+		return 0, false, err
+	}
+	if locked := loginLockRemaining(*emailAttempts, config, now); locked > 0 {
+		return locked, true, nil
+	}
+
+	ipAttempts, err := repoManager.LoginAttemptFindByIPAddressSince(ip, windowStart)
+	if err != nil {
+		return 0, false, err
+	}
+
+	wait := max(
+		loginBackoffRemaining(*emailAttempts, windowStart, loginFreeAttemptsPerAccount, now),
+		loginBackoffRemaining(*ipAttempts, windowStart, loginFreeAttemptsPerIP, now),
+	)
+	return wait, false, nil
+}
+
+// loginThrottleMessage tells the user how long to wait before trying again
+func loginThrottleMessage(wait time.Duration, locked bool) string {
+	if locked {
+		return fmt.Sprintf("This account is temporarily locked after too many failed sign-in attempts. Try again in %d minutes or reset your password.", int(math.Ceil(wait.Minutes())))
+	}
+	return fmt.Sprintf("Too many sign-in attempts. Please wait %d seconds before trying again.", int(math.Ceil(wait.Seconds())))
+}
+
+// recordLoginFailure stores a failed sign-in and reports whether it locked the account
+func (a *MyApp) recordLoginFailure(c buffalo.Context, tx *pop.Connection, config *models.CompanyConfiguration, email, ip string) (bool, error) {
+	if err := tx.Create(&models.LoginAttempt{Email: email, IPAddress: ip}); err != nil {
+		return false, err
+	}
+	if config.LoginLockoutThreshold <= 0 {
+		return false, nil
+	}
+
+	attempts, err := a.Repository(tx).LoginAttemptFindByEmailSince(email, time.Now().Add(-config.LoginLockoutDuration()))
+	if err != nil {
+		return false, err
+	}
+	if len(*attempts) < config.LoginLockoutThreshold {
+		return false, nil
+	}
+
+	logAuditEvent(tx, c, nil, "account_locked", "user", nil, fmt.Sprintf("Sign-in locked for %s after %d failed attempts", email, len(*attempts)))
+	return true, nil
+}
+
+// AdminPasswordsUnlock clears the failed sign-ins of an email address so it can sign in again
+func (a *MyApp) AdminPasswordsUnlock(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	email := strings.ToLower(strings.TrimSpace(c.Param("email")))
+	if email == "" {
+		return c.Error(http.StatusBadRequest, fmt.Errorf("email is required"))
+	}
+
+	if err := repoManager.LoginAttemptDeleteByEmail(email); err != nil {
+		return err
+	}
+
+	var userID interface{}
+	if user, err := repoManager.UserFindByEmail(email); err == nil {
+		userID = &user.ID
+	}
+	logAuditEvent(tx, c, &currentUser.ID, "unlock_account", "user", userID, fmt.Sprintf("Admin unlocked sign-in for %s", email))
+
+	c.Flash().Add("success", fmt.Sprintf("%s can sign in again", email))
+	return c.Redirect(http.StatusSeeOther, "/admin/passwords")
+}
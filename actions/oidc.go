@@ -0,0 +1,384 @@
+package actions
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"golang.org/x/oauth2"
+)
+
+const (
+	sessionOIDCState    = "oidc_state"
+	sessionOIDCNonce    = "oidc_nonce"
+	sessionOIDCVerifier = "oidc_verifier"
+
+	oidcCallbackPath = "/signin/sso/callback"
+)
+
+// oidcHTTPClient talks to the identity provider for discovery, keys and code exchanges
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// oidcProviders caches the discovery document and signing keys of each issuer
+var oidcProviders = struct {
+	sync.Mutex
+	byIssuer map[string]*oidc.Provider
+}{byIssuer: map[string]*oidc.Provider{}}
+
+// oidcIdentity is who the identity provider says signed in
+type oidcIdentity struct {
+	Subject string
+	Email   string
+	Name    string
+	Owner   bool
+	// OwnerMapped is true when an owner claim is configured, Owner then decides the role
+	OwnerMapped bool
+}
+
+// oidcRejection is a reason an identity can't sign in that is safe to show to the user
+type oidcRejection string
+
+func (r oidcRejection) Error() string {
+	return string(r)
+}
+
+// oidcProvider returns the provider of an issuer, running discovery the first time it is used
+func oidcProvider(issuer string) (*oidc.Provider, error) {
+	oidcProviders.Lock()
+	defer oidcProviders.Unlock()
+
+	if provider, ok := oidcProviders.byIssuer[issuer]; ok {
+		return provider, nil
+	}
+
+	// The provider keeps the context to refresh signing keys, so it must outlive the request
+	provider, err := oidc.NewProvider(oidc.ClientContext(context.Background(), oidcHTTPClient), issuer)
+	if err != nil {
+		return nil, err
+	}
+	oidcProviders.byIssuer[issuer] = provider
+	return provider, nil
+}
+
+// oidcOAuth2Config returns the authorization code flow settings for the configured client
+func (a *MyApp) oidcOAuth2Config(config *models.CompanyConfiguration, provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     config.OIDCClientID,
+		ClientSecret: config.OIDCClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  strings.TrimSuffix(a.Options.Host, "/") + oidcCallbackPath,
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+}
+
+// randomURLToken returns a random value for the state and nonce of a sign-in
+func randomURLToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// randomPassword returns a password for accounts that sign in elsewhere. The suffix makes it
+// meet every password policy so creating the account can't fail on it.
+func randomPassword() (string, error) {
+	token, err := randomURLToken()
+	if err != nil {
+		return "", err
+	}
+	return token + "Aa1!", nil
+}
+
+// claimMatches returns true when a claim is the expected value or a list containing it
+func claimMatches(claim interface{}, value string) bool {
+	switch v := claim.(type) {
+	case string:
+		return v == value
+	case bool:
+		return fmt.Sprint(v) == value
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// oidcIdentityFromClaims reads the user's details from the claims of an ID token
+func oidcIdentityFromClaims(config *models.CompanyConfiguration, subject string, claims map[string]interface{}) (oidcIdentity, error) {
+	identity := oidcIdentity{Subject: subject}
+
+	email, _ := claims["email"].(string)
+	identity.Email = strings.ToLower(strings.TrimSpace(email))
+	if identity.Email == "" {
+		return identity, oidcRejection("the identity provider did not share an email address")
+	}
+	// Providers that don't send email_verified at all are trusted to only hand out company addresses
+	if verified, ok := claims["email_verified"].(bool); ok && !verified {
+		return identity, oidcRejection(fmt.Sprintf("the email address %s is not verified with the identity provider", identity.Email))
+	}
+
+	identity.Name, _ = claims["name"].(string)
+	if identity.Name == "" {
+		given, _ := claims["given_name"].(string)
+		family, _ := claims["family_name"].(string)
+		identity.Name = strings.TrimSpace(given + " " + family)
+	}
+	if identity.Name == "" {
+		identity.Name, _ = claims["preferred_username"].(string)
+	}
+	if identity.Name == "" {
+		identity.Name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	if config.OIDCOwnerClaim != "" && config.OIDCOwnerValue != "" {
+		identity.OwnerMapped = true
+		identity.Owner = claimMatches(claims[config.OIDCOwnerClaim], config.OIDCOwnerValue)
+	}
+	return identity, nil
+}
+
+// oidcUser finds the account of a single sign-on identity, linking it by email or creating
+// it on first sign-in, and keeps its name and owner role in step with the provider.
+func (a *MyApp) oidcUser(c buffalo.Context, tx *pop.Connection, identity oidcIdentity) (*models.User, error) {
+	repoManager := a.Repository(tx)
+
+	_, domain, _ := strings.Cut(identity.Email, "@")
+	allowed, err := repoManager.CompanyAllowedDomainIsDomainAllowed(domain)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, oidcRejection(fmt.Sprintf("the email domain %s is not allowed", domain))
+	}
+
+	user, err := repoManager.UserFindByOIDCSubject(identity.Subject)
+	if err != nil {
+		user, err = repoManager.UserFindByEmail(identity.Email)
+		if err == nil {
+			if user.OIDCSubject.Valid {
+				return nil, oidcRejection(fmt.Sprintf("%s is already linked to another single sign-on identity", identity.Email))
+			}
+			user.OIDCSubject = nulls.NewString(identity.Subject)
+			if !user.IsEmailVerified() {
+				user.EmailVerifiedAt = nulls.NewTime(time.Now())
+			}
+			if err := tx.UpdateColumns(user, "oidc_subject", "email_verified_at", "updated_at"); err != nil {
+				return nil, err
+			}
+			logAuditEvent(tx, c, &user.ID, "link_sso", "user", &user.ID, fmt.Sprintf("Linked single sign-on identity to %s", user.Email))
+		} else {
+			// The password is never shown to anyone, it only satisfies the password sign-in checks
+			password, err := randomPassword()
+			if err != nil {
+				return nil, err
+			}
+			user = &models.User{
+				Email:                identity.Email,
+				Name:                 identity.Name,
+				Password:             password,
+				PasswordConfirmation: password,
+				EmailVerifiedAt:      nulls.NewTime(time.Now()),
+				OIDCSubject:          nulls.NewString(identity.Subject),
+			}
+			verrs, err := user.Create(tx)
+			if err != nil {
+				return nil, err
+			}
+			if verrs.HasAny() {
+				return nil, oidcRejection(fmt.Sprintf("could not create an account for %s: %s", identity.Email, verrs.Error()))
+			}
+			logAuditEvent(tx, c, &user.ID, "register", "user", &user.ID, fmt.Sprintf("User registered through single sign-on: %s (%s)", user.Name, user.Email))
+		}
+	}
+
+	if identity.Name != "" && user.Name != identity.Name {
+		user.Name = identity.Name
+		if err := tx.UpdateColumns(user, "name", "updated_at"); err != nil {
+			return nil, err
+		}
+	}
+
+	if identity.OwnerMapped {
+		changed, err := a.setOwnerRole(tx, user, identity.Owner)
+		if err != nil {
+			return nil, err
+		}
+		if changed && identity.Owner {
+			logAuditEvent(tx, c, &user.ID, "sso_grant_owner", "user", &user.ID, fmt.Sprintf("Owner role granted by single sign-on claim to %s", user.Email))
+		} else if changed {
+			logAuditEvent(tx, c, &user.ID, "sso_revoke_owner", "user", &user.ID, fmt.Sprintf("Owner role removed from %s, the single sign-on claim no longer matches", user.Email))
+		}
+	}
+
+	return user, nil
+}
+
+// setOwnerRole gives a user the owner role, or takes it away for the hacker role, as the role
+// mapping of an identity provider says. It returns true when the role changed. The last active
+// owner keeps the role so the app is never left without anyone to run it.
+func (a *MyApp) setOwnerRole(tx *pop.Connection, user *models.User, owner bool) (bool, error) {
+	if owner == user.IsOwner() {
+		return false, nil
+	}
+	if owner {
+		user.Role = models.RoleOwner
+	} else {
+		last, err := isLastActiveOwner(a.Repository(tx), user)
+		if err != nil || last {
+			return false, err
+		}
+		user.Role = models.RoleHacker
+	}
+	return true, tx.UpdateColumns(user, "role", "updated_at")
+}
+
+// ssoOnly sends the client back to the sign-in page when password sign-in is turned off
+func ssoOnly(c buffalo.Context, tx *pop.Connection) (bool, error) {
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return true, err
+	}
+	if config.PasswordLoginAllowed() {
+		return false, nil
+	}
+
+	c.Flash().Add("info", fmt.Sprintf("Password sign-in is turned off. Sign in with %s instead.", config.OIDCDisplayName()))
+	return true, c.Redirect(http.StatusFound, "/signin")
+}
+
+// AuthOIDCNew sends the user to the identity provider to sign in.
+func (a *MyApp) AuthOIDCNew(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+	if !config.OIDCConfigured() {
+		c.Flash().Add("danger", "Single sign-on is not configured")
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+
+	provider, err := oidcProvider(config.OIDCIssuerURL)
+	if err != nil {
+		c.Logger().Errorf("Failed to discover OIDC provider %s: %v", config.OIDCIssuerURL, err)
+		c.Flash().Add("danger", fmt.Sprintf("We couldn't reach %s. Please try again later.", config.OIDCDisplayName()))
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+
+	state, err := randomURLToken()
+	if err != nil {
+		return err
+	}
+	nonce, err := randomURLToken()
+	if err != nil {
+		return err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	c.Session().Set(sessionOIDCState, state)
+	c.Session().Set(sessionOIDCNonce, nonce)
+	c.Session().Set(sessionOIDCVerifier, verifier)
+
+	authURL := a.oidcOAuth2Config(config, provider).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// AuthOIDCCallback signs the user in with the authorization code from the identity provider.
+func (a *MyApp) AuthOIDCCallback(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	state, _ := c.Session().Get(sessionOIDCState).(string)
+	nonce, _ := c.Session().Get(sessionOIDCNonce).(string)
+	verifier, _ := c.Session().Get(sessionOIDCVerifier).(string)
+	c.Session().Delete(sessionOIDCState)
+	c.Session().Delete(sessionOIDCNonce)
+	c.Session().Delete(sessionOIDCVerifier)
+
+	fail := func(reason string) error {
+		logAuditEvent(tx, c, nil, "sso_login_failed", "user", nil, fmt.Sprintf("Single sign-on failed: %s", reason))
+		c.Flash().Add("danger", fmt.Sprintf("Single sign-on failed: %s.", reason))
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+
+	if providerErr := c.Param("error"); providerErr != "" {
+		if description := c.Param("error_description"); description != "" {
+			return fail(description)
+		}
+		return fail(providerErr)
+	}
+	if state == "" || c.Param("state") != state {
+		return fail("the sign-in request expired, please try again")
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+	if !config.OIDCConfigured() {
+		return fail("single sign-on is not configured")
+	}
+
+	provider, err := oidcProvider(config.OIDCIssuerURL)
+	if err != nil {
+		c.Logger().Errorf("Failed to discover OIDC provider %s: %v", config.OIDCIssuerURL, err)
+		return fail("the identity provider could not be reached")
+	}
+
+	ctx := oidc.ClientContext(c.Request().Context(), oidcHTTPClient)
+	token, err := a.oidcOAuth2Config(config, provider).Exchange(ctx, c.Param("code"), oauth2.VerifierOption(verifier))
+	if err != nil {
+		c.Logger().Errorf("Failed to exchange OIDC authorization code: %v", err)
+		return fail("the identity provider rejected the sign-in")
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return fail("the identity provider did not return an ID token")
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: config.OIDCClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		c.Logger().Errorf("Failed to verify OIDC ID token: %v", err)
+		return fail("the ID token could not be verified")
+	}
+	if idToken.Nonce != nonce {
+		return fail("the ID token does not belong to this sign-in")
+	}
+
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return fail("the ID token claims could not be read")
+	}
+
+	identity, err := oidcIdentityFromClaims(config, idToken.Subject, claims)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	user, err := a.oidcUser(c, tx, identity)
+	var rejection oidcRejection
+	if errors.As(err, &rejection) {
+		return fail(rejection.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return beginSignIn(c, tx, user)
+}
@@ -0,0 +1,566 @@
+package actions
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+func TestClaimMatches(t *testing.T) {
+	tests := []struct {
+		claim interface{}
+		value string
+		want  bool
+	}{
+		{"admins", "admins", true},
+		{"Admins", "admins", false},
+		{"", "admins", false},
+		{true, "true", true},
+		{false, "true", false},
+		{[]interface{}{"staff", "admins"}, "admins", true},
+		{[]interface{}{"staff", 1.0, nil}, "admins", false},
+		{[]interface{}{}, "admins", false},
+		{1.0, "1", false},
+		{nil, "admins", false},
+		{map[string]interface{}{"admins": true}, "admins", false},
+	}
+	for _, tt := range tests {
+		if got := claimMatches(tt.claim, tt.value); got != tt.want {
+			t.Errorf("claimMatches(%#v, %q) = %v, want %v", tt.claim, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestOIDCIdentityFromClaims(t *testing.T) {
+	mapped := &models.CompanyConfiguration{OIDCOwnerClaim: "groups", OIDCOwnerValue: "admins"}
+
+	tests := []struct {
+		name     string
+		config   *models.CompanyConfiguration
+		claims   map[string]interface{}
+		want     oidcIdentity
+		rejected bool
+	}{
+		{
+			name:   "full profile",
+			config: &models.CompanyConfiguration{},
+			claims: map[string]interface{}{"email": " Ada@Example.com ", "email_verified": true, "name": "Ada Lovelace"},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "Ada Lovelace"},
+		},
+		{
+			name:   "given and family name",
+			config: &models.CompanyConfiguration{},
+			claims: map[string]interface{}{"email": "ada@example.com", "given_name": "Ada", "family_name": "Lovelace"},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "Ada Lovelace"},
+		},
+		{
+			name:   "preferred username",
+			config: &models.CompanyConfiguration{},
+			claims: map[string]interface{}{"email": "ada@example.com", "preferred_username": "ada.l"},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "ada.l"},
+		},
+		{
+			name:   "local part of the email",
+			config: &models.CompanyConfiguration{},
+			claims: map[string]interface{}{"email": "ada@example.com"},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "ada"},
+		},
+		{
+			name:     "no email",
+			config:   &models.CompanyConfiguration{},
+			claims:   map[string]interface{}{"name": "Ada"},
+			rejected: true,
+		},
+		{
+			name:     "unverified email",
+			config:   &models.CompanyConfiguration{},
+			claims:   map[string]interface{}{"email": "ada@example.com", "email_verified": false},
+			rejected: true,
+		},
+		{
+			name:   "owner claim matches",
+			config: mapped,
+			claims: map[string]interface{}{"email": "ada@example.com", "name": "Ada", "groups": []interface{}{"staff", "admins"}},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "Ada", Owner: true, OwnerMapped: true},
+		},
+		{
+			name:   "owner claim doesn't match",
+			config: mapped,
+			claims: map[string]interface{}{"email": "ada@example.com", "name": "Ada", "groups": []interface{}{"staff"}},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "Ada", OwnerMapped: true},
+		},
+		{
+			name:   "owner claim missing",
+			config: mapped,
+			claims: map[string]interface{}{"email": "ada@example.com", "name": "Ada"},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "Ada", OwnerMapped: true},
+		},
+		{
+			name:   "owner claim without a value isn't mapped",
+			config: &models.CompanyConfiguration{OIDCOwnerClaim: "groups"},
+			claims: map[string]interface{}{"email": "ada@example.com", "name": "Ada", "groups": []interface{}{"admins"}},
+			want:   oidcIdentity{Subject: "sub", Email: "ada@example.com", Name: "Ada"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := oidcIdentityFromClaims(tt.config, "sub", tt.claims)
+			var rejection oidcRejection
+			if tt.rejected {
+				if !errors.As(err, &rejection) {
+					t.Errorf("got %+v, %v; want a rejection", identity, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identity != tt.want {
+				t.Errorf("got %+v, want %+v", identity, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCUser(t *testing.T) {
+	tx := testTx(t)
+	a := &MyApp{}
+	createTestUser(t, tx, models.RoleOwner)
+	domain := fmt.Sprintf("sso-%s.example.com", uuid.Must(uuid.NewV4()).String()[:8])
+	createTestDomainRule(t, tx, domain, false)
+	createTestDomainRule(t, tx, "blocked."+domain, true)
+
+	// A new identity gets an account
+	created, err := a.oidcUser(nil, tx, oidcIdentity{Subject: "new", Email: "new@" + domain, Name: "New Person"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.OIDCSubject.String != "new" || created.Name != "New Person" || created.Role != models.RoleHacker || !created.IsEmailVerified() {
+		t.Errorf("created %+v, want a verified hacker linked to subject new", created)
+	}
+
+	// Signing in again finds the account by subject and follows the provider's name
+	again, err := a.oidcUser(nil, tx, oidcIdentity{Subject: "new", Email: "new@" + domain, Name: "Renamed Person"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != created.ID || again.Name != "Renamed Person" {
+		t.Errorf("signing in again gave %s named %q, want %s named %q", again.ID, again.Name, created.ID, "Renamed Person")
+	}
+
+	// An account with the same email is linked
+	existing := createTestUser(t, tx, models.RoleHacker)
+	existing.Email = "existing@" + domain
+	if err := tx.UpdateColumns(existing, "email"); err != nil {
+		t.Fatal(err)
+	}
+	linked, err := a.oidcUser(nil, tx, oidcIdentity{Subject: "existing", Email: existing.Email, Name: existing.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linked.ID != existing.ID || linked.OIDCSubject.String != "existing" {
+		t.Errorf("linked %s to %q, want %s linked to subject existing", linked.ID, linked.OIDCSubject.String, existing.ID)
+	}
+
+	// ...but not when it's already linked to another identity
+	var rejection oidcRejection
+	_, err = a.oidcUser(nil, tx, oidcIdentity{Subject: "other", Email: existing.Email, Name: existing.Name})
+	if !errors.As(err, &rejection) {
+		t.Errorf("linking an account of another identity returned %v, want a rejection", err)
+	}
+
+	// Blocked domains can't sign in
+	_, err = a.oidcUser(nil, tx, oidcIdentity{Subject: "blocked", Email: "someone@blocked." + domain, Name: "Blocked"})
+	if !errors.As(err, &rejection) {
+		t.Errorf("signing in from a blocked domain returned %v, want a rejection", err)
+	}
+
+	// The owner claim grants and takes away the owner role
+	owner, err := a.oidcUser(nil, tx, oidcIdentity{Subject: "new", Email: "new@" + domain, Name: "Renamed Person", Owner: true, OwnerMapped: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner.Role != models.RoleOwner {
+		t.Errorf("role with the owner claim is %s, want %s", owner.Role, models.RoleOwner)
+	}
+	hacker, err := a.oidcUser(nil, tx, oidcIdentity{Subject: "new", Email: "new@" + domain, Name: "Renamed Person", OwnerMapped: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hacker.Role != models.RoleHacker {
+		t.Errorf("role without the owner claim is %s, want %s", hacker.Role, models.RoleHacker)
+	}
+	for action, want := range map[string]int{"sso_grant_owner": 1, "sso_revoke_owner": 1, "link_sso": 1} {
+		count, err := tx.Where("action = ? AND user_id IN (?, ?)", action, created.ID, existing.ID).Count(&models.AuditLog{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != want {
+			t.Errorf("%d %s audit events, want %d", count, action, want)
+		}
+	}
+
+	// Without an owner claim configured the role is left alone
+	promoted := createTestUser(t, tx, models.RoleOwner)
+	promoted.OIDCSubject = nulls.NewString("promoted")
+	if err := tx.UpdateColumns(promoted, "oidc_subject"); err != nil {
+		t.Fatal(err)
+	}
+	unmapped, err := a.oidcUser(nil, tx, oidcIdentity{Subject: "promoted", Email: promoted.Email, Name: promoted.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unmapped.Role != models.RoleOwner {
+		t.Errorf("role without an owner claim configured is %s, want %s", unmapped.Role, models.RoleOwner)
+	}
+}
+
+func TestSetOwnerRole_KeepsLastOwner(t *testing.T) {
+	tx := testTx(t)
+	a := &MyApp{}
+	if err := tx.RawQuery("UPDATE users SET role = ? WHERE role = ?", models.RoleHacker, models.RoleOwner).Exec(); err != nil {
+		t.Fatal(err)
+	}
+	last := createTestUser(t, tx, models.RoleOwner)
+
+	changed, err := a.setOwnerRole(tx, last, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || last.Role != models.RoleOwner {
+		t.Errorf("the last owner was demoted to %s", last.Role)
+	}
+
+	// A deactivated owner doesn't count
+	other := createTestUser(t, tx, models.RoleOwner)
+	other.DeactivatedAt = nulls.NewTime(time.Now())
+	if err := tx.UpdateColumns(other, "deactivated_at"); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := a.setOwnerRole(tx, last, false); err != nil || changed {
+		t.Errorf("the last active owner was demoted: %v", err)
+	}
+
+	// Once there is another owner the role can go
+	if _, err := a.setOwnerRole(tx, createTestUser(t, tx, models.RoleHacker), true); err != nil {
+		t.Fatal(err)
+	}
+	changed, err = a.setOwnerRole(tx, last, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := &models.User{}
+	if err := tx.Find(stored, last.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !changed || stored.Role != models.RoleHacker {
+		t.Errorf("demoting an owner with another owner left role %s", stored.Role)
+	}
+}
+
+// testOIDCProvider is an identity provider for the single sign-on tests. The tests hand out
+// its authorization codes themselves instead of filling in a sign-in form.
+type testOIDCProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]testOIDCCode
+}
+
+// testOIDCCode is an authorization code and the sign-in it stands for
+type testOIDCCode struct {
+	nonce       string
+	challenge   string
+	redirectURI string
+	claims      map[string]interface{}
+}
+
+const (
+	testOIDCClientID     = "hackathon"
+	testOIDCClientSecret = "secret"
+)
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &testOIDCProvider{t: t, key: key, codes: map[string]testOIDCCode{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		p.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"issuer":                                p.server.URL,
+			"authorization_endpoint":                p.server.URL + "/authorize",
+			"token_endpoint":                        p.server.URL + "/token",
+			"jwks_uri":                              p.server.URL + "/jwks",
+			"response_types_supported":              []string{"code"},
+			"subject_types_supported":               []string{"public"},
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		p.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *testOIDCProvider) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		p.t.Error(err)
+	}
+}
+
+// token exchanges an authorization code for a signed ID token, checking the client and PKCE verifier
+func (p *testOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		p.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	clientID, clientSecret := r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	if user, password, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(user)
+		clientSecret, _ = url.QueryUnescape(password)
+	}
+	if clientID != testOIDCClientID || clientSecret != testOIDCClientSecret {
+		p.writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	code, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || code.redirectURI != r.PostForm.Get("redirect_uri") || base64.RawURLEncoding.EncodeToString(sum[:]) != code.challenge {
+		p.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":   p.server.URL,
+		"aud":   testOIDCClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": code.nonce,
+	}
+	for name, value := range code.claims {
+		claims[name] = value
+	}
+	p.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     p.sign(claims),
+	})
+}
+
+// sign returns the claims as a RS256 JSON web token
+func (p *testOIDCProvider) sign(claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "test"})
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// signIn starts single sign-on in the browser, lets the provider vouch for the claims and
+// returns the app's answer to the callback
+func (p *testOIDCProvider) signIn(b *testBrowser, claims map[string]interface{}) testResponse {
+	p.t.Helper()
+	res := b.get("/signin/sso")
+	if res.Code != http.StatusFound || !strings.HasPrefix(res.Location, p.server.URL+"/authorize?") {
+		p.t.Fatalf("starting single sign-on answered %d to %q, want a redirect to the provider", res.Code, res.Location)
+	}
+	authorize, err := url.Parse(res.Location)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	query := authorize.Query()
+	if query.Get("client_id") != testOIDCClientID || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" || query.Get("nonce") == "" {
+		p.t.Fatalf("the authorization request %s is missing the client, PKCE challenge or nonce", res.Location)
+	}
+
+	code := uuid.Must(uuid.NewV4()).String()
+	p.mu.Lock()
+	p.codes[code] = testOIDCCode{
+		nonce:       query.Get("nonce"),
+		challenge:   query.Get("code_challenge"),
+		redirectURI: query.Get("redirect_uri"),
+		claims:      claims,
+	}
+	p.mu.Unlock()
+	return b.get(oidcCallbackPath + "?" + url.Values{"code": {code}, "state": {query.Get("state")}}.Encode())
+}
+
+func TestAuthOIDCCallback(t *testing.T) {
+	db := requireTestDB(t)
+	provider := newTestOIDCProvider(t)
+	setTestConfig(t, db, func(config *models.CompanyConfiguration) {
+		config.OIDCEnabled = true
+		config.OIDCIssuerURL = provider.server.URL
+		config.OIDCClientID = testOIDCClientID
+		config.OIDCClientSecret = testOIDCClientSecret
+		config.OIDCOwnerClaim = "groups"
+		config.OIDCOwnerValue = "hackathon-owners"
+	})
+	domain := fmt.Sprintf("sso-%s.example.com", uuid.Must(uuid.NewV4()).String()[:8])
+	createTestDomainRule(t, db, domain, false)
+	createTestDomainRule(t, db, "blocked."+domain, true)
+	t.Cleanup(func() {
+		if err := db.RawQuery("DELETE FROM users WHERE email LIKE ?", "%"+domain).Exec(); err != nil {
+			t.Error(err)
+		}
+	})
+	// Another owner keeps the owner role of the signed in user from being the last one
+	createTestUser(t, db, models.RoleOwner)
+
+	findUser := func(email string) *models.User {
+		t.Helper()
+		user := &models.User{}
+		if err := db.Where("email = ?", email).First(user); err != nil {
+			t.Fatalf("no account for %s: %v", email, err)
+		}
+		return user
+	}
+	signedIn := func(b *testBrowser) bool {
+		t.Helper()
+		return b.get("/hackathons").Code == http.StatusOK
+	}
+
+	email := "grace@" + domain
+	claims := map[string]interface{}{"sub": "grace-subject", "email": email, "email_verified": true, "name": "Grace Hopper"}
+
+	t.Run("creates an account", func(t *testing.T) {
+		b := newTestBrowser(t)
+		res := provider.signIn(b, claims)
+		if res.Code != http.StatusFound || res.Location != "/" {
+			t.Fatalf("callback answered %d to %q, want a redirect to /", res.Code, res.Location)
+		}
+		if !signedIn(b) {
+			t.Error("the browser isn't signed in")
+		}
+		user := findUser(email)
+		if user.OIDCSubject.String != "grace-subject" || user.Name != "Grace Hopper" || user.Role != models.RoleHacker || !user.IsEmailVerified() {
+			t.Errorf("created %+v, want a verified hacker named Grace Hopper linked to grace-subject", user)
+		}
+	})
+
+	t.Run("grants and revokes the owner role", func(t *testing.T) {
+		owner := map[string]interface{}{"groups": []interface{}{"engineering", "hackathon-owners"}}
+		for name, value := range claims {
+			owner[name] = value
+		}
+		if res := provider.signIn(newTestBrowser(t), owner); res.Location != "/" {
+			t.Fatalf("callback redirected to %q, want /", res.Location)
+		}
+		if role := findUser(email).Role; role != models.RoleOwner {
+			t.Errorf("role with the owner group is %s, want %s", role, models.RoleOwner)
+		}
+
+		if res := provider.signIn(newTestBrowser(t), claims); res.Location != "/" {
+			t.Fatalf("callback redirected to %q, want /", res.Location)
+		}
+		if role := findUser(email).Role; role != models.RoleHacker {
+			t.Errorf("role without the owner group is %s, want %s", role, models.RoleHacker)
+		}
+	})
+
+	t.Run("links an account with the same email", func(t *testing.T) {
+		existing := createTestUser(t, db, models.RoleHacker)
+		existing.Email = "existing@" + domain
+		if err := db.UpdateColumns(existing, "email"); err != nil {
+			t.Fatal(err)
+		}
+		res := provider.signIn(newTestBrowser(t), map[string]interface{}{"sub": "existing-subject", "email": existing.Email, "name": existing.Name})
+		if res.Location != "/" {
+			t.Fatalf("callback redirected to %q, want /", res.Location)
+		}
+		if user := findUser(existing.Email); user.ID != existing.ID || user.OIDCSubject.String != "existing-subject" {
+			t.Errorf("signed in as %s linked to %q, want %s linked to existing-subject", user.ID, user.OIDCSubject.String, existing.ID)
+		}
+	})
+
+	rejected := map[string]map[string]interface{}{
+		"blocked domain":   {"sub": "blocked-subject", "email": "someone@blocked." + domain, "name": "Blocked"},
+		"unverified email": {"sub": "unverified-subject", "email": "unverified@" + domain, "email_verified": false},
+		"linked elsewhere": {"sub": "another-subject", "email": email},
+	}
+	for name, claims := range rejected {
+		t.Run("rejects "+name, func(t *testing.T) {
+			b := newTestBrowser(t)
+			res := provider.signIn(b, claims)
+			if res.Code != http.StatusFound || res.Location != "/signin" {
+				t.Errorf("callback answered %d to %q, want a redirect to /signin", res.Code, res.Location)
+			}
+			if signedIn(b) {
+				t.Error("the browser is signed in")
+			}
+			count, err := db.Where("oidc_subject = ?", claims["sub"]).Count(&models.User{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != 0 {
+				t.Errorf("%d accounts are linked to %s", count, claims["sub"])
+			}
+		})
+	}
+
+	t.Run("rejects a callback of another sign-in", func(t *testing.T) {
+		b := newTestBrowser(t)
+		if res := b.get("/signin/sso"); res.Code != http.StatusFound {
+			t.Fatalf("starting single sign-on answered %d", res.Code)
+		}
+		res := b.get(oidcCallbackPath + "?" + url.Values{"code": {"forged"}, "state": {"forged"}}.Encode())
+		if res.Code != http.StatusFound || res.Location != "/signin" || signedIn(b) {
+			t.Errorf("a forged state answered %d to %q", res.Code, res.Location)
+		}
+	})
+}
@@ -0,0 +1,384 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+)
+
+// The OpenAPI document at /api/v1/openapi.json is built from apiOperations and the Go types
+// the handlers read and write, so clients can be generated from it. Schemas follow the json
+// tags of those types. Fields tagged openapi:"-" and belongs_to or has_many associations,
+// which the API never loads, are left out, and openapi:"binary" marks uploaded content. Tests
+// fail when a registered API route has no operation here or an operation has no route.
+
+// apiOperation describes one API route in the OpenAPI document
+type apiOperation struct {
+	Method  string
+	Path    string
+	ID      string
+	Summary string
+	// Scope is the token scope the route needs besides read
+	Scope string
+	// Public routes don't need a token
+	Public bool
+	// Query are the query parameters besides the cursor and limit of lists
+	Query []string
+	// Body is the request body type, nil when there is none. Multipart bodies are forms instead of JSON.
+	Body      interface{}
+	Multipart bool
+	// Data is the response data type, a list of them when List is set and the whole body when Raw is set
+	Data   interface{}
+	List   bool
+	Raw    bool
+	Status int
+}
+
+var apiOperations = []apiOperation{
+	{Method: http.MethodGet, Path: "/openapi.json", ID: "getOpenAPI", Summary: "This OpenAPI document", Public: true, Raw: true, Status: http.StatusOK},
+	{Method: http.MethodGet, Path: "/me", ID: "getMe", Summary: "The account of the token", Data: apiUser{}, Status: http.StatusOK},
+	{Method: http.MethodGet, Path: "/hackathons", ID: "listHackathons", Summary: "List the hackathons the account can see", Data: models.Hackathon{}, List: true, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/hackathons", ID: "createHackathon", Summary: "Create a hackathon, owners only", Scope: models.APIScopeAdmin, Body: apiHackathonInput{}, Data: models.Hackathon{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/hackathons/{hackathon_id}", ID: "getHackathon", Summary: "Get a hackathon", Data: models.Hackathon{}, Status: http.StatusOK},
+	{Method: http.MethodPatch, Path: "/hackathons/{hackathon_id}", ID: "updateHackathon", Summary: "Change the fields sent of a hackathon the account owns", Scope: models.APIScopeAdmin, Body: apiHackathonInput{}, Data: models.Hackathon{}, Status: http.StatusOK},
+	{Method: http.MethodDelete, Path: "/hackathons/{hackathon_id}", ID: "deleteHackathon", Summary: "Delete a hackathon the account owns", Scope: models.APIScopeAdmin, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/hackathons/{hackathon_id}/projects", ID: "listProjects", Summary: "List the projects of a hackathon", Data: apiProject{}, List: true, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/hackathons/{hackathon_id}/projects", ID: "createProject", Summary: "Submit a project to a hackathon", Scope: models.APIScopeProjectsWrite, Body: apiProjectInput{}, Data: apiProject{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/projects/{project_id}", ID: "getProject", Summary: "Get a project", Data: apiProject{}, Status: http.StatusOK},
+	{Method: http.MethodPatch, Path: "/projects/{project_id}", ID: "updateProject", Summary: "Change the fields sent of a project the account owns", Scope: models.APIScopeProjectsWrite, Body: apiProjectInput{}, Data: apiProject{}, Status: http.StatusOK},
+	{Method: http.MethodGet, Path: "/projects/{project_id}/members", ID: "listProjectMembers", Summary: "List the members of a project", Data: apiMembership{}, List: true, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/projects/{project_id}/members", ID: "joinProject", Summary: "Join a project of a hackathon with open teams", Scope: models.APIScopeProjectsWrite, Data: apiMembership{}, Status: http.StatusCreated},
+	{Method: http.MethodDelete, Path: "/projects/{project_id}/members/{user_id}", ID: "leaveProject", Summary: "Leave a project, user_id must be the account of the token", Scope: models.APIScopeProjectsWrite, Status: http.StatusNoContent},
+	{Method: http.MethodGet, Path: "/files", ID: "listFiles", Summary: "List file metadata, optionally of one hackathon or project", Query: []string{"hackathon_id", "project_id"}, Data: apiFile{}, List: true, Status: http.StatusOK},
+	{Method: http.MethodPost, Path: "/files", ID: "uploadFile", Summary: "Upload a file of at most 10MB, optionally to a hackathon or a project of the account's team", Scope: models.APIScopeProjectsWrite, Body: apiFileUpload{}, Multipart: true, Data: apiFile{}, Status: http.StatusCreated},
+	{Method: http.MethodGet, Path: "/files/{file_id}", ID: "getFile", Summary: "Get the metadata of a file", Data: apiFile{}, Status: http.StatusOK},
+}
+
+// apiSchemaNames are the types that become named schemas in the document
+var apiSchemaNames = map[reflect.Type]string{
+	reflect.TypeOf(apiUser{}):           "User",
+	reflect.TypeOf(models.Hackathon{}):  "Hackathon",
+	reflect.TypeOf(apiProject{}):        "Project",
+	reflect.TypeOf(apiMembership{}):     "Membership",
+	reflect.TypeOf(apiFile{}):           "File",
+	reflect.TypeOf(apiHackathonInput{}): "HackathonInput",
+	reflect.TypeOf(apiProjectInput{}):   "ProjectInput",
+	reflect.TypeOf(apiFileUpload{}):     "FileUpload",
+	reflect.TypeOf(apiErrorResponse{}):  "Error",
+}
+
+// apiScalarSchemas are the types that are written as a single JSON value
+var apiScalarSchemas = map[reflect.Type]openAPISchema{
+	reflect.TypeOf(time.Time{}):    {Type: "string", Format: "date-time"},
+	reflect.TypeOf(uuid.UUID{}):    {Type: "string", Format: "uuid"},
+	reflect.TypeOf(nulls.Time{}):   {Type: []string{"string", "null"}, Format: "date-time"},
+	reflect.TypeOf(nulls.UUID{}):   {Type: []string{"string", "null"}, Format: "uuid"},
+	reflect.TypeOf(nulls.String{}): {Type: []string{"string", "null"}},
+	reflect.TypeOf(nulls.Int{}):    {Type: []string{"integer", "null"}},
+	reflect.TypeOf(nulls.Int64{}):  {Type: []string{"integer", "null"}, Format: "int64"},
+	reflect.TypeOf(nulls.Bool{}):   {Type: []string{"boolean", "null"}},
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Servers    []openAPIServer                         `json:"servers"`
+	Security   []map[string][]string                   `json:"security"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema        `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+// openAPISchema is a JSON Schema as OpenAPI 3.1 uses it. Type is a string, or a list of them for nullable values.
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 interface{}               `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	ContentEncoding      string                    `json:"contentEncoding,omitempty"`
+	ContentMediaType     string                    `json:"contentMediaType,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Minimum              *int                      `json:"minimum,omitempty"`
+	Maximum              *int                      `json:"maximum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	OneOf                []*openAPISchema          `json:"oneOf,omitempty"`
+}
+
+// openAPISchemas builds the schemas of Go types and collects the named ones as components
+type openAPISchemas struct {
+	components map[string]*openAPISchema
+}
+
+// of returns the schema of a type, a reference for named types
+func (s *openAPISchemas) of(t reflect.Type) *openAPISchema {
+	if name, ok := apiSchemaNames[t]; ok {
+		if _, built := s.components[name]; !built {
+			// Set first so types that refer to themselves end
+			s.components[name] = nil
+			s.components[name] = s.object(t)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + name}
+	}
+	if scalar, ok := apiScalarSchemas[t]; ok {
+		return &scalar
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := s.of(t.Elem())
+		if schema.Ref != "" {
+			return &openAPISchema{OneOf: []*openAPISchema{schema, {Type: "null"}}}
+		}
+		if typ, ok := schema.Type.(string); ok {
+			schema.Type = []string{typ, "null"}
+		}
+		return schema
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &openAPISchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", ContentEncoding: "base64"}
+		}
+		return &openAPISchema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.Struct:
+		return s.object(t)
+	}
+	return &openAPISchema{}
+}
+
+// object returns the schema of a struct the way encoding/json writes it. Fields of embedded
+// structs are promoted unless the outer struct has a field with the same name.
+func (s *openAPISchemas) object(t reflect.Type) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+	var embedded []reflect.Type
+	// fields are the names of the struct's own fields, hidden ones still shadow embedded fields
+	fields := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, tagged := field.Tag.Lookup("json")
+		if field.Anonymous && !tagged && field.Type.Kind() == reflect.Struct {
+			embedded = append(embedded, field.Type)
+			continue
+		}
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = true
+		if field.Tag.Get("openapi") == "-" || field.Tag.Get("belongs_to") != "" || field.Tag.Get("has_many") != "" {
+			continue
+		}
+		schema.Properties[name] = s.of(field.Type)
+		if field.Tag.Get("openapi") == "binary" {
+			schema.Properties[name] = &openAPISchema{Type: "string", ContentMediaType: "application/octet-stream"}
+		}
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	for _, e := range embedded {
+		promoted := s.object(e)
+		required := map[string]bool{}
+		for _, name := range promoted.Required {
+			required[name] = true
+		}
+		for name, property := range promoted.Properties {
+			if fields[name] {
+				continue
+			}
+			schema.Properties[name] = property
+			if required[name] {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// openAPIPathParam matches the parameters in a route path
+var openAPIPathParam = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// openAPIDocument describes the API for client generators
+func (a *MyApp) openAPIDocument() openAPIDocument {
+	schemas := &openAPISchemas{components: map[string]*openAPISchema{}}
+	errorResponse := &openAPIResponse{
+		Description: "The request was refused, the error says why",
+		Content:     map[string]openAPIMediaType{"application/json": {Schema: schemas.of(reflect.TypeOf(apiErrorResponse{}))}},
+	}
+	minLimit, maxLimit := 1, apiMaxLimit
+
+	doc := openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info: openAPIInfo{
+			Title:       "Hackathon API",
+			Description: "Hackathons, projects, team memberships and file metadata. Lists are paged: pass the next_cursor of a page as cursor to get the page after it.",
+			Version:     strings.TrimPrefix(apiBasePath, "/api/"),
+		},
+		Servers:  []openAPIServer{{URL: strings.TrimSuffix(a.Options.Host, "/") + apiBasePath}},
+		Security: []map[string][]string{{"apiToken": {models.APIScopeRead}}},
+		Paths:    map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas: schemas.components,
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"apiToken": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: fmt.Sprintf("An API token from the profile page or an admin's service account, sent as Authorization: Bearer <token>. Its scopes are %s: every token can read, %s covers projects and memberships and %s covers everything.", strings.Join(models.APIScopes, ", "), models.APIScopeProjectsWrite, models.APIScopeAdmin),
+				},
+			},
+		},
+	}
+
+	for _, op := range apiOperations {
+		operation := &openAPIOperation{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Responses:   map[string]*openAPIResponse{"default": errorResponse},
+		}
+		for _, match := range openAPIPathParam.FindAllStringSubmatch(op.Path, -1) {
+			operation.Parameters = append(operation.Parameters, openAPIParameter{Name: match[1], In: "path", Required: true, Schema: &openAPISchema{Type: "string"}})
+		}
+		for _, name := range op.Query {
+			operation.Parameters = append(operation.Parameters, openAPIParameter{Name: name, In: "query", Schema: &openAPISchema{Type: "string"}})
+		}
+		if op.List {
+			operation.Parameters = append(operation.Parameters,
+				openAPIParameter{Name: "cursor", In: "query", Description: "The next_cursor of the previous page", Schema: &openAPISchema{Type: "string"}},
+				openAPIParameter{Name: "limit", In: "query", Description: fmt.Sprintf("Rows per page, %d by default", apiDefaultLimit), Schema: &openAPISchema{Type: "integer", Minimum: &minLimit, Maximum: &maxLimit}},
+			)
+		}
+		if op.Body != nil {
+			contentType := "application/json"
+			if op.Multipart {
+				contentType = "multipart/form-data"
+			}
+			operation.RequestBody = &openAPIRequestBody{
+				Required: true,
+				Content:  map[string]openAPIMediaType{contentType: {Schema: schemas.of(reflect.TypeOf(op.Body))}},
+			}
+		}
+
+		success := &openAPIResponse{Description: http.StatusText(op.Status)}
+		switch {
+		case op.Raw:
+			success.Content = map[string]openAPIMediaType{"application/json": {Schema: &openAPISchema{Type: "object"}}}
+		case op.List:
+			success.Content = map[string]openAPIMediaType{"application/json": {Schema: &openAPISchema{
+				Type: "object",
+				Properties: map[string]*openAPISchema{
+					"data":        {Type: "array", Items: schemas.of(reflect.TypeOf(op.Data))},
+					"next_cursor": {Type: "string", Description: "Missing on the last page"},
+				},
+				Required: []string{"data"},
+			}}}
+		case op.Data != nil:
+			success.Content = map[string]openAPIMediaType{"application/json": {Schema: &openAPISchema{
+				Type:       "object",
+				Properties: map[string]*openAPISchema{"data": schemas.of(reflect.TypeOf(op.Data))},
+				Required:   []string{"data"},
+			}}}
+		}
+		operation.Responses[fmt.Sprint(op.Status)] = success
+
+		switch {
+		case op.Public:
+			operation.Security = []map[string][]string{{}}
+		case op.Scope != "":
+			operation.Security = []map[string][]string{{"apiToken": {op.Scope}}}
+			operation.Responses["401"] = errorResponse
+			operation.Responses["403"] = errorResponse
+		default:
+			operation.Responses["401"] = errorResponse
+		}
+
+		if doc.Paths[op.Path] == nil {
+			doc.Paths[op.Path] = map[string]*openAPIOperation{}
+		}
+		doc.Paths[op.Path][strings.ToLower(op.Method)] = operation
+	}
+	return doc
+}
+
+// APIOpenAPI returns the OpenAPI document of the API
+func (a *MyApp) APIOpenAPI(c buffalo.Context) error {
+	return apiRender(c, http.StatusOK, a.openAPIDocument())
+}
@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIOperations_CoverRoutes(t *testing.T) {
+	documented := map[string]bool{}
+	for _, op := range apiOperations {
+		documented[op.Method+" "+op.Path] = true
+	}
+
+	routed := map[string]bool{}
+	for _, route := range App().Routes() {
+		path, ok := strings.CutPrefix(route.Path, apiBasePath)
+		if !ok {
+			continue
+		}
+		if path != "/" {
+			path = strings.TrimSuffix(path, "/")
+		}
+		key := route.Method + " " + path
+		routed[key] = true
+		if !documented[key] {
+			t.Errorf("API route %s has no operation in apiOperations", key)
+		}
+	}
+
+	for key := range documented {
+		if !routed[key] {
+			t.Errorf("operation %s in apiOperations has no route", key)
+		}
+	}
+}
+
+func TestAPIOpenAPI(t *testing.T) {
+	res := httptest.NewRecorder()
+	App().ServeHTTP(res, httptest.NewRequest(http.MethodGet, apiBasePath+"/openapi.json", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf("GET %s/openapi.json = %d, want %d: %s", apiBasePath, res.Code, http.StatusOK, res.Body)
+	}
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(res.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("the document isn't JSON: %v", err)
+	}
+	if doc.OpenAPI != "3.1.0" || doc.Info.Title == "" || doc.Info.Version == "" {
+		t.Errorf("openapi = %q, info = %+v, want 3.1.0 with a title and version", doc.OpenAPI, doc.Info)
+	}
+
+	operationIDs := map[string]bool{}
+	for path, operations := range doc.Paths {
+		for method, raw := range operations {
+			var operation struct {
+				OperationID string                     `json:"operationId"`
+				Responses   map[string]json.RawMessage `json:"responses"`
+			}
+			if err := json.Unmarshal(raw, &operation); err != nil {
+				t.Fatalf("%s %s: %v", method, path, err)
+			}
+			if operation.OperationID == "" || operationIDs[operation.OperationID] {
+				t.Errorf("%s %s has a missing or duplicate operationId %q", method, path, operation.OperationID)
+			}
+			operationIDs[operation.OperationID] = true
+			if len(operation.Responses) == 0 {
+				t.Errorf("%s %s has no responses", method, path)
+			}
+		}
+	}
+	for _, op := range apiOperations {
+		if !operationIDs[op.ID] {
+			t.Errorf("operation %s is missing from the document", op.ID)
+		}
+	}
+
+	// Every reference has to point at a schema of the document
+	for _, ref := range strings.Split(res.Body.String(), `"$ref":"`)[1:] {
+		ref, _, _ = strings.Cut(ref, `"`)
+		name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+		if _, found := doc.Components.Schemas[name]; !ok || !found {
+			t.Errorf("reference %s doesn't resolve", ref)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/mailers"
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// passwordResetWindow is the period the request limits below apply to
+	passwordResetWindow    = time.Hour
+	passwordResetsPerEmail = 3
+	passwordResetsPerIP    = 10
+)
+
+// clientIP returns the address of the client without the port
+func clientIP(c buffalo.Context) string {
+	host, _, err := net.SplitHostPort(c.Request().RemoteAddr)
+	if err != nil {
+		return c.Request().RemoteAddr
+	}
+	return host
+}
+
+// generatePasswordResetToken returns a random secret for a reset link
+func generatePasswordResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// ForgotPasswordNew renders the form to request a password reset email.
+func (a *MyApp) ForgotPasswordNew(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	if redirected, err := ssoOnly(c, tx); redirected || err != nil {
+		return err
+	}
+
+	return c.Render(http.StatusOK, r.HTML("auth/forgot_password.plush.html"))
+}
+
+// ForgotPasswordCreate emails a reset link. The response is the same whether or not the
+// address belongs to an account so the form can't be used to discover users.
+func (a *MyApp) ForgotPasswordCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	if redirected, err := ssoOnly(c, tx); redirected || err != nil {
+		return err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(c.Param("email")))
+	if email == "" {
+		c.Flash().Add("danger", "Please enter your email address")
+		return c.Redirect(http.StatusSeeOther, "/forgot-password")
+	}
+
+	ip := clientIP(c)
+	since := time.Now().Add(-passwordResetWindow)
+	sentMessage := fmt.Sprintf("If an account exists for %s, we've sent it a link to reset the password. The link expires in %d minutes.", email, int(models.PasswordResetTTL.Minutes()))
+
+	ipCount, err := repoManager.PasswordResetCountByIPAddressSince(ip, since)
+	if err != nil {
+		return err
+	}
+	if ipCount >= passwordResetsPerIP {
+		logAuditEvent(tx, c, nil, "password_reset_throttled", "user", nil, fmt.Sprintf("Too many password reset requests from %s", ip))
+		c.Flash().Add("danger", "Too many password reset requests. Please try again later.")
+		return c.Redirect(http.StatusSeeOther, "/forgot-password")
+	}
+
+	emailCount, err := repoManager.PasswordResetCountByEmailSince(email, since)
+	if err != nil {
+		return err
+	}
+	if emailCount >= passwordResetsPerEmail {
+		logAuditEvent(tx, c, nil, "password_reset_throttled", "user", nil, fmt.Sprintf("Too many password reset requests for email: %s", email))
+		c.Flash().Add("info", sentMessage)
+		return c.Redirect(http.StatusSeeOther, "/signin")
+	}
+
+	reset := &models.PasswordReset{
+		Email:     email,
+		IPAddress: ip,
+		ExpiresAt: time.Now().Add(models.PasswordResetTTL),
+	}
+
+	user, err := repoManager.UserFindByEmail(email)
+	if err != nil {
+		if err := tx.Create(reset); err != nil {
+			return err
+		}
+		logAuditEvent(tx, c, nil, "password_reset_requested", "user", nil, fmt.Sprintf("Password reset requested for unknown email: %s", email))
+		c.Flash().Add("info", sentMessage)
+		return c.Redirect(http.StatusSeeOther, "/signin")
+	}
+
+	token, err := generatePasswordResetToken()
+	if err != nil {
+		return err
+	}
+	reset.UserID = nulls.NewUUID(user.ID)
+	reset.TokenHash = nulls.NewString(models.HashPasswordResetToken(token))
+	if err := tx.Create(reset); err != nil {
+		return err
+	}
+
+	resetURL := fmt.Sprintf("%s/forgot-password/%s", strings.TrimSuffix(a.Options.Host, "/"), token)
+	if err := mailers.SendPasswordReset(*user, resetURL, reset.ExpiresAt); err != nil {
+		c.Logger().Errorf("Failed to send password reset email to %s: %v", user.Email, err)
+	}
+
+	logAuditEvent(tx, c, &user.ID, "password_reset_requested", "user", &user.ID, fmt.Sprintf("Password reset email sent to %s", user.Email))
+
+	c.Flash().Add("info", sentMessage)
+	return c.Redirect(http.StatusSeeOther, "/signin")
+}
+
+// usablePasswordReset looks up the reset of a token from the URL and its user
+func usablePasswordReset(repoManager repository.RepositoryInterface, token string) (*models.PasswordReset, *models.User, bool) {
+	reset, err := repoManager.PasswordResetFindByTokenHash(models.HashPasswordResetToken(token))
+	if err != nil || !reset.IsUsable(time.Now()) {
+		return nil, nil, false
+	}
+
+	user, err := repoManager.UserFindByID(reset.UserID.UUID)
+	if err != nil {
+		return nil, nil, false
+	}
+	return reset, user, true
+}
+
+// ForgotPasswordEdit renders the form to choose a new password from an emailed link.
+func (a *MyApp) ForgotPasswordEdit(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	if redirected, err := ssoOnly(c, tx); redirected || err != nil {
+		return err
+	}
+
+	token := c.Param("token")
+	if _, _, ok := usablePasswordReset(a.Repository(tx), token); !ok {
+		c.Flash().Add("danger", "This password reset link is invalid or has expired. Please request a new one.")
+		return c.Redirect(http.StatusFound, "/forgot-password")
+	}
+
+	c.Set("token", token)
+	return c.Render(http.StatusOK, r.HTML("auth/forgot_password_edit.plush.html"))
+}
+
+// ForgotPasswordUpdate sets a new password from an emailed link and signs the user out everywhere.
+func (a *MyApp) ForgotPasswordUpdate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	if redirected, err := ssoOnly(c, tx); redirected || err != nil {
+		return err
+	}
+
+	token := c.Param("token")
+	formPath := fmt.Sprintf("/forgot-password/%s", token)
+
+	_, user, ok := usablePasswordReset(repoManager, token)
+	if !ok {
+		c.Flash().Add("danger", "This password reset link is invalid or has expired. Please request a new one.")
+		return c.Redirect(http.StatusSeeOther, "/forgot-password")
+	}
+
+	// Validate password against company policy
+	user.Password = c.Param("Password")
+	user.PasswordConfirmation = c.Param("PasswordConfirmation")
+	verrs, err := user.ValidateCreate(tx)
+	if err != nil {
+		c.Flash().Add("danger", "Validation error")
+		return c.Redirect(http.StatusSeeOther, formPath)
+	}
+	if verrs.HasAny() {
+		for _, verr := range verrs.Errors {
+			for _, msg := range verr {
+				c.Flash().Add("danger", msg)
+			}
+		}
+		return c.Redirect(http.StatusSeeOther, formPath)
+	}
+
+	ph, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		c.Flash().Add("danger", "Failed to process password")
+		return c.Redirect(http.StatusSeeOther, formPath)
+	}
+
+	user.PasswordHash = string(ph)
+	user.ForcePasswordReset = false
+	user.PasswordChangedAt = nulls.NewTime(time.Now())
+	if err := tx.UpdateColumns(user, "password_hash", "force_password_reset", "password_changed_at", "updated_at"); err != nil {
+		return err
+	}
+
+	// The link is single use, and any other outstanding links stop working too
+	if err := repoManager.PasswordResetExpireByUserID(user.ID); err != nil {
+		return err
+	}
+
+	// Whoever knew the old password may still be signed in
+	if err := repoManager.UserSessionDeleteByUserID(user.ID); err != nil {
+		return err
+	}
+
+	// Proving access to the inbox lifts a lockout
+	if err := repoManager.LoginAttemptDeleteByEmail(strings.ToLower(user.Email)); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &user.ID, "password_reset_completed", "user", &user.ID, fmt.Sprintf("Password reset by email link: %s (%s)", user.Name, user.Email))
+
+	c.Session().Clear()
+	c.Flash().Add("success", "Your password has been reset. Sign in with your new password.")
+	return c.Redirect(http.StatusSeeOther, "/signin")
+}
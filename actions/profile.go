@@ -2,8 +2,10 @@ package actions
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
 
 	"github.com/gobuffalo/buffalo"
 	"github.com/gobuffalo/pop/v6"
@@ -16,23 +18,58 @@ func (a *MyApp) ProfileShow(c buffalo.Context) error {
 	user := c.Value("current_user").(models.User)
 	repoManager := a.Repository(tx)
 
+	ownedHackathons, allProjects, err := loadUserParticipation(tx, repoManager, user)
+	if err != nil {
+		return err
+	}
+
+	invitations, err := repoManager.TeamRequestFindPendingInvitationsByEmail(user.Email)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := repoManager.UserSessionFindByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	apiTokens, err := repoManager.APITokenFindByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	c.Set("user", user)
+	c.Set("ownedHackathons", ownedHackathons)
+	c.Set("projects", allProjects)
+	c.Set("calendarFeedURL", a.calendarFeedURL(user))
+	c.Set("invitations", invitations)
+	c.Set("sessions", sessions)
+	c.Set("apiTokens", apiTokens)
+	c.Set("accessLevels", apiTokenAccess)
+	c.Set("expiryDays", apiTokenExpiryDays)
+	c.Set("now", time.Now())
+	return c.Render(http.StatusOK, r.HTML("profile/show.plush.html"))
+}
+
+// loadUserParticipation gathers the hackathons a user owns and the projects they created or are a member of
+func loadUserParticipation(tx *pop.Connection, repoManager repository.RepositoryInterface, user models.User) (*models.Hackathons, models.Projects, error) {
 	// Fetch hackathons owned by this user
 	ownedHackathons, err := repoManager.HackathonFindByOwnerID(user.ID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Fetch projects created by this user
 	createdProjects, err := repoManager.ProjectFindByUserID(user.ID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// For member projects, we still need the complex logic to get projects through memberships
 	// This is a bit complex for the repository pattern, so we'll keep it for now
 	var memberships models.ProjectMemberships
 	if err := tx.Where("user_id = ?", user.ID).Eager("Project").All(&memberships); err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	// Extract member projects
@@ -46,10 +83,7 @@ func (a *MyApp) ProfileShow(c buffalo.Context) error {
 	// Combine and deduplicate projects
 	allProjects := append(*createdProjects, memberProjects...)
 
-	c.Set("user", user)
-	c.Set("ownedHackathons", ownedHackathons)
-	c.Set("projects", allProjects)
-	return c.Render(http.StatusOK, r.HTML("profile/show.plush.html"))
+	return ownedHackathons, allProjects, nil
 }
 
 // ProfileEdit renders the profile edit form.
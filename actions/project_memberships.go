@@ -1,56 +1,113 @@
 package actions
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
 
 	"github.com/gobuffalo/buffalo"
 	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
 )
 
-// ProjectMembershipsCreate allows a user to join a project
-func (a *MyApp) ProjectMembershipsCreate(c buffalo.Context) error {
-	tx := c.Value("tx").(*pop.Connection)
-	currentUser := c.Value("current_user").(models.User)
-	repoManager := a.Repository(tx)
-
-	projectIDStr := c.Param("project_id")
-	hackathonID := c.Param("hackathon_id")
+// errTeamFull, errAlreadyMember and errSubmissionsClosed explain why addProjectMember did not add a member
+var (
+	errTeamFull          = errors.New("team is full")
+	errAlreadyMember     = errors.New("already a member")
+	errSubmissionsClosed = errors.New("submissions are closed")
+)
 
-	projectID := projectIDStr // Use string directly
+// addProjectMember adds a user to a project's team. The project row stays locked until the
+// request transaction ends, so concurrent joins cannot exceed the team size limit.
+func addProjectMember(tx *pop.Connection, repoManager repository.RepositoryInterface, projectID string, userID uuid.UUID) (*models.ProjectMembership, error) {
+	project, err := repoManager.ProjectLockByID(projectID)
+	if err != nil {
+		return nil, err
+	}
 
-	// Check if already a member
-	isMember, err := repoManager.ProjectMembershipIsUserMember(projectID, currentUser.ID)
+	isMember, err := repoManager.ProjectMembershipIsUserMember(project.ID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if isMember {
-		c.Flash().Add("warning", "You are already a member of this project.")
-		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathonID)
+		return nil, errAlreadyMember
 	}
 
-	// Find the project
-	project, err := repoManager.ProjectFindByID(projectID)
+	hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
 	if err != nil {
-		return c.Error(http.StatusNotFound, err)
+		return nil, err
+	}
+	if project.IsFrozen(*hackathon, time.Now()) {
+		return nil, errSubmissionsClosed
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	memberCount, err := repoManager.ProjectMembershipCountByProjectID(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	if memberCount >= hackathon.TeamSizeLimit(config) {
+		return nil, errTeamFull
 	}
 
-	// Create membership
 	membership := &models.ProjectMembership{
 		ProjectID: project.ID,
-		UserID:    currentUser.ID,
+		UserID:    userID,
 	}
-
 	if err := tx.Create(membership); err != nil {
-		// Check if it's a duplicate key error (user already member)
 		if isDuplicateError(err) {
-			c.Flash().Add("warning", "You are already a member of this project.")
-			return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathonID)
+			return nil, errAlreadyMember
 		}
+		return nil, err
+	}
+	return membership, nil
+}
+
+// ProjectMembershipsCreate allows a user to join a project instantly when the hackathon has open teams
+func (a *MyApp) ProjectMembershipsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	currentUser := c.Value("current_user").(models.User)
+	repoManager := a.Repository(tx)
+
+	hackathonID := c.Param("hackathon_id")
+
+	// Find the project
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+	if !hackathon.OpenTeams {
+		c.Flash().Add("info", "Teams in this hackathon are invite-only. Send the owner a join request instead.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", hackathonID, project.ID)
+	}
+
+	membership, err := addProjectMember(tx, repoManager, project.ID, currentUser.ID)
+	switch {
+	case errors.Is(err, errAlreadyMember):
+		c.Flash().Add("warning", "You are already a member of this project.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathonID)
+	case errors.Is(err, errTeamFull):
+		c.Flash().Add("warning", fmt.Sprintf("%s is full.", project.Name))
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathonID)
+	case errors.Is(err, errSubmissionsClosed):
+		c.Flash().Add("warning", "Submissions are closed, so teams can no longer change.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathonID)
+	case err != nil:
 		return err
 	}
 
@@ -81,6 +138,11 @@ func (a *MyApp) ProjectMembershipsDestroy(c buffalo.Context) error {
 		c.Flash().Add("danger", "You cannot leave a project you own.")
 		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathonID)
 	}
+	if closed, err := submissionsClosed(c, repoManager, project); err != nil {
+		return err
+	} else if closed {
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", hackathonID, project.ID)
+	}
 
 	// Find and delete membership
 	membership, err := repoManager.ProjectMembershipFindByProjectIDAndUserID(projectID, currentUser.ID)
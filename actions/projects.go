@@ -47,6 +47,13 @@ func (a *MyApp) ProjectsUpdateImage(c buffalo.Context) error {
 		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
 	}
 
+	// Projects are frozen once submissions close
+	if closed, err := submissionsClosed(c, a.Repository(tx), project); err != nil {
+		return err
+	} else if closed {
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+	}
+
 	// Handle image upload
 	if uploadedImage, imageHeader, err := c.Request().FormFile("image"); err == nil {
 		defer uploadedImage.Close()
@@ -92,6 +99,24 @@ func (a *MyApp) ProjectsUpdateImage(c buffalo.Context) error {
 	return c.Redirect(http.StatusFound, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
 }
 
+// isHackathonOrganizer returns true if the user administers the platform or owns the hackathon
+func isHackathonOrganizer(user models.User, hackathon *models.Hackathon) bool {
+	return user.IsOwner() || hackathon.OwnerID == user.ID
+}
+
+// scopeVisibleProjects hides projects that are not approved yet from everyone except
+// the hackathon's organizers and the project's own team
+func scopeVisibleProjects(c buffalo.Context, q *pop.Query, hackathon *models.Hackathon) *pop.Query {
+	user, ok := c.Value("current_user").(models.User)
+	if !ok {
+		return q.Where("review_status = ?", models.ProjectReviewApproved)
+	}
+	if isHackathonOrganizer(user, hackathon) {
+		return q
+	}
+	return q.Where("(review_status = ? OR id IN (SELECT project_id FROM project_memberships WHERE user_id = ?))", models.ProjectReviewApproved, user.ID)
+}
+
 // ProjectsIndex lists all projects for a hackathon
 func (a *MyApp) ProjectsIndex(c buffalo.Context) error {
 	tx := c.Value("tx").(*pop.Connection)
@@ -102,7 +127,8 @@ func (a *MyApp) ProjectsIndex(c buffalo.Context) error {
 	}
 
 	projects := &models.Projects{}
-	if err := tx.Where("hackathon_id = ?", hackathon.ID).Eager("User").All(projects); err != nil {
+	q := scopeVisibleProjects(c, tx.Where("hackathon_id = ?", hackathon.ID), hackathon)
+	if err := q.Eager("User").All(projects); err != nil {
 		return err
 	}
 
@@ -155,6 +181,12 @@ func (a *MyApp) ProjectsShow(c buffalo.Context) error {
 		isOwner = *project.UserID == cu.ID
 	}
 
+	// Hackathon owners can grant submission extensions
+	isHackathonOwner := false
+	if cu, ok := c.Value("current_user").(models.User); ok {
+		isHackathonOwner = hackathon.OwnerID == cu.ID
+	}
+
 	// Load files for this project
 	files := &models.Files{}
 	if err := tx.Where("project_id = ?", project.ID).All(files); err != nil {
@@ -189,9 +221,85 @@ func (a *MyApp) ProjectsShow(c buffalo.Context) error {
 		}
 	}
 
+	// Projects waiting for review are only visible to their team and the organizers
+	if !project.IsApproved() && !isMember {
+		cu, ok := c.Value("current_user").(models.User)
+		if !ok || !isHackathonOrganizer(cu, hackathon) {
+			return c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+		}
+	}
+
+	// Check if current user is assigned to judge this project
+	isJudge := false
+	if cu, ok := c.Value("current_user").(models.User); ok {
+		assigned, err := a.Repository(tx).JudgingIsJudgeAssigned(project.ID, cu.ID)
+		if err == nil {
+			isJudge = assigned
+		}
+	}
+
+	// Join requests and invitations waiting for an answer
+	repoManager := a.Repository(tx)
+	pendingRequests := &models.JoinRequests{}
+	pendingInvitations := &models.ProjectInvitations{}
+	hasPendingRequest := false
+	var myInvitation *models.ProjectInvitation
+	if cu, ok := c.Value("current_user").(models.User); ok {
+		if isOwner {
+			requests, err := repoManager.TeamRequestFindPendingJoinRequestsByProjectID(project.ID)
+			if err != nil {
+				return err
+			}
+			pendingRequests = requests
+
+			invitations, err := repoManager.TeamRequestFindPendingInvitationsByProjectID(project.ID)
+			if err != nil {
+				return err
+			}
+			pendingInvitations = invitations
+		} else if !isMember {
+			pending, err := repoManager.TeamRequestHasPendingJoinRequest(project.ID, cu.ID)
+			if err != nil {
+				return err
+			}
+			hasPendingRequest = pending
+
+			invitations, err := repoManager.TeamRequestFindPendingInvitationsByEmail(cu.Email)
+			if err != nil {
+				return err
+			}
+			for i := range *invitations {
+				if (*invitations)[i].ProjectID == project.ID {
+					myInvitation = &(*invitations)[i]
+				}
+			}
+		}
+	}
+
+	// Remaining seats on the team
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+	teamSizeLimit := hackathon.TeamSizeLimit(config)
+	seatsRemaining := teamSizeLimit - len(*memberships)
+	if seatsRemaining < 0 {
+		seatsRemaining = 0
+	}
+
 	c.Set("hackathon", hackathon)
 	c.Set("project", project)
 	c.Set("isProjectOwner", isOwner)
+	c.Set("isProjectJudge", isJudge)
+	c.Set("teamSizeLimit", teamSizeLimit)
+	c.Set("pendingRequests", pendingRequests)
+	c.Set("pendingInvitations", pendingInvitations)
+	c.Set("hasPendingRequest", hasPendingRequest)
+	c.Set("myInvitation", myInvitation)
+	c.Set("seatsRemaining", seatsRemaining)
+	c.Set("submissionsFrozen", project.IsFrozen(*hackathon, time.Now()))
+	c.Set("submissionsCloseAt", project.SubmissionsCloseAt(*hackathon))
+	c.Set("isHackathonOwner", isHackathonOwner)
 	c.Set("files", files)
 	c.Set("projectUsers", projectUsers)
 	c.Set("isMember", isMember)
@@ -252,6 +360,24 @@ func (a *MyApp) ProjectsCreate(c buffalo.Context) error {
 	}
 
 	tx := c.Value("tx").(*pop.Connection)
+
+	// No new projects once submissions close
+	if closed, err := submissionsClosed(c, a.Repository(tx), project); err != nil {
+		return c.Error(http.StatusNotFound, err)
+	} else if closed {
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", project.HackathonID)
+	}
+
+	// Hold new submissions for an organizer's review when the platform requires it
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+	project.ReviewStatus = models.ProjectReviewApproved
+	if config.RequireProjectApproval {
+		project.ReviewStatus = models.ProjectReviewPending
+	}
+
 	verrs, err := tx.ValidateAndCreate(project)
 	if err != nil {
 		return err
@@ -276,7 +402,12 @@ func (a *MyApp) ProjectsCreate(c buffalo.Context) error {
 	}
 
 	// Log project creation
-	logAuditEvent(tx, c, &currentUser.ID, "create", "project", &project.ID, fmt.Sprintf("Project created: %s", project.Name))
+	logAuditEvent(tx, c, &currentUser.ID, "create", "project", project.ID, fmt.Sprintf("Project created: %s", project.Name))
+
+	if project.IsPendingReview() {
+		c.Flash().Add("info", "Project submitted! It will be visible to other hackers once an organizer approves it.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+	}
 
 	c.Flash().Add("success", "Project created successfully!")
 	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
@@ -298,6 +429,13 @@ func (a *MyApp) ProjectsEdit(c buffalo.Context) error {
 		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
 	}
 
+	// Projects are frozen once submissions close
+	if closed, err := submissionsClosed(c, a.Repository(tx), project); err != nil {
+		return err
+	} else if closed {
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+	}
+
 	hackathon := &models.Hackathon{}
 	if err := tx.Find(hackathon, project.HackathonID); err != nil {
 		return c.Error(http.StatusNotFound, err)
@@ -324,6 +462,13 @@ func (a *MyApp) ProjectsUpdate(c buffalo.Context) error {
 		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
 	}
 
+	// Projects are frozen once submissions close
+	if closed, err := submissionsClosed(c, a.Repository(tx), project); err != nil {
+		return err
+	} else if closed {
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+	}
+
 	// Handle image upload
 	if uploadedImage, imageHeader, err := c.Request().FormFile("image"); err == nil {
 		defer uploadedImage.Close()
@@ -356,6 +501,12 @@ func (a *MyApp) ProjectsUpdate(c buffalo.Context) error {
 		return err
 	}
 
+	// Editing a rejected project sends it back to the review queue
+	resubmitted := project.IsRejected()
+	if resubmitted {
+		project.ReviewStatus = models.ProjectReviewPending
+	}
+
 	verrs, err := tx.ValidateAndUpdate(project)
 	if err != nil {
 		return err
@@ -371,7 +522,13 @@ func (a *MyApp) ProjectsUpdate(c buffalo.Context) error {
 	}
 
 	// Log project update
-	logAuditEvent(tx, c, &currentUser.ID, "update", "project", &project.ID, fmt.Sprintf("Project updated: %s", project.Name))
+	logAuditEvent(tx, c, &currentUser.ID, "update", "project", project.ID, fmt.Sprintf("Project updated: %s", project.Name))
+
+	if resubmitted {
+		logAuditEvent(tx, c, &currentUser.ID, "resubmit_project", "project", project.ID, fmt.Sprintf("Project resubmitted for review: %s", project.Name))
+		c.Flash().Add("info", "Project updated and resubmitted for review.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+	}
 
 	c.Flash().Add("success", "Project updated successfully!")
 	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
@@ -421,7 +578,7 @@ func (a *MyApp) ProjectsTogglePresenting(c buffalo.Context) error {
 		if !project.Presenting {
 			action = "unset_presenting"
 		}
-		logAuditEvent(tx, c, &cu.ID, action, "project", &project.ID, fmt.Sprintf("Project presenting status changed: %s", project.Name))
+		logAuditEvent(tx, c, &cu.ID, action, "project", project.ID, fmt.Sprintf("Project presenting status changed: %s", project.Name))
 	}
 
 	c.Flash().Add("success", "Project presenting status updated!")
@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// bindScheduleItem copies the schedule item form fields into item
+func bindScheduleItem(c buffalo.Context, item *models.ScheduleItem) {
+	item.Title = strings.TrimSpace(c.Param("title"))
+	item.ItemType = c.Param("item_type")
+
+	item.StartsAt = time.Time{}
+	if start, err := time.Parse("2006-01-02T15:04", c.Param("starts_at")); err == nil {
+		item.StartsAt = start
+	}
+
+	item.EndsAt = nulls.Time{}
+	if end, err := time.Parse("2006-01-02T15:04", c.Param("ends_at")); err == nil {
+		item.EndsAt = nulls.NewTime(end)
+	}
+
+	item.Location = nulls.String{}
+	if location := strings.TrimSpace(c.Param("location")); location != "" {
+		item.Location = nulls.NewString(location)
+	}
+
+	item.Link = nulls.String{}
+	if link := strings.TrimSpace(c.Param("link")); link != "" {
+		item.Link = nulls.NewString(link)
+	}
+}
+
+// ScheduleItemsCreate adds an item to a hackathon's schedule (owner-only)
+func (a *MyApp) ScheduleItemsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	hackathonID := c.Param("hackathon_id")
+
+	item := &models.ScheduleItem{HackathonID: hackathonID}
+	bindScheduleItem(c, item)
+
+	verrs, err := tx.ValidateAndCreate(item)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.String())
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/edit", hackathonID)
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "create", "schedule_item", &item.ID, fmt.Sprintf("Schedule item added: %s", item.Title))
+
+	c.Flash().Add("success", "Schedule item added!")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/edit", hackathonID)
+}
+
+// ScheduleItemsUpdate changes an item of a hackathon's schedule (owner-only)
+func (a *MyApp) ScheduleItemsUpdate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	hackathonID := c.Param("hackathon_id")
+
+	item, err := repoManager.ScheduleItemFindByID(c.Param("item_id"))
+	if err != nil || item.HackathonID != hackathonID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("schedule item not found"))
+	}
+
+	bindScheduleItem(c, item)
+
+	verrs, err := tx.ValidateAndUpdate(item)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.String())
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s/edit", hackathonID)
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "update", "schedule_item", &item.ID, fmt.Sprintf("Schedule item updated: %s", item.Title))
+
+	c.Flash().Add("success", "Schedule item updated!")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/edit", hackathonID)
+}
+
+// ScheduleItemsDestroy removes an item from a hackathon's schedule (owner-only)
+func (a *MyApp) ScheduleItemsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	hackathonID := c.Param("hackathon_id")
+
+	item, err := repoManager.ScheduleItemFindByID(c.Param("item_id"))
+	if err != nil || item.HackathonID != hackathonID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("schedule item not found"))
+	}
+
+	if err := tx.Destroy(item); err != nil {
+		return err
+	}
+
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, "delete", "schedule_item", &item.ID, fmt.Sprintf("Schedule item removed: %s", item.Title))
+
+	c.Flash().Add("success", "Schedule item removed.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/edit", hackathonID)
+}
@@ -0,0 +1,114 @@
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo/worker"
+	"github.com/gobuffalo/envy"
+	"github.com/gobuffalo/pop/v6"
+)
+
+const (
+	// hackathonStatusJob is the name of the background job that moves hackathons along on their dates
+	hackathonStatusJob = "hackathon_status_transitions"
+	// sessionCleanupJob is the name of the background job that deletes expired sessions
+	sessionCleanupJob = "session_cleanup"
+	// sessionCleanupInterval is how often expired sessions are deleted
+	sessionCleanupInterval = time.Hour
+)
+
+// HackathonTransition describes a status change applied to a hackathon
+type HackathonTransition struct {
+	Hackathon models.Hackathon
+	From      string
+	To        string
+}
+
+// TransitionHackathonStatuses moves upcoming hackathons to active once they start and
+// active hackathons to completed once they end. Hidden hackathons are left alone.
+// Every transition is recorded in the audit log as a system action.
+func TransitionHackathonStatuses(tx *pop.Connection, now time.Time) ([]HackathonTransition, error) {
+	repoManager := repository.NewRepositoryManager(tx)
+
+	hackathons, err := repoManager.HackathonLockDueForStatusTransition(now)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := []HackathonTransition{}
+	for _, hackathon := range *hackathons {
+		next := hackathon.NextStatus(now)
+		if next == "" {
+			continue
+		}
+
+		transition := HackathonTransition{Hackathon: hackathon, From: hackathon.Status, To: next}
+		hackathon.Status = next
+		if err := tx.UpdateColumns(&hackathon, "status", "updated_at"); err != nil {
+			return transitions, err
+		}
+
+		logAuditEvent(tx, nil, nil, "transition_status", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon %s moved from %s to %s", hackathon.Title, transition.From, transition.To))
+		transitions = append(transitions, transition)
+	}
+	return transitions, nil
+}
+
+// hackathonStatusInterval returns how often the scheduler checks hackathon dates
+func hackathonStatusInterval() time.Duration {
+	interval, err := time.ParseDuration(envy.Get("HACKATHON_STATUS_INTERVAL", "1m"))
+	if err != nil || interval <= 0 {
+		return time.Minute
+	}
+	return interval
+}
+
+// registerWorkers registers the background jobs and schedules their first run
+func (a *MyApp) registerWorkers() {
+	a.registerRecurringJob(hackathonStatusJob, hackathonStatusInterval(), func() error {
+		return models.DB.Transaction(func(tx *pop.Connection) error {
+			transitions, err := TransitionHackathonStatuses(tx, time.Now())
+			for _, t := range transitions {
+				a.Logger.Infof("hackathon %s moved from %s to %s", t.Hackathon.ID, t.From, t.To)
+			}
+			return err
+		})
+	})
+
+	a.registerRecurringJob(sessionCleanupJob, sessionCleanupInterval, func() error {
+		return models.DB.Transaction(func(tx *pop.Connection) error {
+			deleted, err := CleanupExpiredSessions(tx, time.Now())
+			if deleted > 0 {
+				a.Logger.Infof("deleted %d expired sessions", deleted)
+			}
+			return err
+		})
+	})
+}
+
+// registerRecurringJob registers a job that runs right away and then reschedules itself
+// every interval for as long as the app runs
+func (a *MyApp) registerRecurringJob(name string, interval time.Duration, run func() error) {
+	job := worker.Job{Handler: name}
+
+	err := a.Worker.Register(name, func(worker.Args) error {
+		defer func() {
+			if err := a.Worker.PerformIn(job, interval); err != nil {
+				a.Logger.Errorf("scheduling %s: %v", name, err)
+			}
+		}()
+		return run()
+	})
+	if err != nil {
+		a.Logger.Errorf("registering %s: %v", name, err)
+		return
+	}
+
+	if err := a.Worker.PerformIn(job, 0); err != nil {
+		a.Logger.Errorf("scheduling %s: %v", name, err)
+	}
+}
@@ -0,0 +1,97 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/envy"
+)
+
+func TestTransitionHackathonStatuses(t *testing.T) {
+	tx := testTx(t)
+	owner := createTestUser(t, tx, models.RoleOwner)
+	now := time.Now()
+	day := 24 * time.Hour
+
+	hackathons := map[string]struct {
+		hackathon *models.Hackathon
+		want      string
+	}{
+		"not started":      {createTestHackathon(t, tx, owner, models.HackathonStatusUpcoming, now.Add(day), now.Add(2*day)), models.HackathonStatusUpcoming},
+		"started":          {createTestHackathon(t, tx, owner, models.HackathonStatusUpcoming, now.Add(-day), now.Add(day)), models.HackathonStatusActive},
+		"missed the start": {createTestHackathon(t, tx, owner, models.HackathonStatusUpcoming, now.Add(-2*day), now.Add(-day)), models.HackathonStatusCompleted},
+		"running":          {createTestHackathon(t, tx, owner, models.HackathonStatusActive, now.Add(-day), now.Add(day)), models.HackathonStatusActive},
+		"ended":            {createTestHackathon(t, tx, owner, models.HackathonStatusActive, now.Add(-2*day), now.Add(-day)), models.HackathonStatusCompleted},
+		"hidden":           {createTestHackathon(t, tx, owner, models.HackathonStatusHidden, now.Add(-2*day), now.Add(-day)), models.HackathonStatusHidden},
+	}
+
+	transitions, err := TransitionHackathonStatuses(tx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transitioned := map[string]HackathonTransition{}
+	for _, transition := range transitions {
+		transitioned[transition.Hackathon.ID] = transition
+	}
+
+	for name, tt := range hackathons {
+		stored := &models.Hackathon{}
+		if err := tx.Find(stored, tt.hackathon.ID); err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != tt.want {
+			t.Errorf("%s hackathon is %s, want %s", name, stored.Status, tt.want)
+		}
+
+		transition, ok := transitioned[tt.hackathon.ID]
+		if moved := tt.want != tt.hackathon.Status; moved != ok {
+			t.Errorf("%s hackathon reported as transitioned: %v, want %v", name, ok, moved)
+			continue
+		}
+		if ok && (transition.From != tt.hackathon.Status || transition.To != tt.want) {
+			t.Errorf("%s hackathon moved from %s to %s, want %s to %s", name, transition.From, transition.To, tt.hackathon.Status, tt.want)
+		}
+		count, err := tx.Where("action = ? AND resource_id = ?", "transition_status", tt.hackathon.ID).Count(&models.AuditLog{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := 0
+		if ok {
+			want = 1
+		}
+		if count != want {
+			t.Errorf("%s hackathon has %d transition audit events, want %d", name, count, want)
+		}
+	}
+
+	// A second run has nothing left to do
+	transitions, err = TransitionHackathonStatuses(tx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, transition := range transitions {
+		if _, ok := transitioned[transition.Hackathon.ID]; ok {
+			t.Errorf("hackathon %s moved again from %s to %s", transition.Hackathon.ID, transition.From, transition.To)
+		}
+	}
+}
+
+func TestHackathonStatusInterval(t *testing.T) {
+	defer envy.Set("HACKATHON_STATUS_INTERVAL", envy.Get("HACKATHON_STATUS_INTERVAL", ""))
+
+	for value, want := range map[string]time.Duration{
+		"":      time.Minute,
+		"30s":   30 * time.Second,
+		"5m":    5 * time.Minute,
+		"0s":    time.Minute,
+		"-1m":   time.Minute,
+		"often": time.Minute,
+	} {
+		envy.Set("HACKATHON_STATUS_INTERVAL", value)
+		if got := hackathonStatusInterval(); got != want {
+			t.Errorf("HACKATHON_STATUS_INTERVAL=%q gives %s, want %s", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,1058 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+)
+
+// SCIM 2.0 provisioning, see RFC 7643 and RFC 7644. Identity providers create, update and
+// deactivate accounts with a bearer token an owner issues in the admin panel. The groups are
+// the app's roles, so adding someone to a group gives them that role.
+
+const (
+	scimBasePath   = "/scim/v2"
+	scimMaxResults = 200
+
+	scimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaEnterpriseUser        = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	scimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	scimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// scimRoleGroups are the groups identity providers can push members to, one per role
+var scimRoleGroups = []struct {
+	Role        string
+	DisplayName string
+}{
+	{models.RoleOwner, "Owners"},
+	{models.RoleHacker, "Hackers"},
+	{models.RoleJudge, "Judges"},
+}
+
+var (
+	// scimEqFilter matches the only filter expressions supported, like userName eq "ada@example.com"
+	scimEqFilter = regexp.MustCompile(`(?i)^\s*([a-z0-9_.:]+)\s+eq\s+"((?:[^"\\]|\\.)*)"\s*$`)
+	// scimMemberPath matches patch paths that pick one group member, like members[value eq "<id>"]
+	scimMemberPath = regexp.MustCompile(`(?i)^members\[\s*value\s+eq\s+"([^"]+)"\s*\]$`)
+)
+
+// scimProblem is a request the SCIM endpoint refuses, it is returned to the client as a SCIM error
+type scimProblem struct {
+	Status   int
+	SCIMType string
+	Detail   string
+}
+
+func (p scimProblem) Error() string {
+	return p.Detail
+}
+
+type scimMeta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location"`
+}
+
+type scimName struct {
+	Formatted string `json:"formatted,omitempty"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type scimEnterpriseUser struct {
+	Department string `json:"department,omitempty"`
+}
+
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type scimUser struct {
+	Schemas     []string            `json:"schemas"`
+	ID          string              `json:"id"`
+	ExternalID  string              `json:"externalId,omitempty"`
+	UserName    string              `json:"userName"`
+	Name        scimName            `json:"name"`
+	DisplayName string              `json:"displayName,omitempty"`
+	Emails      []scimEmail         `json:"emails"`
+	Active      bool                `json:"active"`
+	Groups      []scimMember        `json:"groups"`
+	Enterprise  *scimEnterpriseUser `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta        scimMeta            `json:"meta"`
+}
+
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members,omitempty"`
+	Meta        scimMeta     `json:"meta"`
+}
+
+type scimListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults int           `json:"totalResults"`
+	StartIndex   int           `json:"startIndex"`
+	ItemsPerPage int           `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+type scimPatchRequest struct {
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+// scimUserChanges collects the attributes of a request that the app stores, nil fields are left alone
+type scimUserChanges struct {
+	UserName   *string
+	Email      *string
+	Name       *string
+	GivenName  *string
+	FamilyName *string
+	Team       *string
+	ExternalID *string
+	Active     *bool
+}
+
+// scimString decodes a string attribute, a missing or null value clears it
+func scimString(raw json.RawMessage) (*string, error) {
+	value := ""
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, scimProblem{http.StatusBadRequest, "invalidValue", "Expected a string value"}
+		}
+	}
+	value = strings.TrimSpace(value)
+	return &value, nil
+}
+
+// scimBool decodes a boolean attribute. Some identity providers send "True" and "False" as strings.
+func scimBool(raw json.RawMessage) (*bool, error) {
+	var value bool
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return &value, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if parsed, err := strconv.ParseBool(strings.ToLower(text)); err == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, scimProblem{http.StatusBadRequest, "invalidValue", "Expected a boolean value"}
+}
+
+// set records one attribute of a user resource or patch. Attributes the app doesn't store are
+// ignored, identity providers often send more than the app needs.
+func (ch *scimUserChanges) set(path string, raw json.RawMessage) error {
+	var err error
+	switch key := strings.ToLower(path); key {
+	case "active":
+		if len(raw) == 0 {
+			return scimProblem{http.StatusBadRequest, "mutability", "active can't be removed"}
+		}
+		ch.Active, err = scimBool(raw)
+	case "username":
+		ch.UserName, err = scimString(raw)
+	case "emails":
+		var emails []scimEmail
+		if len(raw) > 0 && json.Unmarshal(raw, &emails) != nil {
+			return scimProblem{http.StatusBadRequest, "invalidValue", "emails must be a list"}
+		}
+		for i, email := range emails {
+			if email.Primary || i == 0 {
+				value := strings.TrimSpace(email.Value)
+				ch.Email = &value
+			}
+		}
+	case `emails[type eq "work"].value`, "emails.value":
+		ch.Email, err = scimString(raw)
+	case "displayname", "name.formatted":
+		ch.Name, err = scimString(raw)
+	case "name.givenname":
+		ch.GivenName, err = scimString(raw)
+	case "name.familyname":
+		ch.FamilyName, err = scimString(raw)
+	case "name":
+		var name map[string]json.RawMessage
+		if len(raw) > 0 && json.Unmarshal(raw, &name) != nil {
+			return scimProblem{http.StatusBadRequest, "invalidValue", "name must be an object"}
+		}
+		for attribute, value := range name {
+			if err := ch.set("name."+attribute, value); err != nil {
+				return err
+			}
+		}
+	case "externalid":
+		ch.ExternalID, err = scimString(raw)
+	case strings.ToLower(scimSchemaEnterpriseUser) + ":department":
+		ch.Team, err = scimString(raw)
+	case strings.ToLower(scimSchemaEnterpriseUser):
+		var enterprise map[string]json.RawMessage
+		if len(raw) > 0 && json.Unmarshal(raw, &enterprise) != nil {
+			return scimProblem{http.StatusBadRequest, "invalidValue", "The enterprise extension must be an object"}
+		}
+		for attribute, value := range enterprise {
+			if err := ch.set(scimSchemaEnterpriseUser+":"+attribute, value); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// email returns the new email address, userName wins because identity providers look users up by it
+func (ch scimUserChanges) email() *string {
+	if ch.UserName != nil && *ch.UserName != "" {
+		return ch.UserName
+	}
+	return ch.Email
+}
+
+// name returns the new name, preferring the display name over the given and family names
+func (ch scimUserChanges) name() *string {
+	if ch.Name != nil && *ch.Name != "" {
+		return ch.Name
+	}
+	if ch.GivenName != nil || ch.FamilyName != nil {
+		parts := []string{}
+		for _, part := range []*string{ch.GivenName, ch.FamilyName} {
+			if part != nil && *part != "" {
+				parts = append(parts, *part)
+			}
+		}
+		name := strings.Join(parts, " ")
+		return &name
+	}
+	return nil
+}
+
+// scimUserChangesFromResource reads a full user resource, as sent to create or replace a user
+func scimUserChangesFromResource(body io.Reader) (scimUserChanges, error) {
+	ch := scimUserChanges{}
+	resource := map[string]json.RawMessage{}
+	if err := json.NewDecoder(body).Decode(&resource); err != nil {
+		return ch, scimProblem{http.StatusBadRequest, "invalidSyntax", "The request body is not a JSON object"}
+	}
+	for attribute, value := range resource {
+		if err := ch.set(attribute, value); err != nil {
+			return ch, err
+		}
+	}
+	return ch, nil
+}
+
+// scimUserChangesFromPatch reads the operations of a patch request on a user
+func scimUserChangesFromPatch(body io.Reader) (scimUserChanges, error) {
+	ch := scimUserChanges{}
+	patch := scimPatchRequest{}
+	if err := json.NewDecoder(body).Decode(&patch); err != nil || len(patch.Operations) == 0 {
+		return ch, scimProblem{http.StatusBadRequest, "invalidSyntax", "The request body is not a patch with operations"}
+	}
+
+	for _, op := range patch.Operations {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			if op.Path != "" {
+				if err := ch.set(op.Path, op.Value); err != nil {
+					return ch, err
+				}
+				continue
+			}
+			attributes := map[string]json.RawMessage{}
+			if err := json.Unmarshal(op.Value, &attributes); err != nil {
+				return ch, scimProblem{http.StatusBadRequest, "invalidValue", "A patch without a path needs an object value"}
+			}
+			for attribute, value := range attributes {
+				if err := ch.set(attribute, value); err != nil {
+					return ch, err
+				}
+			}
+		case "remove":
+			if op.Path == "" {
+				return ch, scimProblem{http.StatusBadRequest, "noTarget", "Removing needs a path"}
+			}
+			if err := ch.set(op.Path, nil); err != nil {
+				return ch, err
+			}
+		default:
+			return ch, scimProblem{http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("Unknown patch operation %q", op.Op)}
+		}
+	}
+	return ch, nil
+}
+
+// scimRender writes a SCIM response body
+func scimRender(c buffalo.Context, status int, v interface{}) error {
+	return c.Render(status, r.Func("application/scim+json", func(w io.Writer, d render.Data) error {
+		return json.NewEncoder(w).Encode(v)
+	}))
+}
+
+// scimError writes a SCIM error response
+func scimError(c buffalo.Context, status int, scimType, detail string) error {
+	body := map[string]interface{}{
+		"schemas": []string{scimSchemaError},
+		"status":  strconv.Itoa(status),
+		"detail":  detail,
+	}
+	if scimType != "" {
+		body["scimType"] = scimType
+	}
+	return scimRender(c, status, body)
+}
+
+// scimFailure sends refused requests back as SCIM errors and passes other errors on
+func scimFailure(c buffalo.Context, err error) error {
+	var problem scimProblem
+	if errors.As(err, &problem) {
+		return scimError(c, problem.Status, problem.SCIMType, problem.Detail)
+	}
+	return err
+}
+
+// scimAudit records a provisioning change along with the token that made it
+func scimAudit(tx *pop.Connection, c buffalo.Context, action string, user *models.User, details string) {
+	token := c.Value("scim_token").(models.SCIMToken)
+	logAuditEvent(tx, c, nil, action, "user", &user.ID, fmt.Sprintf("%s (SCIM token %q)", details, token.Name))
+}
+
+// scimLocation returns the URL of a SCIM resource
+func (a *MyApp) scimLocation(parts ...string) string {
+	return strings.TrimSuffix(a.Options.Host, "/") + scimBasePath + "/" + strings.Join(parts, "/")
+}
+
+// scimPage reads the 1-based startIndex and count parameters of a list request
+func scimPage(c buffalo.Context) (int, int) {
+	startIndex, err := strconv.Atoi(c.Param("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := strconv.Atoi(c.Param("count"))
+	if err != nil || count > scimMaxResults {
+		count = scimMaxResults
+	}
+	if count < 0 {
+		count = 0
+	}
+	return startIndex, count
+}
+
+// scimFilter parses a filter of the form attribute eq "value", the only kind supported
+func scimFilter(filter string) (string, string, error) {
+	match := scimEqFilter.FindStringSubmatch(filter)
+	if match == nil {
+		return "", "", scimProblem{http.StatusBadRequest, "invalidFilter", `Only filters of the form attribute eq "value" are supported`}
+	}
+	value, err := strconv.Unquote(`"` + match[2] + `"`)
+	if err != nil {
+		return "", "", scimProblem{http.StatusBadRequest, "invalidFilter", "The filter value is not a valid string"}
+	}
+	return strings.ToLower(match[1]), value, nil
+}
+
+// scimRoleGroupName returns the display name of a role's group
+func scimRoleGroupName(role string) (string, bool) {
+	for _, group := range scimRoleGroups {
+		if group.Role == role {
+			return group.DisplayName, true
+		}
+	}
+	return "", false
+}
+
+func (a *MyApp) scimUserResource(user *models.User) scimUser {
+	resource := scimUser{
+		Schemas:     []string{scimSchemaUser},
+		ID:          user.ID.String(),
+		ExternalID:  user.SCIMExternalID.String,
+		UserName:    user.Email,
+		Name:        scimName{Formatted: user.Name},
+		DisplayName: user.Name,
+		Emails:      []scimEmail{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      !user.IsDeactivated(),
+		Groups:      []scimMember{},
+		Meta: scimMeta{
+			ResourceType: "User",
+			Created:      &user.CreatedAt,
+			LastModified: &user.UpdatedAt,
+			Location:     a.scimLocation("Users", user.ID.String()),
+		},
+	}
+	if displayName, ok := scimRoleGroupName(user.Role); ok {
+		resource.Groups = append(resource.Groups, scimMember{Value: user.Role, Display: displayName, Ref: a.scimLocation("Groups", user.Role)})
+	}
+	if user.CompanyTeam != "" {
+		resource.Schemas = append(resource.Schemas, scimSchemaEnterpriseUser)
+		resource.Enterprise = &scimEnterpriseUser{Department: user.CompanyTeam}
+	}
+	return resource
+}
+
+func (a *MyApp) scimGroupResource(role, displayName string, members *models.Users) scimGroup {
+	group := scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          role,
+		DisplayName: displayName,
+		Meta: scimMeta{
+			ResourceType: "Group",
+			Location:     a.scimLocation("Groups", role),
+		},
+	}
+	if members != nil {
+		group.Members = []scimMember{}
+		for _, member := range *members {
+			group.Members = append(group.Members, scimMember{Value: member.ID.String(), Display: member.Email, Ref: a.scimLocation("Users", member.ID.String())})
+		}
+	}
+	return group
+}
+
+// scimFindUser finds the user of a SCIM resource ID, anything that isn't a user ID is simply not found
+func scimFindUser(repoManager repository.RepositoryInterface, id string) (*models.User, error) {
+	userID, err := uuid.FromString(id)
+	if err != nil {
+		return nil, scimProblem{http.StatusNotFound, "", fmt.Sprintf("User %s not found", id)}
+	}
+	user, err := repoManager.UserFindByID(userID)
+	if err != nil {
+		return nil, scimProblem{http.StatusNotFound, "", fmt.Sprintf("User %s not found", id)}
+	}
+	return user, nil
+}
+
+// isLastActiveOwner returns true when taking the user's owner role or account away would leave no owner to run the app
+func isLastActiveOwner(repoManager repository.RepositoryInterface, user *models.User) (bool, error) {
+	if !user.IsOwner() || user.IsDeactivated() {
+		return false, nil
+	}
+	owners, err := repoManager.UserFindByRole(models.RoleOwner)
+	if err != nil {
+		return false, err
+	}
+	for _, owner := range *owners {
+		if owner.ID != user.ID && !owner.IsDeactivated() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// applySCIMUserChanges copies the requested attributes onto a user and returns the ones that changed
+func applySCIMUserChanges(repoManager repository.RepositoryInterface, user *models.User, ch scimUserChanges) ([]string, error) {
+	changed := []string{}
+
+	if email := ch.email(); email != nil {
+		normalized := strings.ToLower(*email)
+		if normalized == "" {
+			return nil, scimProblem{http.StatusBadRequest, "invalidValue", "userName must be an email address"}
+		}
+		if normalized != user.Email {
+			local, domain, found := strings.Cut(normalized, "@")
+			if !found || local == "" || domain == "" {
+				return nil, scimProblem{http.StatusBadRequest, "invalidValue", "userName must be an email address"}
+			}
+			allowed, err := repoManager.CompanyAllowedDomainIsDomainAllowed(domain)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				return nil, scimProblem{http.StatusBadRequest, "invalidValue", fmt.Sprintf("The email domain %s is not allowed", domain)}
+			}
+			if existing, err := repoManager.UserFindByEmail(normalized); err == nil && existing.ID != user.ID {
+				return nil, scimProblem{http.StatusConflict, "uniqueness", fmt.Sprintf("A user with userName %s already exists", normalized)}
+			}
+			user.Email = normalized
+			changed = append(changed, "email")
+		}
+		// The identity provider vouches for the addresses it provisions
+		if !user.IsEmailVerified() {
+			user.EmailVerifiedAt = nulls.NewTime(time.Now())
+		}
+	}
+
+	if name := ch.name(); name != nil && *name != "" && *name != user.Name {
+		user.Name = *name
+		changed = append(changed, "name")
+	}
+
+	if ch.Team != nil && *ch.Team != user.CompanyTeam {
+		user.CompanyTeam = *ch.Team
+		changed = append(changed, "company team")
+	}
+
+	if ch.ExternalID != nil && *ch.ExternalID != user.SCIMExternalID.String {
+		user.SCIMExternalID = nulls.String{}
+		if *ch.ExternalID != "" {
+			user.SCIMExternalID = nulls.NewString(*ch.ExternalID)
+		}
+		changed = append(changed, "external ID")
+	}
+
+	return changed, nil
+}
+
+// setSCIMUserActive deactivates or reactivates a user. Deactivating signs them out everywhere.
+func setSCIMUserActive(c buffalo.Context, tx *pop.Connection, repoManager repository.RepositoryInterface, user *models.User, active bool) error {
+	if active == !user.IsDeactivated() {
+		return nil
+	}
+
+	if active {
+		user.DeactivatedAt = nulls.Time{}
+		if err := tx.UpdateColumns(user, "deactivated_at", "updated_at"); err != nil {
+			return err
+		}
+		scimAudit(tx, c, "scim_reactivate_user", user, fmt.Sprintf("Reactivated user %s (%s)", user.Name, user.Email))
+		return nil
+	}
+
+	last, err := isLastActiveOwner(repoManager, user)
+	if err != nil {
+		return err
+	}
+	if last {
+		return scimProblem{http.StatusConflict, "mutability", "The last active owner can't be deactivated"}
+	}
+
+	user.DeactivatedAt = nulls.NewTime(time.Now())
+	if err := tx.UpdateColumns(user, "deactivated_at", "updated_at"); err != nil {
+		return err
+	}
+	if err := repoManager.UserSessionDeleteByUserID(user.ID); err != nil {
+		return err
+	}
+	scimAudit(tx, c, "scim_deactivate_user", user, fmt.Sprintf("Deactivated user %s (%s)", user.Name, user.Email))
+	return nil
+}
+
+// setSCIMUserRole moves a user to another role's group
+func setSCIMUserRole(c buffalo.Context, tx *pop.Connection, repoManager repository.RepositoryInterface, user *models.User, role string) error {
+	if user.Role == role {
+		return nil
+	}
+	if role != models.RoleOwner {
+		last, err := isLastActiveOwner(repoManager, user)
+		if err != nil {
+			return err
+		}
+		if last {
+			return scimProblem{http.StatusConflict, "mutability", "The last active owner can't lose the owner role"}
+		}
+	}
+
+	previous := user.Role
+	user.Role = role
+	if err := tx.UpdateColumns(user, "role", "updated_at"); err != nil {
+		return err
+	}
+	scimAudit(tx, c, "scim_update_role", user, fmt.Sprintf("Changed role of %s from %s to %s", user.Email, previous, role))
+	return nil
+}
+
+// updateSCIMUser saves the requested changes to an existing user
+func (a *MyApp) updateSCIMUser(c buffalo.Context, tx *pop.Connection, user *models.User, ch scimUserChanges) error {
+	repoManager := a.Repository(tx)
+
+	changed, err := applySCIMUserChanges(repoManager, user, ch)
+	if err != nil {
+		return err
+	}
+	if len(changed) > 0 {
+		if err := tx.UpdateColumns(user, "email", "name", "company_team", "scim_external_id", "email_verified_at", "updated_at"); err != nil {
+			return err
+		}
+		scimAudit(tx, c, "scim_update_user", user, fmt.Sprintf("Updated %s of user %s (%s)", strings.Join(changed, ", "), user.Name, user.Email))
+	}
+
+	if ch.Active != nil {
+		return setSCIMUserActive(c, tx, repoManager, user, *ch.Active)
+	}
+	return nil
+}
+
+// RequireSCIMToken authenticates SCIM requests with a bearer token issued in the admin panel
+func (a *MyApp) RequireSCIMToken(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		tx := c.Value("tx").(*pop.Connection)
+		repoManager := a.Repository(tx)
+
+		scheme, token, _ := strings.Cut(c.Request().Header.Get("Authorization"), " ")
+		token = strings.TrimSpace(token)
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			c.Response().Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+			return scimError(c, http.StatusUnauthorized, "", "A bearer token is required")
+		}
+
+		scimToken, err := repoManager.SCIMTokenFindByTokenHash(models.HashSCIMToken(token))
+		if err != nil {
+			c.Response().Header().Set("WWW-Authenticate", `Bearer realm="SCIM", error="invalid_token"`)
+			return scimError(c, http.StatusUnauthorized, "", "The bearer token is invalid or has been revoked")
+		}
+
+		now := time.Now()
+		if !scimToken.LastUsedAt.Valid || now.Sub(scimToken.LastUsedAt.Time) >= sessionTouchInterval {
+			if err := repoManager.SCIMTokenTouch(scimToken.ID, now); err != nil {
+				c.Logger().Errorf("Failed to record SCIM token use: %v", err)
+			}
+		}
+
+		c.Set("scim_token", *scimToken)
+		return next(c)
+	}
+}
+
+// SCIMServiceProviderConfig describes the SCIM features the app supports
+func (a *MyApp) SCIMServiceProviderConfig(c buffalo.Context) error {
+	return scimRender(c, http.StatusOK, map[string]interface{}{
+		"schemas":        []string{scimSchemaServiceProviderConfig},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]interface{}{"supported": true, "maxResults": scimMaxResults},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "A SCIM token created in the admin panel",
+			"primary":     true,
+		}},
+		"meta": scimMeta{ResourceType: "ServiceProviderConfig", Location: a.scimLocation("ServiceProviderConfig")},
+	})
+}
+
+// SCIMUsersIndex lists users, optionally filtered by userName, emails or externalId
+func (a *MyApp) SCIMUsersIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	startIndex, count := scimPage(c)
+
+	users := &models.Users{}
+	total := 0
+	if filter := c.Param("filter"); filter != "" {
+		attribute, value, err := scimFilter(filter)
+		if err != nil {
+			return scimFailure(c, err)
+		}
+
+		var user *models.User
+		switch attribute {
+		case "username", "emails", "emails.value":
+			user, err = repoManager.UserFindByEmail(strings.ToLower(strings.TrimSpace(value)))
+		case "externalid":
+			user, err = repoManager.UserFindBySCIMExternalID(value)
+		case "id":
+			user, err = scimFindUser(repoManager, value)
+		default:
+			return scimError(c, http.StatusBadRequest, "invalidFilter", "Users can be filtered by id, userName, emails.value and externalId")
+		}
+		if err == nil {
+			total = 1
+			if startIndex == 1 && count > 0 {
+				*users = append(*users, *user)
+			}
+		}
+	} else {
+		var err error
+		users, total, err = repoManager.UserFindPage(startIndex-1, count)
+		if err != nil {
+			return err
+		}
+	}
+
+	resources := []interface{}{}
+	for i := range *users {
+		resources = append(resources, a.scimUserResource(&(*users)[i]))
+	}
+	return scimRender(c, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// SCIMUsersShow returns one user
+func (a *MyApp) SCIMUsersShow(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	user, err := scimFindUser(a.Repository(tx), c.Param("user_id"))
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	return scimRender(c, http.StatusOK, a.scimUserResource(user))
+}
+
+// SCIMUsersCreate provisions a new account. It signs in through single sign-on, LDAP or a password reset.
+func (a *MyApp) SCIMUsersCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	ch, err := scimUserChangesFromResource(c.Request().Body)
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	if email := ch.email(); email == nil || *email == "" {
+		return scimError(c, http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+
+	// The password is never shown to anyone, the user sets their own by resetting it
+	password, err := randomPassword()
+	if err != nil {
+		return err
+	}
+	user := &models.User{
+		Role:                 models.RoleHacker,
+		Password:             password,
+		PasswordConfirmation: password,
+	}
+	if _, err := applySCIMUserChanges(repoManager, user, ch); err != nil {
+		return scimFailure(c, err)
+	}
+	if user.Name == "" {
+		user.Name, _, _ = strings.Cut(user.Email, "@")
+	}
+	if ch.Active != nil && !*ch.Active {
+		user.DeactivatedAt = nulls.NewTime(time.Now())
+	}
+
+	verrs, err := user.Create(tx)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return scimError(c, http.StatusBadRequest, "invalidValue", verrs.Error())
+	}
+
+	scimAudit(tx, c, "scim_create_user", user, fmt.Sprintf("Provisioned user %s (%s)", user.Name, user.Email))
+
+	resource := a.scimUserResource(user)
+	c.Response().Header().Set("Location", resource.Meta.Location)
+	return scimRender(c, http.StatusCreated, resource)
+}
+
+// SCIMUsersUpdate replaces the attributes of a user with the ones in the request
+func (a *MyApp) SCIMUsersUpdate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	user, err := scimFindUser(a.Repository(tx), c.Param("user_id"))
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	ch, err := scimUserChangesFromResource(c.Request().Body)
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	if err := a.updateSCIMUser(c, tx, user, ch); err != nil {
+		return scimFailure(c, err)
+	}
+	return scimRender(c, http.StatusOK, a.scimUserResource(user))
+}
+
+// SCIMUsersPatch changes some attributes of a user, setting active to false deactivates them
+func (a *MyApp) SCIMUsersPatch(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	user, err := scimFindUser(a.Repository(tx), c.Param("user_id"))
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	ch, err := scimUserChangesFromPatch(c.Request().Body)
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	if err := a.updateSCIMUser(c, tx, user, ch); err != nil {
+		return scimFailure(c, err)
+	}
+	return scimRender(c, http.StatusOK, a.scimUserResource(user))
+}
+
+// SCIMUsersDestroy deactivates a user. Accounts are kept so their projects and scores stay intact.
+func (a *MyApp) SCIMUsersDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	user, err := scimFindUser(repoManager, c.Param("user_id"))
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	if err := setSCIMUserActive(c, tx, repoManager, user, false); err != nil {
+		return scimFailure(c, err)
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// SCIMGroupsIndex lists the role groups, optionally filtered by displayName
+func (a *MyApp) SCIMGroupsIndex(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	startIndex, count := scimPage(c)
+
+	attribute, value := "", ""
+	if filter := c.Param("filter"); filter != "" {
+		var err error
+		if attribute, value, err = scimFilter(filter); err != nil {
+			return scimFailure(c, err)
+		}
+		if attribute != "displayname" && attribute != "id" {
+			return scimError(c, http.StatusBadRequest, "invalidFilter", "Groups can be filtered by id and displayName")
+		}
+	}
+	withMembers := !strings.Contains(strings.ToLower(c.Param("excludedAttributes")), "members")
+
+	matching := []interface{}{}
+	for _, group := range scimRoleGroups {
+		if attribute == "id" && value != group.Role {
+			continue
+		}
+		if attribute == "displayname" && !strings.EqualFold(value, group.DisplayName) && !strings.EqualFold(value, group.Role) {
+			continue
+		}
+
+		var members *models.Users
+		if withMembers {
+			var err error
+			if members, err = repoManager.UserFindByRole(group.Role); err != nil {
+				return err
+			}
+		}
+		matching = append(matching, a.scimGroupResource(group.Role, group.DisplayName, members))
+	}
+
+	resources := []interface{}{}
+	if startIndex <= len(matching) {
+		resources = matching[startIndex-1:]
+		if len(resources) > count {
+			resources = resources[:count]
+		}
+	}
+	return scimRender(c, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: len(matching),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// SCIMGroupsShow returns a role group with its members
+func (a *MyApp) SCIMGroupsShow(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	role := c.Param("group_id")
+	displayName, ok := scimRoleGroupName(role)
+	if !ok {
+		return scimError(c, http.StatusNotFound, "", fmt.Sprintf("Group %s not found", role))
+	}
+	members, err := a.Repository(tx).UserFindByRole(role)
+	if err != nil {
+		return err
+	}
+	return scimRender(c, http.StatusOK, a.scimGroupResource(role, displayName, members))
+}
+
+// scimMemberIDs decodes a list of group members
+func scimMemberIDs(raw json.RawMessage) ([]string, error) {
+	members := []scimMember{}
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &members); err != nil {
+			return nil, scimProblem{http.StatusBadRequest, "invalidValue", "members must be a list"}
+		}
+	}
+	ids := []string{}
+	for _, member := range members {
+		ids = append(ids, member.Value)
+	}
+	return ids, nil
+}
+
+// addSCIMGroupMembers gives users the role of a group
+func (a *MyApp) addSCIMGroupMembers(c buffalo.Context, tx *pop.Connection, role string, ids []string) error {
+	repoManager := a.Repository(tx)
+	for _, id := range ids {
+		user, err := scimFindUser(repoManager, id)
+		if err != nil {
+			return scimProblem{http.StatusBadRequest, "invalidValue", err.Error()}
+		}
+		if err := setSCIMUserRole(c, tx, repoManager, user, role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeSCIMGroupMembers sends members of a group back to the hacker role. Everyone has a role,
+// so removing someone from the hackers group leaves them a hacker.
+func (a *MyApp) removeSCIMGroupMembers(c buffalo.Context, tx *pop.Connection, role string, ids []string) error {
+	repoManager := a.Repository(tx)
+	for _, id := range ids {
+		user, err := scimFindUser(repoManager, id)
+		if err != nil {
+			return scimProblem{http.StatusBadRequest, "invalidValue", err.Error()}
+		}
+		if user.Role == role {
+			if err := setSCIMUserRole(c, tx, repoManager, user, models.RoleHacker); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replaceSCIMGroupMembers makes the listed users the only members of a group
+func (a *MyApp) replaceSCIMGroupMembers(c buffalo.Context, tx *pop.Connection, role string, ids []string) error {
+	current, err := a.Repository(tx).UserFindByRole(role)
+	if err != nil {
+		return err
+	}
+
+	// Add first so replacing the owners never leaves the app without one in between
+	if err := a.addSCIMGroupMembers(c, tx, role, ids); err != nil {
+		return err
+	}
+	keep := map[string]bool{}
+	for _, id := range ids {
+		keep[strings.ToLower(id)] = true
+	}
+	removed := []string{}
+	for _, member := range *current {
+		if !keep[member.ID.String()] {
+			removed = append(removed, member.ID.String())
+		}
+	}
+	return a.removeSCIMGroupMembers(c, tx, role, removed)
+}
+
+// SCIMGroupsUpdate replaces the members of a role group
+func (a *MyApp) SCIMGroupsUpdate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	role := c.Param("group_id")
+	displayName, ok := scimRoleGroupName(role)
+	if !ok {
+		return scimError(c, http.StatusNotFound, "", fmt.Sprintf("Group %s not found", role))
+	}
+
+	resource := map[string]json.RawMessage{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&resource); err != nil {
+		return scimError(c, http.StatusBadRequest, "invalidSyntax", "The request body is not a JSON object")
+	}
+	ids, err := scimMemberIDs(resource["members"])
+	if err != nil {
+		return scimFailure(c, err)
+	}
+	if err := a.replaceSCIMGroupMembers(c, tx, role, ids); err != nil {
+		return scimFailure(c, err)
+	}
+
+	members, err := a.Repository(tx).UserFindByRole(role)
+	if err != nil {
+		return err
+	}
+	return scimRender(c, http.StatusOK, a.scimGroupResource(role, displayName, members))
+}
+
+// SCIMGroupsPatch adds, removes or replaces members of a role group
+func (a *MyApp) SCIMGroupsPatch(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+
+	role := c.Param("group_id")
+	displayName, ok := scimRoleGroupName(role)
+	if !ok {
+		return scimError(c, http.StatusNotFound, "", fmt.Sprintf("Group %s not found", role))
+	}
+
+	patch := scimPatchRequest{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&patch); err != nil || len(patch.Operations) == 0 {
+		return scimError(c, http.StatusBadRequest, "invalidSyntax", "The request body is not a patch with operations")
+	}
+
+	for _, op := range patch.Operations {
+		path := strings.ToLower(op.Path)
+		raw := op.Value
+
+		// A patch without a path carries the attributes in its value, only members are stored
+		if path == "" {
+			attributes := map[string]json.RawMessage{}
+			if err := json.Unmarshal(op.Value, &attributes); err != nil {
+				return scimError(c, http.StatusBadRequest, "invalidValue", "A patch without a path needs an object value")
+			}
+			members, ok := attributes["members"]
+			if !ok {
+				continue
+			}
+			path, raw = "members", members
+		}
+
+		if match := scimMemberPath.FindStringSubmatch(op.Path); match != nil && strings.EqualFold(op.Op, "remove") {
+			if err := a.removeSCIMGroupMembers(c, tx, role, []string{match[1]}); err != nil {
+				return scimFailure(c, err)
+			}
+			continue
+		}
+		if path != "members" {
+			// The display names of the role groups are fixed
+			continue
+		}
+
+		ids, err := scimMemberIDs(raw)
+		if err != nil {
+			return scimFailure(c, err)
+		}
+		switch strings.ToLower(op.Op) {
+		case "add":
+			err = a.addSCIMGroupMembers(c, tx, role, ids)
+		case "remove":
+			if len(raw) == 0 {
+				// Removing the members attribute itself empties the group
+				err = a.replaceSCIMGroupMembers(c, tx, role, []string{})
+			} else {
+				err = a.removeSCIMGroupMembers(c, tx, role, ids)
+			}
+		case "replace":
+			err = a.replaceSCIMGroupMembers(c, tx, role, ids)
+		default:
+			err = scimProblem{http.StatusBadRequest, "invalidSyntax", fmt.Sprintf("Unknown patch operation %q", op.Op)}
+		}
+		if err != nil {
+			return scimFailure(c, err)
+		}
+	}
+
+	members, err := a.Repository(tx).UserFindByRole(role)
+	if err != nil {
+		return err
+	}
+	return scimRender(c, http.StatusOK, a.scimGroupResource(role, displayName, members))
+}
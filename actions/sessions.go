@@ -0,0 +1,164 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+)
+
+// sessionTouchInterval limits how often a session's last activity is written
+const sessionTouchInterval = time.Minute
+
+// startSession signs a user in on this browser with a new server-side session.
+func startSession(c buffalo.Context, tx *pop.Connection, user *models.User) error {
+	now := time.Now()
+	session := &models.UserSession{
+		UserID:     user.ID,
+		IPAddress:  clientIP(c),
+		UserAgent:  c.Request().UserAgent(),
+		LastSeenAt: now,
+	}
+	if err := tx.Create(session); err != nil {
+		return err
+	}
+
+	c.Session().Set(sessionCurrentUserID, user.ID.String())
+	c.Session().Set(sessionID, session.ID.String())
+	return nil
+}
+
+// activeSession returns the user and server-side session of the browser. Sessions that
+// were revoked or have expired are cleared from the cookie.
+func (a *MyApp) activeSession(c buffalo.Context, tx *pop.Connection, uid string) (*models.User, *models.UserSession, bool) {
+	repoManager := a.Repository(tx)
+
+	sid, err := uuid.FromString(fmt.Sprint(c.Session().Get(sessionID)))
+	if err != nil {
+		c.Session().Clear()
+		return nil, nil, false
+	}
+
+	session, err := repoManager.UserSessionFindByID(sid)
+	if err != nil || session.UserID.String() != uid {
+		c.Session().Clear()
+		return nil, nil, false
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	now := time.Now()
+	if session.IsExpired(config, now) {
+		if err := tx.Destroy(session); err != nil {
+			c.Logger().Errorf("Failed to delete expired session: %v", err)
+		}
+		c.Session().Clear()
+		c.Flash().Add("warning", "Your session has expired. Please sign in again.")
+		return nil, nil, false
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		session.LastSeenAt = now
+		if err := tx.UpdateColumns(session, "last_seen_at", "updated_at"); err != nil {
+			c.Logger().Errorf("Failed to update session activity: %v", err)
+		}
+	}
+
+	user, err := repoManager.UserFindByID(session.UserID)
+	if err != nil {
+		c.Session().Clear()
+		return nil, nil, false
+	}
+	if user.IsDeactivated() {
+		if err := tx.Destroy(session); err != nil {
+			c.Logger().Errorf("Failed to delete session of deactivated user: %v", err)
+		}
+		c.Session().Clear()
+		return nil, nil, false
+	}
+	return user, session, true
+}
+
+// CleanupExpiredSessions deletes the sessions that have outlived the configured timeouts
+func CleanupExpiredSessions(tx *pop.Connection, now time.Time) (int, error) {
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return 0, err
+	}
+	repoManager := repository.NewRepositoryManager(tx)
+	return repoManager.UserSessionDeleteExpired(now.Add(-config.SessionIdleTimeout()), now.Add(-config.SessionAbsoluteTimeout()))
+}
+
+// ProfileSessionsDestroy signs one of the current user's devices out.
+func (a *MyApp) ProfileSessionsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	session, err := repoManager.UserSessionFindByID(c.Param("session_id"))
+	if err != nil || session.UserID != currentUser.ID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("session not found"))
+	}
+
+	if err := tx.Destroy(session); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_session", "user_session", session.ID, fmt.Sprintf("Signed out %s (%s)", session.Device(), session.IPAddress))
+
+	if current, ok := c.Value("current_session").(models.UserSession); ok && current.ID == session.ID {
+		c.Session().Clear()
+		c.Flash().Add("success", "Signed out")
+		return c.Redirect(http.StatusSeeOther, "/signin")
+	}
+
+	c.Flash().Add("success", fmt.Sprintf("%s has been signed out", session.Device()))
+	return c.Redirect(http.StatusSeeOther, "/profile")
+}
+
+// ProfileSessionsDestroyAll signs the current user out on every device, including this one.
+func (a *MyApp) ProfileSessionsDestroyAll(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	if err := repoManager.UserSessionDeleteByUserID(currentUser.ID); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_all_sessions", "user", &currentUser.ID, fmt.Sprintf("Signed out everywhere: %s", currentUser.Email))
+
+	c.Session().Clear()
+	c.Flash().Add("success", "You have been signed out on every device")
+	return c.Redirect(http.StatusSeeOther, "/signin")
+}
+
+// AdminUsersSessionsDestroy signs a user out on every device
+func (a *MyApp) AdminUsersSessionsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	user, err := repoManager.UserFindByID(c.Param("user_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if err := repoManager.UserSessionDeleteByUserID(user.ID); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "admin_revoke_sessions", "user", &user.ID, fmt.Sprintf("Signed out all sessions of %s (%s)", user.Name, user.Email))
+
+	c.Flash().Add("success", "All sessions of this user have been signed out")
+	return c.Redirect(http.StatusSeeOther, fmt.Sprintf("/admin/users/%s", user.ID))
+}
@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// submissionDateFormat is how submission deadlines are shown to participants
+const submissionDateFormat = "Jan 2, 2006 15:04"
+
+// submissionsClosed reports whether the project is frozen and, if so, flashes why
+func submissionsClosed(c buffalo.Context, repoManager repository.RepositoryInterface, project *models.Project) (bool, error) {
+	hackathon, err := repoManager.HackathonFindByID(project.HackathonID)
+	if err != nil {
+		return false, err
+	}
+	if !project.IsFrozen(*hackathon, time.Now()) {
+		return false, nil
+	}
+
+	c.Flash().Add("warning", fmt.Sprintf("Submissions for %s closed on %s. Ask an organizer for an extension.", hackathon.Title, project.SubmissionsCloseAt(*hackathon).Format(submissionDateFormat)))
+	return true, nil
+}
+
+// ProjectExtensionsCreate lets a project keep changing after the submission deadline (hackathon owner only)
+func (a *MyApp) ProjectExtensionsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != c.Param("hackathon_id") {
+		return c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+	}
+	projectPath := fmt.Sprintf("/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+
+	until, err := time.Parse("2006-01-02T15:04", c.Param("extended_until"))
+	if err != nil {
+		c.Flash().Add("danger", "Please pick the date and time the extension ends.")
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+	if !until.After(time.Now()) {
+		c.Flash().Add("danger", "An extension must end in the future.")
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	project.ExtendedUntil = nulls.NewTime(until)
+	if err := tx.UpdateColumns(project, "extended_until", "updated_at"); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "grant_extension", "project", project.ID, fmt.Sprintf("Submission extension for project %s until %s", project.Name, until.Format(time.RFC3339)))
+
+	c.Flash().Add("success", fmt.Sprintf("%s can keep submitting until %s.", project.Name, until.Format(submissionDateFormat)))
+	return c.Redirect(http.StatusSeeOther, projectPath)
+}
+
+// ProjectExtensionsDestroy withdraws a project's extension (hackathon owner only)
+func (a *MyApp) ProjectExtensionsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != c.Param("hackathon_id") {
+		return c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+	}
+
+	project.ExtendedUntil = nulls.Time{}
+	if err := tx.UpdateColumns(project, "extended_until", "updated_at"); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_extension", "project", project.ID, fmt.Sprintf("Submission extension for project %s withdrawn", project.Name))
+
+	c.Flash().Add("success", "Extension withdrawn.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+}
@@ -0,0 +1,305 @@
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// findOwnedProject loads the project of the request and checks the current user owns it
+func findOwnedProject(c buffalo.Context, repoManager repository.RepositoryInterface) (*models.Project, error) {
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != c.Param("hackathon_id") {
+		return nil, c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+	}
+	if project.UserID == nil || *project.UserID != currentUser.ID {
+		return nil, c.Error(http.StatusForbidden, fmt.Errorf("only the project owner can manage the team"))
+	}
+	return project, nil
+}
+
+// JoinRequestsCreate asks the project owner to let the current user join the team
+func (a *MyApp) JoinRequestsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != c.Param("hackathon_id") {
+		return c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+	}
+	projectPath := fmt.Sprintf("/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+
+	if closed, err := submissionsClosed(c, repoManager, project); err != nil {
+		return err
+	} else if closed {
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	isMember, err := repoManager.ProjectMembershipIsUserMember(project.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	if isMember {
+		c.Flash().Add("warning", "You are already a member of this project.")
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	pending, err := repoManager.TeamRequestHasPendingJoinRequest(project.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	if pending {
+		c.Flash().Add("info", "Your request is already waiting for the project owner.")
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	request := &models.JoinRequest{
+		ProjectID: project.ID,
+		UserID:    currentUser.ID,
+		Status:    models.TeamRequestPending,
+	}
+	if message := strings.TrimSpace(c.Param("message")); message != "" {
+		request.Message = nulls.NewString(message)
+	}
+
+	verrs, err := tx.ValidateAndCreate(request)
+	if err != nil {
+		if isDuplicateError(err) {
+			c.Flash().Add("info", "Your request is already waiting for the project owner.")
+			return c.Redirect(http.StatusSeeOther, projectPath)
+		}
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.String())
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "request_join", "project", project.ID, fmt.Sprintf("Requested to join project: %s", project.Name))
+
+	c.Flash().Add("success", "Join request sent to the project owner.")
+	return c.Redirect(http.StatusSeeOther, projectPath)
+}
+
+// JoinRequestsApprove adds the requester to the team (project owner only)
+func (a *MyApp) JoinRequestsApprove(c buffalo.Context) error {
+	return a.decideJoinRequest(c, true)
+}
+
+// JoinRequestsDecline turns down a join request (project owner only)
+func (a *MyApp) JoinRequestsDecline(c buffalo.Context) error {
+	return a.decideJoinRequest(c, false)
+}
+
+// decideJoinRequest records the project owner's answer to a pending join request
+func (a *MyApp) decideJoinRequest(c buffalo.Context, approve bool) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := findOwnedProject(c, repoManager)
+	if err != nil {
+		return err
+	}
+	projectPath := fmt.Sprintf("/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+
+	request, err := repoManager.TeamRequestFindJoinRequestByID(c.Param("request_id"))
+	if err != nil || request.ProjectID != project.ID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("join request not found"))
+	}
+	if request.Status != models.TeamRequestPending {
+		c.Flash().Add("info", "This request has already been answered.")
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	if approve {
+		_, err := addProjectMember(tx, repoManager, project.ID, request.UserID)
+		switch {
+		case errors.Is(err, errTeamFull):
+			c.Flash().Add("warning", "The team is full. Free a seat before approving more requests.")
+			return c.Redirect(http.StatusSeeOther, projectPath)
+		case errors.Is(err, errSubmissionsClosed):
+			c.Flash().Add("warning", "Submissions are closed, so the team can no longer change.")
+			return c.Redirect(http.StatusSeeOther, projectPath)
+		case err != nil && !errors.Is(err, errAlreadyMember):
+			return err
+		}
+		request.Status = models.TeamRequestApproved
+	} else {
+		request.Status = models.TeamRequestDeclined
+	}
+
+	request.DecidedByID = nulls.NewUUID(currentUser.ID)
+	if err := tx.Update(request); err != nil {
+		return err
+	}
+
+	action := "approve_join"
+	message := "Join request approved. Welcome to the team!"
+	if !approve {
+		action = "decline_join"
+		message = "Join request declined."
+	}
+	logAuditEvent(tx, c, &currentUser.ID, action, "join_request", &request.ID, fmt.Sprintf("Join request of user %s for project %s: %s", request.UserID, project.Name, request.Status))
+
+	c.Flash().Add("success", message)
+	return c.Redirect(http.StatusSeeOther, projectPath)
+}
+
+// ProjectInvitationsCreate invites a user by email to join the team (project owner only)
+func (a *MyApp) ProjectInvitationsCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := findOwnedProject(c, repoManager)
+	if err != nil {
+		return err
+	}
+	projectPath := fmt.Sprintf("/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+
+	if closed, err := submissionsClosed(c, repoManager, project); err != nil {
+		return err
+	} else if closed {
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(c.Param("email")))
+	if invitee, err := repoManager.UserFindByEmail(email); err == nil {
+		isMember, err := repoManager.ProjectMembershipIsUserMember(project.ID, invitee.ID)
+		if err != nil {
+			return err
+		}
+		if isMember {
+			c.Flash().Add("warning", fmt.Sprintf("%s is already on the team.", email))
+			return c.Redirect(http.StatusSeeOther, projectPath)
+		}
+	}
+
+	invitation := &models.ProjectInvitation{
+		ProjectID:   project.ID,
+		Email:       email,
+		InvitedByID: currentUser.ID,
+		Status:      models.TeamRequestPending,
+	}
+	verrs, err := tx.ValidateAndCreate(invitation)
+	if err != nil {
+		if isDuplicateError(err) {
+			c.Flash().Add("info", fmt.Sprintf("%s has already been invited.", email))
+			return c.Redirect(http.StatusSeeOther, projectPath)
+		}
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", verrs.String())
+		return c.Redirect(http.StatusSeeOther, projectPath)
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "invite", "project_invitation", &invitation.ID, fmt.Sprintf("Invited %s to project: %s", email, project.Name))
+
+	c.Flash().Add("success", fmt.Sprintf("Invitation sent to %s.", email))
+	return c.Redirect(http.StatusSeeOther, projectPath)
+}
+
+// ProjectInvitationsDestroy withdraws a pending invitation (project owner only)
+func (a *MyApp) ProjectInvitationsDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	project, err := findOwnedProject(c, repoManager)
+	if err != nil {
+		return err
+	}
+
+	invitation, err := repoManager.TeamRequestFindInvitationByID(c.Param("invitation_id"))
+	if err != nil || invitation.ProjectID != project.ID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("invitation not found"))
+	}
+
+	if err := tx.Destroy(invitation); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "revoke_invitation", "project_invitation", &invitation.ID, fmt.Sprintf("Invitation of %s to project %s revoked", invitation.Email, project.Name))
+
+	c.Flash().Add("success", "Invitation withdrawn.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+}
+
+// ProjectInvitationsAccept joins the team the current user was invited to
+func (a *MyApp) ProjectInvitationsAccept(c buffalo.Context) error {
+	return a.answerInvitation(c, true)
+}
+
+// ProjectInvitationsDecline turns down an invitation sent to the current user
+func (a *MyApp) ProjectInvitationsDecline(c buffalo.Context) error {
+	return a.answerInvitation(c, false)
+}
+
+// answerInvitation records the invitee's answer to a pending invitation
+func (a *MyApp) answerInvitation(c buffalo.Context, accept bool) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	invitation, err := repoManager.TeamRequestFindInvitationByID(c.Param("invitation_id"))
+	if err != nil || !invitation.IsFor(currentUser) {
+		return c.Error(http.StatusNotFound, fmt.Errorf("invitation not found"))
+	}
+	if invitation.Status != models.TeamRequestPending {
+		c.Flash().Add("info", "You already answered this invitation.")
+		return c.Redirect(http.StatusSeeOther, "/profile")
+	}
+
+	project, err := repoManager.ProjectFindByID(invitation.ProjectID)
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+	projectPath := fmt.Sprintf("/hackathons/%s/projects/%s", project.HackathonID, project.ID)
+
+	if accept {
+		_, err := addProjectMember(tx, repoManager, project.ID, currentUser.ID)
+		switch {
+		case errors.Is(err, errTeamFull):
+			c.Flash().Add("warning", fmt.Sprintf("%s is full. Ask the owner to free a seat.", project.Name))
+			return c.Redirect(http.StatusSeeOther, projectPath)
+		case errors.Is(err, errSubmissionsClosed):
+			c.Flash().Add("warning", fmt.Sprintf("Submissions for %s are closed, so the team can no longer change.", project.Name))
+			return c.Redirect(http.StatusSeeOther, projectPath)
+		case err != nil && !errors.Is(err, errAlreadyMember):
+			return err
+		}
+		invitation.Status = models.TeamRequestAccepted
+	} else {
+		invitation.Status = models.TeamRequestDeclined
+	}
+
+	if err := tx.Update(invitation); err != nil {
+		return err
+	}
+
+	action := "accept_invitation"
+	if !accept {
+		action = "decline_invitation"
+	}
+	logAuditEvent(tx, c, &currentUser.ID, action, "project_invitation", &invitation.ID, fmt.Sprintf("Invitation to project %s %s", project.Name, invitation.Status))
+
+	if !accept {
+		c.Flash().Add("success", "Invitation declined.")
+		return c.Redirect(http.StatusSeeOther, "/profile")
+	}
+	c.Flash().Add("success", fmt.Sprintf("You joined %s!", project.Name))
+	return c.Redirect(http.StatusSeeOther, projectPath)
+}
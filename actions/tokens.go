@@ -0,0 +1,74 @@
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/gobuffalo/envy"
+)
+
+var errMalformedToken = errors.New("malformed token")
+
+func init() {
+	// Anyone could forge password reset and verification links signed with the public fallback key
+	if envy.Get("SESSION_SECRET", "") == "" && ENV != "development" && ENV != "test" {
+		log.Fatalf("SESSION_SECRET has to be set in the %s environment, it signs sessions and emailed links", ENV)
+	}
+}
+
+// tokenSigningKey returns the key signed links are authenticated with, matching Buffalo's session secret.
+// The fallback is only used in development and test.
+func tokenSigningKey() []byte {
+	secret := envy.Get("SESSION_SECRET", "")
+	if secret == "" {
+		secret = "buffalo-secret"
+	}
+	return []byte(secret)
+}
+
+// tokenSignature authenticates an encoded payload for a purpose and the values it is bound to
+func tokenSignature(purpose, encoded string, bind ...string) string {
+	mac := hmac.New(sha256.New, tokenSigningKey())
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write([]byte(encoded))
+	for _, value := range bind {
+		mac.Write([]byte{0})
+		mac.Write([]byte(value))
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signToken returns a URL-safe token carrying payload. Values in bind are not part of the
+// token but must match again when it is checked, so changing them invalidates the token.
+func signToken(purpose, payload string, bind ...string) string {
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + tokenSignature(purpose, encoded, bind...)
+}
+
+// tokenPayload returns the payload of a token without checking its signature
+func tokenPayload(token string) (string, error) {
+	encoded, _, found := strings.Cut(token, ".")
+	if !found {
+		return "", errMalformedToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errMalformedToken
+	}
+	return string(payload), nil
+}
+
+// tokenSignatureValid reports whether a token was issued by signToken for the same purpose and bound values
+func tokenSignatureValid(purpose, token string, bind ...string) bool {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return false
+	}
+	expected := tokenSignature(purpose, encoded, bind...)
+	return hmac.Equal([]byte(signature), []byte(expected))
+}
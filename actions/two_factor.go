@@ -0,0 +1,495 @@
+package actions
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"image/png"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	sessionTwoFactorUserID    = "two_factor_user_id"
+	sessionTwoFactorStartedAt = "two_factor_started_at"
+	sessionTwoFactorEnrollKey = "two_factor_enrollment_key"
+
+	// twoFactorSignInWindow is how long a password check stays valid while waiting for the second factor
+	twoFactorSignInWindow = 5 * time.Minute
+	twoFactorPeriod       = 30
+	recoveryCodeCount     = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// RequireTwoFactorEnrollment sends signed-in users to the enrollment page when the company requires two-factor authentication.
+func (a *MyApp) RequireTwoFactorEnrollment(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		currentUser, ok := c.Value("current_user").(models.User)
+		if !ok || currentUser.TOTPEnabled {
+			return next(c)
+		}
+
+		path := c.Request().URL.Path
+		for _, allowed := range []string{"/profile/two-factor", "/verify-email", "/signout", "/reset-password", "/assets/"} {
+			if strings.HasPrefix(path, allowed) {
+				return next(c)
+			}
+		}
+
+		tx, ok := c.Value("tx").(*pop.Connection)
+		if !ok {
+			return next(c)
+		}
+		config, err := models.GetDefaultConfig(tx)
+		if err != nil {
+			return err
+		}
+		if !config.TwoFactorRequired {
+			return next(c)
+		}
+
+		return c.Redirect(http.StatusFound, "/profile/two-factor?required=true")
+	}
+}
+
+// beginSignIn signs in a user whose password or single sign-on checked out. Users with an
+// authenticator app still owe a second factor before a session starts, deactivated users and service accounts are turned away.
+func beginSignIn(c buffalo.Context, tx *pop.Connection, dbUser *models.User) error {
+	if dbUser.IsServiceAccount {
+		logAuditEvent(tx, c, &dbUser.ID, "login_failed", "user", &dbUser.ID, fmt.Sprintf("Sign-in refused for service account: %s", dbUser.Name))
+		c.Flash().Add("danger", "Service accounts can't sign in, they only use API tokens.")
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+
+	if dbUser.IsDeactivated() {
+		logAuditEvent(tx, c, &dbUser.ID, "login_failed", "user", &dbUser.ID, fmt.Sprintf("Sign-in refused for deactivated account: %s", dbUser.Email))
+		c.Flash().Add("danger", "This account has been deactivated. Contact an administrator if you think this is a mistake.")
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+
+	if dbUser.TOTPEnabled {
+		c.Session().Clear()
+		c.Session().Set(sessionTwoFactorUserID, dbUser.ID.String())
+		c.Session().Set(sessionTwoFactorStartedAt, time.Now().Unix())
+		return c.Redirect(http.StatusFound, "/signin/two-factor")
+	}
+
+	return completeSignIn(c, tx, dbUser)
+}
+
+// completeSignIn starts a session for a user whose credentials have been fully verified
+func completeSignIn(c buffalo.Context, tx *pop.Connection, dbUser *models.User) error {
+	c.Session().Clear()
+
+	// A successful sign-in starts the failure count over
+	if err := repository.NewRepositoryManager(tx).LoginAttemptDeleteByEmail(strings.ToLower(dbUser.Email)); err != nil {
+		return err
+	}
+
+	// Check if user needs to reset password
+	if dbUser.ForcePasswordReset {
+		if err := startSession(c, tx, dbUser); err != nil {
+			return err
+		}
+		return c.Redirect(http.StatusFound, "/reset-password?required=true")
+	}
+
+	// Log successful login
+	logAuditEvent(tx, c, &dbUser.ID, "login", "user", &dbUser.ID, fmt.Sprintf("User logged in: %s (%s)", dbUser.Name, dbUser.Email))
+
+	if err := startSession(c, tx, dbUser); err != nil {
+		return err
+	}
+	c.Flash().Add("success", "Welcome back!")
+	return c.Redirect(http.StatusFound, "/")
+}
+
+// pendingTwoFactorUser returns the user who passed the password check and still owes a second factor
+func (a *MyApp) pendingTwoFactorUser(c buffalo.Context, repoManager repository.RepositoryInterface) (*models.User, bool) {
+	uid, ok := c.Session().Get(sessionTwoFactorUserID).(string)
+	if !ok || uid == "" {
+		return nil, false
+	}
+	startedAt, ok := c.Session().Get(sessionTwoFactorStartedAt).(int64)
+	if !ok || time.Since(time.Unix(startedAt, 0)) > twoFactorSignInWindow {
+		return nil, false
+	}
+
+	user, err := repoManager.UserFindByID(uid)
+	if err != nil || !user.TOTPEnabled {
+		return nil, false
+	}
+	return user, true
+}
+
+// AuthTwoFactorNew renders the second sign-in step.
+func (a *MyApp) AuthTwoFactorNew(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	if _, ok := a.pendingTwoFactorUser(c, a.Repository(tx)); !ok {
+		c.Session().Clear()
+		c.Flash().Add("danger", "Your sign-in has expired. Please sign in again.")
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+	return c.Render(http.StatusOK, r.HTML("auth/two_factor.plush.html"))
+}
+
+// AuthTwoFactorCreate verifies an authenticator or recovery code and finishes signing in.
+func (a *MyApp) AuthTwoFactorCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	dbUser, ok := a.pendingTwoFactorUser(c, repoManager)
+	if !ok {
+		c.Session().Clear()
+		c.Flash().Add("danger", "Your sign-in has expired. Please sign in again.")
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+
+	// Guessing codes counts against the same limits as guessing passwords
+	email := strings.ToLower(dbUser.Email)
+	ip := clientIP(c)
+	wait, locked, err := loginThrottle(repoManager, config, email, ip, time.Now())
+	if err != nil {
+		return err
+	}
+	if locked {
+		c.Session().Clear()
+		c.Flash().Add("danger", loginThrottleMessage(wait, true))
+		return c.Redirect(http.StatusFound, "/signin")
+	}
+	if wait > 0 {
+		c.Flash().Add("danger", loginThrottleMessage(wait, false))
+		return c.Redirect(http.StatusFound, "/signin/two-factor")
+	}
+
+	code := strings.TrimSpace(c.Param("code"))
+	verified, err := verifyTOTPCode(tx, dbUser, code)
+	if err != nil {
+		return err
+	}
+	if !verified && code != "" {
+		verified, err = redeemRecoveryCode(tx, c, repoManager, dbUser, code)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !verified {
+		logAuditEvent(tx, c, &dbUser.ID, "two_factor_failed", "user", &dbUser.ID, fmt.Sprintf("Invalid two-factor code for %s", dbUser.Email))
+
+		locked, err := a.recordLoginFailure(c, tx, config, email, ip)
+		if err != nil {
+			return err
+		}
+		if locked {
+			c.Session().Clear()
+			c.Flash().Add("danger", loginThrottleMessage(config.LoginLockoutDuration(), true))
+			return c.Redirect(http.StatusFound, "/signin")
+		}
+
+		c.Flash().Add("danger", "Invalid authentication code")
+		return c.Redirect(http.StatusFound, "/signin/two-factor")
+	}
+
+	return completeSignIn(c, tx, dbUser)
+}
+
+// verifyTOTPCode checks a code against the user's enrolled secret. A time step is only
+// accepted once so an observed code cannot be replayed.
+func verifyTOTPCode(tx *pop.Connection, user *models.User, code string) (bool, error) {
+	if !user.TOTPSecret.Valid {
+		return false, nil
+	}
+
+	step, ok := matchTOTPStep(user.TOTPSecret.String, code, time.Now())
+	if !ok || (user.TOTPLastStep.Valid && step <= user.TOTPLastStep.Int64) {
+		return false, nil
+	}
+
+	user.TOTPLastStep = nulls.NewInt64(step)
+	if err := tx.UpdateColumns(user, "totp_last_step", "updated_at"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// matchTOTPStep returns the time step a code belongs to, allowing one step of clock drift either way
+func matchTOTPStep(secret, code string, now time.Time) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != int(otp.DigitsSix) {
+		return 0, false
+	}
+
+	opts := totp.ValidateOpts{Period: twoFactorPeriod, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+	for _, skew := range []int64{0, -1, 1} {
+		at := now.Add(time.Duration(skew*twoFactorPeriod) * time.Second)
+		expected, err := totp.GenerateCodeCustom(secret, at, opts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / twoFactorPeriod, true
+		}
+	}
+	return 0, false
+}
+
+// redeemRecoveryCode marks a matching recovery code as used
+func redeemRecoveryCode(tx *pop.Connection, c buffalo.Context, repoManager repository.RepositoryInterface, user *models.User, code string) (bool, error) {
+	recoveryCode, err := repoManager.RecoveryCodeFindUnusedByUserIDAndHash(user.ID, models.HashRecoveryCode(code))
+	if err != nil {
+		return false, nil
+	}
+
+	recoveryCode.UsedAt = nulls.NewTime(time.Now())
+	if err := tx.UpdateColumns(recoveryCode, "used_at", "updated_at"); err != nil {
+		return false, err
+	}
+
+	logAuditEvent(tx, c, &user.ID, "use_recovery_code", "user", &user.ID, fmt.Sprintf("Recovery code used to sign in: %s", user.Email))
+	return true, nil
+}
+
+// generateRecoveryCodes replaces a user's recovery codes and returns the new plain codes
+func generateRecoveryCodes(tx *pop.Connection, repoManager repository.RepositoryInterface, user *models.User) ([]string, error) {
+	if err := repoManager.RecoveryCodeDeleteByUserID(user.ID); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		code := fmt.Sprintf("%s-%s-%s-%s", encoded[0:4], encoded[4:8], encoded[8:12], encoded[12:16])
+
+		if err := tx.Create(&models.RecoveryCode{UserID: user.ID, CodeHash: models.HashRecoveryCode(code)}); err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// enrollmentKey returns the pending enrollment key of the session, creating one when needed
+func enrollmentKey(c buffalo.Context, tx *pop.Connection, user models.User) (*otp.Key, error) {
+	if url, ok := c.Session().Get(sessionTwoFactorEnrollKey).(string); ok && url != "" {
+		if key, err := otp.NewKeyFromURL(url); err == nil && key.AccountName() == user.Email {
+			return key, nil
+		}
+	}
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      config.CompanyName,
+		AccountName: user.Email,
+		Period:      twoFactorPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.Session().Set(sessionTwoFactorEnrollKey, key.URL())
+	return key, nil
+}
+
+// qrCodeDataURI renders an enrollment key as an inline PNG image
+func qrCodeDataURI(key *otp.Key) (string, error) {
+	img, err := key.Image(200, 200)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// TwoFactorShow renders two-factor enrollment or, once enrolled, its settings.
+func (a *MyApp) TwoFactorShow(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	user := c.Value("current_user").(models.User)
+	repoManager := a.Repository(tx)
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+
+	c.Set("user", user)
+	c.Set("twoFactorRequired", config.TwoFactorRequired)
+	c.Set("enrollmentRequired", c.Param("required") == "true" && !user.TOTPEnabled)
+
+	if user.TOTPEnabled {
+		remaining, err := repoManager.RecoveryCodeCountUnusedByUserID(user.ID)
+		if err != nil {
+			return err
+		}
+		c.Set("remainingRecoveryCodes", remaining)
+		return c.Render(http.StatusOK, r.HTML("profile/two_factor.plush.html"))
+	}
+
+	key, err := enrollmentKey(c, tx, user)
+	if err != nil {
+		return err
+	}
+	qrCode, err := qrCodeDataURI(key)
+	if err != nil {
+		return err
+	}
+
+	c.Set("secret", key.Secret())
+	c.Set("qrCode", qrCode)
+	return c.Render(http.StatusOK, r.HTML("profile/two_factor.plush.html"))
+}
+
+// TwoFactorCreate confirms enrollment with a code from the authenticator app.
+func (a *MyApp) TwoFactorCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	user := c.Value("current_user").(models.User)
+	repoManager := a.Repository(tx)
+
+	if user.TOTPEnabled {
+		c.Flash().Add("info", "Two-factor authentication is already enabled")
+		return c.Redirect(http.StatusSeeOther, "/profile/two-factor")
+	}
+
+	url, ok := c.Session().Get(sessionTwoFactorEnrollKey).(string)
+	if !ok || url == "" {
+		c.Flash().Add("danger", "Your enrollment has expired. Please scan the new QR code.")
+		return c.Redirect(http.StatusSeeOther, "/profile/two-factor")
+	}
+	key, err := otp.NewKeyFromURL(url)
+	if err != nil {
+		return err
+	}
+
+	step, ok := matchTOTPStep(key.Secret(), strings.TrimSpace(c.Param("code")), time.Now())
+	if !ok {
+		c.Flash().Add("danger", "That code is not valid. Check the time on your device and try again.")
+		return c.Redirect(http.StatusSeeOther, "/profile/two-factor")
+	}
+
+	user.TOTPSecret = nulls.NewString(key.Secret())
+	user.TOTPEnabled = true
+	user.TOTPLastStep = nulls.NewInt64(step)
+	if err := tx.UpdateColumns(&user, "totp_secret", "totp_enabled", "totp_last_step", "updated_at"); err != nil {
+		return err
+	}
+
+	codes, err := generateRecoveryCodes(tx, repoManager, &user)
+	if err != nil {
+		return err
+	}
+	c.Session().Delete(sessionTwoFactorEnrollKey)
+
+	logAuditEvent(tx, c, &user.ID, "enable_two_factor", "user", &user.ID, fmt.Sprintf("Two-factor authentication enabled: %s", user.Email))
+
+	c.Set("user", user)
+	c.Set("recoveryCodes", codes)
+	c.Flash().Add("success", "Two-factor authentication is enabled!")
+	return c.Render(http.StatusOK, r.HTML("profile/recovery_codes.plush.html"))
+}
+
+// TwoFactorRecoveryCodesCreate replaces the recovery codes after checking a current authenticator code.
+func (a *MyApp) TwoFactorRecoveryCodesCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	user := c.Value("current_user").(models.User)
+	repoManager := a.Repository(tx)
+
+	if !user.TOTPEnabled {
+		return c.Redirect(http.StatusSeeOther, "/profile/two-factor")
+	}
+
+	verified, err := verifyTOTPCode(tx, &user, strings.TrimSpace(c.Param("code")))
+	if err != nil {
+		return err
+	}
+	if !verified {
+		c.Flash().Add("danger", "Invalid authentication code")
+		return c.Redirect(http.StatusSeeOther, "/profile/two-factor")
+	}
+
+	codes, err := generateRecoveryCodes(tx, repoManager, &user)
+	if err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &user.ID, "regenerate_recovery_codes", "user", &user.ID, fmt.Sprintf("Recovery codes regenerated: %s", user.Email))
+
+	c.Set("user", user)
+	c.Set("recoveryCodes", codes)
+	c.Flash().Add("success", "New recovery codes generated. Your previous codes no longer work.")
+	return c.Render(http.StatusOK, r.HTML("profile/recovery_codes.plush.html"))
+}
+
+// TwoFactorDestroy turns two-factor authentication off after checking the user's password.
+func (a *MyApp) TwoFactorDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	user := c.Value("current_user").(models.User)
+	repoManager := a.Repository(tx)
+
+	config, err := models.GetDefaultConfig(tx)
+	if err != nil {
+		return err
+	}
+	if config.TwoFactorRequired {
+		c.Flash().Add("danger", "Two-factor authentication is required by your organization and cannot be turned off")
+		return c.Redirect(http.StatusSeeOther, "/profile/two-factor")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(c.Param("password"))); err != nil {
+		c.Flash().Add("danger", "Password is incorrect")
+		return c.Redirect(http.StatusSeeOther, "/profile/two-factor")
+	}
+
+	if err := clearTwoFactor(tx, repoManager, &user); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &user.ID, "disable_two_factor", "user", &user.ID, fmt.Sprintf("Two-factor authentication disabled: %s", user.Email))
+
+	c.Flash().Add("success", "Two-factor authentication is turned off")
+	return c.Redirect(http.StatusSeeOther, "/profile")
+}
+
+// clearTwoFactor removes a user's authenticator secret and recovery codes
+func clearTwoFactor(tx *pop.Connection, repoManager repository.RepositoryInterface, user *models.User) error {
+	user.TOTPSecret = nulls.String{}
+	user.TOTPEnabled = false
+	user.TOTPLastStep = nulls.Int64{}
+	if err := tx.UpdateColumns(user, "totp_secret", "totp_enabled", "totp_last_step", "updated_at"); err != nil {
+		return err
+	}
+	return repoManager.RecoveryCodeDeleteByUserID(user.ID)
+}
@@ -0,0 +1,144 @@
+package actions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+// testTOTPCode returns the code of the test secret at the given time
+func testTOTPCode(t *testing.T, at time.Time) string {
+	t.Helper()
+	code, err := totp.GenerateCodeCustom(testTOTPSecret, at, totp.ValidateOpts{Period: twoFactorPeriod, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestMatchTOTPStep(t *testing.T) {
+	now := time.Unix(1_800_000_015, 0)
+	step := now.Unix() / twoFactorPeriod
+	period := twoFactorPeriod * time.Second
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{"current step", testTOTPCode(t, now), step, true},
+		{"with spaces", testTOTPCode(t, now)[:3] + " " + testTOTPCode(t, now)[3:], step, true},
+		{"one step behind", testTOTPCode(t, now.Add(-period)), step - 1, true},
+		{"one step ahead", testTOTPCode(t, now.Add(period)), step + 1, true},
+		{"two steps behind", testTOTPCode(t, now.Add(-2*period)), 0, false},
+		{"two steps ahead", testTOTPCode(t, now.Add(2*period)), 0, false},
+		{"too short", testTOTPCode(t, now)[:5], 0, false},
+		{"empty", "", 0, false},
+	}
+	for _, tt := range tests {
+		gotStep, gotOK := matchTOTPStep(testTOTPSecret, tt.code, now)
+		if gotOK != tt.wantOK || gotStep != tt.wantStep {
+			t.Errorf("%s: matchTOTPStep(%q) = %d, %v; want %d, %v", tt.name, tt.code, gotStep, gotOK, tt.wantStep, tt.wantOK)
+		}
+	}
+}
+
+func TestVerifyTOTPCode_RejectsReplay(t *testing.T) {
+	tx := testTx(t)
+	user := createTestUser(t, tx, models.RoleHacker)
+	user.TOTPSecret = nulls.NewString(testTOTPSecret)
+	user.TOTPEnabled = true
+	if err := tx.UpdateColumns(user, "totp_secret", "totp_enabled"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	previous := testTOTPCode(t, now.Add(-twoFactorPeriod*time.Second))
+	current := testTOTPCode(t, now)
+	if previous == current {
+		t.Skip("the codes of two steps happen to be the same")
+	}
+
+	ok, err := verifyTOTPCode(tx, user, current)
+	if err != nil || !ok {
+		t.Fatalf("the current code was rejected: %v", err)
+	}
+
+	stored := &models.User{}
+	if err := tx.Find(stored, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if !stored.TOTPLastStep.Valid || stored.TOTPLastStep.Int64 < now.Unix()/twoFactorPeriod {
+		t.Errorf("totp_last_step = %v, want the current step %d", stored.TOTPLastStep, now.Unix()/twoFactorPeriod)
+	}
+
+	// Neither the same code nor one of an earlier step signs in again
+	for _, code := range []string{current, previous} {
+		if ok, err := verifyTOTPCode(tx, stored, code); err != nil || ok {
+			t.Errorf("replayed code %s was accepted: %v", code, err)
+		}
+	}
+
+	stored.TOTPSecret = nulls.String{}
+	if ok, _ := verifyTOTPCode(tx, stored, testTOTPCode(t, now.Add(twoFactorPeriod*time.Second))); ok {
+		t.Error("a code was accepted for a user without a secret")
+	}
+}
+
+func TestRedeemRecoveryCode_SingleUse(t *testing.T) {
+	tx := testTx(t)
+	repoManager := repository.NewRepositoryManager(tx)
+	user := createTestUser(t, tx, models.RoleHacker)
+
+	codes, err := generateRecoveryCodes(tx, repoManager, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("got %d recovery codes, want %d", len(codes), recoveryCodeCount)
+	}
+
+	if ok, err := redeemRecoveryCode(tx, nil, repoManager, user, codes[0]); err != nil || !ok {
+		t.Fatalf("recovery code %s was rejected: %v", codes[0], err)
+	}
+	if ok, err := redeemRecoveryCode(tx, nil, repoManager, user, codes[0]); err != nil || ok {
+		t.Errorf("recovery code %s was accepted twice: %v", codes[0], err)
+	}
+
+	// Codes are matched without dashes or case
+	typed := strings.ToUpper(strings.ReplaceAll(codes[1], "-", ""))
+	if ok, err := redeemRecoveryCode(tx, nil, repoManager, user, typed); err != nil || !ok {
+		t.Errorf("recovery code %s typed as %s was rejected: %v", codes[1], typed, err)
+	}
+
+	other := createTestUser(t, tx, models.RoleHacker)
+	if ok, _ := redeemRecoveryCode(tx, nil, repoManager, other, codes[2]); ok {
+		t.Error("a recovery code of another user was accepted")
+	}
+
+	unused, err := repoManager.RecoveryCodeCountUnusedByUserID(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unused != recoveryCodeCount-2 {
+		t.Errorf("%d recovery codes are left, want %d", unused, recoveryCodeCount-2)
+	}
+
+	// Generating new codes replaces the old ones
+	if _, err := generateRecoveryCodes(tx, repoManager, user); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := redeemRecoveryCode(tx, nil, repoManager, user, codes[3]); ok {
+		t.Error("a replaced recovery code was accepted")
+	}
+}
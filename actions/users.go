@@ -3,16 +3,25 @@ package actions
 import (
 	"fmt"
 	"net/http"
-	"strings"
+	"time"
 
 	"github.com/arxdsilva/hackathon/models"
 
 	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
 )
 
 // UsersNew renders the sign-up form.
 func (a *MyApp) UsersNew(c buffalo.Context) error {
+	// Accounts are created on first single sign-on when password sign-in is turned off
+	if _, signedIn := c.Value("current_user").(models.User); !signedIn {
+		if redirected, err := ssoOnly(c, c.Value("tx").(*pop.Connection)); redirected || err != nil {
+			return err
+		}
+	}
+
 	c.Set("user", models.User{})
 	return c.Render(http.StatusOK, r.HTML("users/new.plush.html"))
 }
@@ -20,6 +29,11 @@ func (a *MyApp) UsersNew(c buffalo.Context) error {
 // UsersCreate handles user registration.
 func (a *MyApp) UsersCreate(c buffalo.Context) error {
 	tx := c.Value("tx").(*pop.Connection)
+	if _, signedIn := c.Value("current_user").(models.User); !signedIn {
+		if redirected, err := ssoOnly(c, tx); redirected || err != nil {
+			return err
+		}
+	}
 
 	u := &models.User{}
 	if err := c.Bind(u); err != nil {
@@ -27,28 +41,89 @@ func (a *MyApp) UsersCreate(c buffalo.Context) error {
 		return c.Redirect(http.StatusFound, "/users/new")
 	}
 
-	// Validate email domain is allowed
-	if strings.Contains(u.Email, "@") {
-		parts := strings.Split(u.Email, "@")
-		if len(parts) == 2 {
-			domain := strings.ToLower(strings.TrimSpace(parts[1]))
-			repoManager := a.Repository(tx)
-			allowed, err := repoManager.CompanyAllowedDomainIsDomainAllowed(domain)
-			if err != nil {
-				c.Flash().Add("danger", "Could not validate email domain")
-				return c.Redirect(http.StatusFound, "/users/new")
-			}
-			if !allowed {
-				c.Flash().Add("danger", "Email domain is not allowed for registration")
-				return c.Redirect(http.StatusFound, "/users/new")
-			}
+	// Accounts created by an administrator don't depend on public registration being open
+	currentUser, signedIn := c.Value("current_user").(models.User)
+	createdByOwner := signedIn && currentUser.IsOwner()
+
+	// Invitation links open registration to whoever holds them, with a preset role and team
+	retryPath := "/users/new"
+	var invitation *models.Invitation
+	var invitedTo *models.Hackathon
+	if token := c.Param("invitation_token"); token != "" && !signedIn {
+		var err error
+		invitation, invitedTo, err = a.usableInvitation(tx, token)
+		if err != nil {
+			c.Flash().Add("danger", "This invitation link is invalid, expired or has already been used. Ask for a new one.")
+			return c.Redirect(http.StatusFound, "/signin")
+		}
+		setInvitation(c, token, invitation, invitedTo)
+		retryPath = "/invitations/" + token
+		if invitation.CompanyTeam != "" {
+			u.CompanyTeam = invitation.CompanyTeam
+		}
+	}
+
+	// The first account sets the installation up, before anyone could have added domain rules
+	users, err := a.Repository(tx).UserCount()
+	if err != nil {
+		return err
+	}
+
+	// The role is never bound from the form: the first account owns the installation,
+	// invitations and owners choose one and everyone else registers as a hacker
+	switch {
+	case users == 0:
+		u.Role = models.RoleOwner
+	case invitation != nil:
+		u.Role = invitation.Role
+	case createdByOwner && models.IsValidRole(c.Param("Role")):
+		u.Role = c.Param("Role")
+	default:
+		u.Role = models.RoleHacker
+	}
+
+	// Addresses without a domain are reported by the user validations instead
+	if domain := models.EmailDomain(u.Email); domain != "" && users > 0 {
+		config, err := models.GetDefaultConfig(tx)
+		if err != nil {
+			return err
+		}
+		check, err := a.Repository(tx).CompanyAllowedDomainCheckDomain(domain, config.AllowPublicRegistration || createdByOwner || invitation != nil)
+		if err != nil {
+			c.Flash().Add("danger", "Could not validate email domain")
+			return c.Redirect(http.StatusFound, retryPath)
+		}
+		if !check.Allowed {
+			verrs := validate.NewErrors()
+			verrs.Add("email", check.Reason)
+			c.Set("errors", verrs)
+			c.Set("user", u)
+			return c.Render(http.StatusUnprocessableEntity, r.HTML("users/new.plush.html"))
+		}
+	}
+
+	// Accounts created by an administrator don't need to confirm their address
+	if createdByOwner {
+		u.EmailVerifiedAt = nulls.NewTime(time.Now())
+	}
+
+	// Counting the use first holds the invitation's row until this request finishes, so
+	// concurrent sign-ups can't go over its limit. Failed validations roll the use back.
+	if invitation != nil {
+		redeemed, err := a.Repository(tx).InvitationRedeem(invitation.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !redeemed {
+			c.Flash().Add("danger", "This invitation link is invalid, expired or has already been used. Ask for a new one.")
+			return c.Redirect(http.StatusFound, "/signin")
 		}
 	}
 
 	verrs, err := u.Create(tx)
 	if err != nil {
 		c.Flash().Add("danger", "Could not create user")
-		return c.Redirect(http.StatusFound, "/users/new")
+		return c.Redirect(http.StatusFound, retryPath)
 	}
 
 	if verrs.HasAny() {
@@ -58,12 +133,37 @@ func (a *MyApp) UsersCreate(c buffalo.Context) error {
 	}
 
 	// Log the user registration
-	logAuditEvent(tx, c, &u.ID, "register", "user", &u.ID, fmt.Sprintf("User registered: %s (%s)", u.Name, u.Email))
+	if invitation != nil {
+		logAuditEvent(tx, c, &u.ID, "register", "user", &u.ID, fmt.Sprintf("User registered with invitation %s as %s: %s (%s)", invitation.ID, u.Role, u.Name, u.Email))
+	} else {
+		logAuditEvent(tx, c, &u.ID, "register", "user", &u.ID, fmt.Sprintf("User registered: %s (%s)", u.Name, u.Email))
+	}
 
 	// Only set session if no user is currently logged in (prevents admin session switching)
 	if _, ok := c.Session().Get(sessionCurrentUserID).(string); !ok {
-		c.Session().Set(sessionCurrentUserID, u.ID.String())
+		config, err := models.GetDefaultConfig(tx)
+		if err != nil {
+			return err
+		}
+
+		if err := startSession(c, tx, u); err != nil {
+			return err
+		}
+		if config.RequireEmailVerification && !u.IsEmailVerified() {
+			if err := a.sendEmailVerification(*u); err != nil {
+				c.Logger().Errorf("Failed to send verification email to %s: %v", u.Email, err)
+				c.Flash().Add("warning", "Account created, but we couldn't send the verification email. Please request a new one.")
+				return c.Redirect(http.StatusFound, "/verify-email")
+			}
+			c.Session().Set(sessionVerificationSentAt, time.Now().Unix())
+			c.Flash().Add("success", fmt.Sprintf("Account created! We sent a verification link to %s.", u.Email))
+			return c.Redirect(http.StatusFound, "/verify-email")
+		}
+
 		c.Flash().Add("success", "Account created! Welcome")
+		if invitedTo != nil {
+			return c.Redirect(http.StatusFound, "/hackathons/"+invitedTo.ID)
+		}
 		return c.Redirect(http.StatusFound, "/")
 	}
 
@@ -97,7 +197,7 @@ func (a *MyApp) UsersUpdate(c buffalo.Context) error {
 	}
 
 	newRole := c.Request().FormValue("role")
-	if newRole != models.RoleOwner && newRole != models.RoleHacker {
+	if !models.IsValidRole(newRole) {
 		c.Flash().Add("danger", "Invalid role")
 		return c.Redirect(http.StatusFound, "/admin")
 	}
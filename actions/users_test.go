@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestUsersCreate_Role(t *testing.T) {
+	db := requireTestDB(t)
+	domain := fmt.Sprintf("users-%s.example.com", uuid.Must(uuid.NewV4()).String()[:8])
+	createTestDomainRule(t, db, domain, false)
+	setTestConfig(t, db, func(config *models.CompanyConfiguration) {
+		config.AllowPublicRegistration = true
+		config.RequireEmailVerification = false
+	})
+	t.Cleanup(func() {
+		if err := db.RawQuery("DELETE FROM users WHERE email LIKE ?", "%@"+domain).Exec(); err != nil {
+			t.Error(err)
+		}
+	})
+	owner := createTestUser(t, db, models.RoleOwner)
+
+	register := func(b *testBrowser, name, role string) *models.User {
+		t.Helper()
+		email := name + "@" + domain
+		res := b.post("/users", url.Values{
+			"Name":                 {name},
+			"Email":                {email},
+			"Password":             {"Test-Passw0rd!"},
+			"PasswordConfirmation": {"Test-Passw0rd!"},
+			"Role":                 {role},
+		})
+		if res.Code != http.StatusFound {
+			t.Fatalf("registering %s answered %d: %s", email, res.Code, res.Body)
+		}
+		user := &models.User{}
+		if err := db.Where("email = ?", email).First(user); err != nil {
+			t.Fatal(err)
+		}
+		return user
+	}
+
+	if user := register(newTestBrowser(t), "mallory", models.RoleOwner); user.Role != models.RoleHacker {
+		t.Errorf("self-registration asking for %s got %s, want %s", models.RoleOwner, user.Role, models.RoleHacker)
+	}
+
+	admin := newTestBrowser(t)
+	admin.signIn(owner)
+	if user := register(admin, "judy", models.RoleJudge); user.Role != models.RoleJudge {
+		t.Errorf("an owner creating a %s got %s", models.RoleJudge, user.Role)
+	}
+	if user := register(admin, "nobody", "superuser"); user.Role != models.RoleHacker {
+		t.Errorf("an owner creating a superuser got %s, want %s", user.Role, models.RoleHacker)
+	}
+}
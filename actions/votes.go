@@ -0,0 +1,181 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// loadVoteTally returns the vote count of every voted project of a hackathon and the total number of votes
+func loadVoteTally(repoManager repository.RepositoryInterface, hackathonID string) (map[string]int, int, error) {
+	tallies, err := repoManager.VoteTallyByHackathonID(hackathonID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	voteCounts := make(map[string]int)
+	totalVotes := 0
+	for _, tally := range *tallies {
+		voteCounts[tally.ProjectID] = tally.Votes
+		totalVotes += tally.Votes
+	}
+	return voteCounts, totalVotes, nil
+}
+
+// sortProjectsByVotes returns a copy of the projects ordered by vote count, most voted first
+func sortProjectsByVotes(projects models.Projects, voteCounts map[string]int) models.Projects {
+	sorted := make(models.Projects, len(projects))
+	copy(sorted, projects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return voteCounts[sorted[i].ID] > voteCounts[sorted[j].ID]
+	})
+	return sorted
+}
+
+// VotesCreate casts the current user's people's-choice vote for a presenting project
+func (a *MyApp) VotesCreate(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	project, err := repoManager.ProjectFindByID(c.Param("project_id"))
+	if err != nil || project.HackathonID != hackathon.ID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("project not found"))
+	}
+
+	if !hackathon.VotingOpen {
+		c.Flash().Add("warning", "Voting is not open for this hackathon.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+	}
+
+	if !project.Presenting {
+		c.Flash().Add("warning", "Only presenting projects can receive votes.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+	}
+
+	isMember, err := repoManager.ProjectMembershipIsUserMember(project.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	if isMember || (project.UserID != nil && *project.UserID == currentUser.ID) {
+		c.Flash().Add("warning", "You cannot vote for your own project.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+	}
+
+	if _, err := repoManager.VoteFindByProjectIDAndUserID(project.ID, currentUser.ID); err == nil {
+		c.Flash().Add("info", "You already voted for this project.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+	}
+
+	// Locking the voter makes their concurrent votes wait for each other, so they can't all pass
+	// the limit check on the same count. Locking their vote rows wouldn't cover a first vote.
+	if _, err := repoManager.UserLockByID(currentUser.ID); err != nil {
+		return err
+	}
+	votesCast, err := repoManager.VoteCountByHackathonIDAndUserID(hackathon.ID, currentUser.ID)
+	if err != nil {
+		return err
+	}
+	if votesCast >= hackathon.VotesPerUser {
+		c.Flash().Add("warning", fmt.Sprintf("You have used all %d of your votes.", hackathon.VotesPerUser))
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+	}
+
+	vote := &models.Vote{
+		HackathonID: hackathon.ID,
+		ProjectID:   project.ID,
+		UserID:      currentUser.ID,
+	}
+	verrs, err := tx.ValidateAndCreate(vote)
+	if err != nil {
+		if isDuplicateError(err) {
+			c.Flash().Add("info", "You already voted for this project.")
+			return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+		}
+		return err
+	}
+	if verrs.HasAny() {
+		c.Flash().Add("danger", "Could not record your vote.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "vote", "project", project.ID, fmt.Sprintf("Voted for project: %s", project.Name))
+
+	c.Flash().Add("success", fmt.Sprintf("Vote cast for %s!", project.Name))
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+}
+
+// VotesDestroy withdraws the current user's vote for a project while voting is open
+func (a *MyApp) VotesDestroy(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+	currentUser := c.Value("current_user").(models.User)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	if !hackathon.VotingOpen {
+		c.Flash().Add("warning", "Voting is closed; votes can no longer be changed.")
+		return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+	}
+
+	vote, err := repoManager.VoteFindByProjectIDAndUserID(c.Param("project_id"), currentUser.ID)
+	if err != nil || vote.HackathonID != hackathon.ID {
+		return c.Error(http.StatusNotFound, fmt.Errorf("vote not found"))
+	}
+
+	if err := tx.Destroy(vote); err != nil {
+		return err
+	}
+
+	logAuditEvent(tx, c, &currentUser.ID, "unvote", "project", vote.ProjectID, "Vote withdrawn")
+
+	c.Flash().Add("success", "Vote withdrawn.")
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+}
+
+// VotingToggle opens or closes people's-choice voting of a hackathon (owner-only)
+func (a *MyApp) VotingToggle(c buffalo.Context) error {
+	tx := c.Value("tx").(*pop.Connection)
+	repoManager := a.Repository(tx)
+
+	hackathon, err := repoManager.HackathonFindByID(c.Param("hackathon_id"))
+	if err != nil {
+		return c.Error(http.StatusNotFound, err)
+	}
+
+	hackathon.VotingOpen = !hackathon.VotingOpen
+	if !hackathon.VotingOpen {
+		hackathon.VotingClosedAt = nulls.NewTime(time.Now())
+	}
+	if err := tx.Update(hackathon); err != nil {
+		return err
+	}
+
+	action := "open_voting"
+	message := "People's-choice voting is now open!"
+	if !hackathon.VotingOpen {
+		action = "close_voting"
+		message = "People's-choice voting closed."
+	}
+	currentUser := c.Value("current_user").(models.User)
+	logAuditEvent(tx, c, &currentUser.ID, action, "hackathon", hackathon.ID, fmt.Sprintf("People's-choice voting toggled: %s", hackathon.Title))
+
+	c.Flash().Add("success", message)
+	return c.Redirect(http.StatusSeeOther, "/hackathons/%s", hackathon.ID)
+}
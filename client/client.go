@@ -0,0 +1,361 @@
+// Package client calls the platform's JSON API from Go programs, like bots that post hackathon
+// updates. It authenticates with an API token, decodes responses into the models structs,
+// retries requests the server failed with a 5xx and pages through lists with iterators:
+//
+//	c := client.New("https://hackathon.example.com", os.Getenv("HACKATHON_TOKEN"))
+//	for hackathon, err := range c.ListHackathons(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(hackathon.Title)
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+)
+
+const (
+	basePath = "/api/v1"
+
+	// pageSize is the largest page the API returns
+	pageSize = 100
+)
+
+// Client calls the API of one platform with one token. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	retries    int
+	retryWait  time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with the given client instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times a failed request is retried and how long to wait before
+// the first retry. The wait doubles with every retry. 0 retries turns retrying off.
+func WithRetries(retries int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryWait = wait
+	}
+}
+
+// New returns a client for the platform at baseURL, like https://hackathon.example.com,
+// that authenticates with an API token from the profile page or a service account
+func New(baseURL, token string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), basePath) + basePath,
+		token:      token,
+		httpClient: http.DefaultClient,
+		retries:    3,
+		retryWait:  500 * time.Millisecond,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Error is a request the API refused. Code is a stable identifier like not_found,
+// insufficient_scope or submissions_closed, Details has the invalid fields of validation_failed.
+type Error struct {
+	StatusCode int                 `json:"status"`
+	Code       string              `json:"code"`
+	Message    string              `json:"message"`
+	Details    map[string][]string `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("hackathon api: %s (%d %s)", e.Message, e.StatusCode, e.Code)
+}
+
+// IsNotFound returns true when err is an API error for something that doesn't exist or isn't visible to the token
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// envelope is the body of successful responses
+type envelope[T any] struct {
+	Data       T      `json:"data"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// request is an API call, body is sent as is so it can be sent again on retries
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+}
+
+// do sends a request and decodes the response into out, which can be nil. Requests that
+// fail with a 5xx are retried: the server rolls back everything a failed request did, so
+// this is safe for every method. Requests that failed to get a response at all are only
+// retried when they don't change anything, since the server may have handled them.
+func (c *Client) do(ctx context.Context, r request, out interface{}) error {
+	target := c.baseURL + r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, r.method, target, bytes.NewReader(r.body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Accept", "application/json")
+		if r.contentType != "" {
+			req.Header.Set("Content-Type", r.contentType)
+		}
+
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			if attempt < c.retries && ctx.Err() == nil && (r.method == http.MethodGet || r.method == http.MethodDelete) {
+				if err := c.wait(ctx, attempt, nil); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+		if res.StatusCode >= http.StatusInternalServerError && attempt < c.retries {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			if err := c.wait(ctx, attempt, res); err != nil {
+				return err
+			}
+			continue
+		}
+		return decodeResponse(res, out)
+	}
+}
+
+// wait sleeps before the retry after the given attempt, or as long as a Retry-After header asks
+func (c *Client) wait(ctx context.Context, attempt int, res *http.Response) error {
+	wait := c.retryWait << attempt
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			wait = time.Duration(seconds) * time.Second
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// decodeResponse reads a response into out, or returns the error it carries
+func decodeResponse(res *http.Response, out interface{}) error {
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var problem struct {
+			Error *Error `json:"error"`
+		}
+		if err := json.Unmarshal(body, &problem); err != nil || problem.Error == nil {
+			return &Error{StatusCode: res.StatusCode, Code: "unexpected_response", Message: http.StatusText(res.StatusCode)}
+		}
+		problem.Error.StatusCode = res.StatusCode
+		return problem.Error
+	}
+
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("hackathon api: decoding the response of %s %s: %w", res.Request.Method, res.Request.URL.Path, err)
+	}
+	return nil
+}
+
+// get returns the data of a single resource
+func get[T any](ctx context.Context, c *Client, r request) (*T, error) {
+	var res envelope[T]
+	if err := c.do(ctx, r, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// list pages through a list, a page at a time as the loop asks for more. It stops after the first error.
+func list[T any](ctx context.Context, c *Client, path string, query url.Values) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		q := url.Values{}
+		for key, values := range query {
+			q[key] = values
+		}
+		q.Set("limit", strconv.Itoa(pageSize))
+
+		for {
+			var page envelope[[]T]
+			if err := c.do(ctx, request{method: http.MethodGet, path: path, query: q}, &page); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.NextCursor == "" {
+				return
+			}
+			q.Set("cursor", page.NextCursor)
+		}
+	}
+}
+
+// jsonRequest returns a request with v as its JSON body
+func jsonRequest(method, path string, v interface{}) (request, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return request{}, err
+	}
+	return request{method: method, path: path, body: body, contentType: "application/json"}, nil
+}
+
+// Me returns the account of the token
+func (c *Client) Me(ctx context.Context) (*models.User, error) {
+	return get[models.User](ctx, c, request{method: http.MethodGet, path: "/me"})
+}
+
+// ListHackathons iterates over the hackathons the token's account can see, oldest first
+func (c *Client) ListHackathons(ctx context.Context) iter.Seq2[models.Hackathon, error] {
+	return list[models.Hackathon](ctx, c, "/hackathons", nil)
+}
+
+// GetHackathon returns a hackathon
+func (c *Client) GetHackathon(ctx context.Context, hackathonID string) (*models.Hackathon, error) {
+	return get[models.Hackathon](ctx, c, request{method: http.MethodGet, path: "/hackathons/" + url.PathEscape(hackathonID)})
+}
+
+// ListProjects iterates over the projects of a hackathon
+func (c *Client) ListProjects(ctx context.Context, hackathonID string) iter.Seq2[models.Project, error] {
+	return list[models.Project](ctx, c, "/hackathons/"+url.PathEscape(hackathonID)+"/projects", nil)
+}
+
+// GetProject returns a project
+func (c *Client) GetProject(ctx context.Context, projectID string) (*models.Project, error) {
+	return get[models.Project](ctx, c, request{method: http.MethodGet, path: "/projects/" + url.PathEscape(projectID)})
+}
+
+// CreateProject submits a project to a hackathon with the token's account as its owner.
+// Only the name, description, repository and demo URLs and status of the project are sent.
+// The token needs the projects:write scope.
+func (c *Client) CreateProject(ctx context.Context, hackathonID string, project models.Project) (*models.Project, error) {
+	fields := map[string]string{
+		"name":           project.Name,
+		"description":    project.Description,
+		"repository_url": project.RepositoryURL,
+		"demo_url":       project.DemoURL,
+	}
+	if project.Status != "" {
+		fields["status"] = project.Status
+	}
+	r, err := jsonRequest(http.MethodPost, "/hackathons/"+url.PathEscape(hackathonID)+"/projects", fields)
+	if err != nil {
+		return nil, err
+	}
+	return get[models.Project](ctx, c, r)
+}
+
+// ListProjectMembers iterates over the members of a project
+func (c *Client) ListProjectMembers(ctx context.Context, projectID string) iter.Seq2[models.ProjectMembership, error] {
+	return list[models.ProjectMembership](ctx, c, "/projects/"+url.PathEscape(projectID)+"/members", nil)
+}
+
+// JoinProject adds the token's account to a project of a hackathon with open teams.
+// The token needs the projects:write scope.
+func (c *Client) JoinProject(ctx context.Context, projectID string) (*models.ProjectMembership, error) {
+	return get[models.ProjectMembership](ctx, c, request{method: http.MethodPost, path: "/projects/" + url.PathEscape(projectID) + "/members"})
+}
+
+// ListFiles iterates over file metadata, of one hackathon or project when their IDs aren't empty.
+// The content of files isn't included.
+func (c *Client) ListFiles(ctx context.Context, hackathonID, projectID string) iter.Seq2[models.File, error] {
+	query := url.Values{}
+	if hackathonID != "" {
+		query.Set("hackathon_id", hackathonID)
+	}
+	if projectID != "" {
+		query.Set("project_id", projectID)
+	}
+	return list[models.File](ctx, c, "/files", query)
+}
+
+// UploadFile uploads the Data of a file named Filename, to the project or hackathon of its
+// ProjectID or HackathonID when set. Files can be at most 10MB. It returns the file's
+// metadata without the content. The token needs the projects:write scope.
+func (c *Client) UploadFile(ctx context.Context, file models.File) (*models.File, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "file", "filename": file.Filename}))
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(file.Data); err != nil {
+		return nil, err
+	}
+	if file.HackathonID != nil {
+		if err := form.WriteField("hackathon_id", *file.HackathonID); err != nil {
+			return nil, err
+		}
+	}
+	if file.ProjectID != nil {
+		if err := form.WriteField("project_id", *file.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	return get[models.File](ctx, c, request{method: http.MethodPost, path: "/files", body: body.Bytes(), contentType: form.FormDataContentType()})
+}
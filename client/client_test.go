@@ -0,0 +1,526 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arxdsilva/hackathon/actions"
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+)
+
+var (
+	setupTestDB  sync.Once
+	errNoTestDB  error
+	errMigration error
+)
+
+// testDB points the app at the migrated test database. The test is skipped when the
+// database isn't reachable.
+func testDB(t *testing.T) *pop.Connection {
+	t.Helper()
+	setupTestDB.Do(func() {
+		db, err := pop.Connect("test")
+		if err != nil {
+			errMigration = err
+			return
+		}
+		if err := db.RawQuery("SELECT 1").Exec(); err != nil {
+			errNoTestDB = err
+			return
+		}
+		migrator, err := pop.NewFileMigrator("../migrations", db)
+		if err != nil {
+			errMigration = err
+			return
+		}
+		if errMigration = migrator.Up(); errMigration != nil {
+			return
+		}
+
+		// The app runs every request in a transaction on models.DB, which follows GO_ENV
+		pop.Debug = false
+		models.DB = db
+	})
+	if errNoTestDB != nil {
+		t.Skipf("test database unavailable: %v", errNoTestDB)
+	}
+	if errMigration != nil {
+		t.Fatal(errMigration)
+	}
+	return models.DB
+}
+
+// testServer serves the app, through wrap when it isn't nil, and returns its URL
+func testServer(t *testing.T, wrap func(http.Handler) http.Handler) string {
+	t.Helper()
+	var handler http.Handler = actions.App()
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// createUser creates a hacker with an API token of the given scopes. Deleting the user
+// when the test ends also deletes its token, hackathons, projects and files.
+func createUser(t *testing.T, db *pop.Connection, scopes string) (*models.User, string) {
+	t.Helper()
+	password := "Client-Test-Passw0rd!"
+	user := &models.User{
+		Email:                fmt.Sprintf("client-test-%s@example.com", uuid.Must(uuid.NewV4())),
+		Name:                 "Client Test",
+		Role:                 models.RoleHacker,
+		Password:             password,
+		PasswordConfirmation: password,
+		EmailVerifiedAt:      nulls.NewTime(time.Now()),
+	}
+	verrs, err := user.Create(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+	t.Cleanup(func() {
+		if err := db.RawQuery("DELETE FROM users WHERE id = ?", user.ID).Exec(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	token := uuid.Must(uuid.NewV4()).String()
+	verrs, err = db.ValidateAndCreate(&models.APIToken{
+		UserID:    user.ID,
+		Name:      "client test",
+		Scopes:    scopes,
+		TokenHash: models.HashAPIToken(token),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+	return user, token
+}
+
+// createHackathon creates an active hackathon with open teams that takes submissions for a day
+func createHackathon(t *testing.T, db *pop.Connection, owner *models.User, title string) *models.Hackathon {
+	t.Helper()
+	hackathon := &models.Hackathon{
+		Title:        title,
+		Description:  "Created by the client tests",
+		StartDate:    time.Now().Add(-time.Hour),
+		EndDate:      time.Now().Add(24 * time.Hour),
+		Status:       models.HackathonStatusActive,
+		OwnerID:      owner.ID,
+		VotesPerUser: models.DefaultVotesPerUser,
+		OpenTeams:    true,
+		MaxTeamSize:  nulls.NewInt(5),
+	}
+	verrs, err := db.ValidateAndCreate(hackathon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verrs.HasAny() {
+		t.Fatal(verrs)
+	}
+	return hackathon
+}
+
+// flaky answers the first failures requests with status instead of passing them on
+type flaky struct {
+	next     http.Handler
+	status   int
+	failures int32
+	requests atomic.Int32
+}
+
+func (f *flaky) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.requests.Add(1) <= f.failures {
+		http.Error(w, http.StatusText(f.status), f.status)
+		return
+	}
+	f.next.ServeHTTP(w, r)
+}
+
+func TestListHackathons_Pages(t *testing.T) {
+	db := testDB(t)
+	owner, token := createUser(t, db, models.APIScopeRead)
+
+	created := map[string]bool{}
+	for i := 0; i < 2*pageSize+1; i++ {
+		hackathon := createHackathon(t, db, owner, fmt.Sprintf("Client test hackathon %d", i))
+		created[hackathon.ID] = true
+	}
+
+	var pages atomic.Int32
+	url := testServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == basePath+"/hackathons" {
+				pages.Add(1)
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	c := New(url, token)
+
+	seen := map[string]bool{}
+	var previous time.Time
+	for hackathon, err := range c.ListHackathons(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hackathon.CreatedAt.Before(previous) {
+			t.Errorf("hackathon %s created at %s is listed after one created at %s", hackathon.ID, hackathon.CreatedAt, previous)
+		}
+		previous = hackathon.CreatedAt
+		if seen[hackathon.ID] {
+			t.Errorf("hackathon %s is listed twice", hackathon.ID)
+		}
+		seen[hackathon.ID] = true
+	}
+	for id := range created {
+		if !seen[id] {
+			t.Errorf("hackathon %s is missing from the list", id)
+		}
+	}
+	if pages.Load() < 3 {
+		t.Errorf("listed %d hackathons in %d pages, want at least 3 pages", len(created), pages.Load())
+	}
+
+	// Stopping the loop early doesn't fetch more pages
+	pages.Store(0)
+	for _, err := range c.ListHackathons(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		break
+	}
+	if pages.Load() != 1 {
+		t.Errorf("fetched %d pages for the first hackathon, want 1", pages.Load())
+	}
+}
+
+func TestCreateAndJoinProject(t *testing.T) {
+	db := testDB(t)
+	organizer, _ := createUser(t, db, models.APIScopeRead)
+	founder, founderToken := createUser(t, db, models.APIScopeProjectsWrite)
+	joiner, joinerToken := createUser(t, db, models.APIScopeProjectsWrite)
+	_, readToken := createUser(t, db, models.APIScopeRead)
+	hackathon := createHackathon(t, db, organizer, "Client test projects")
+	url := testServer(t, nil)
+	ctx := context.Background()
+
+	project, err := New(url, founderToken).CreateProject(ctx, hackathon.ID, models.Project{
+		Name:          "Client test project",
+		Description:   "Created through the Go client",
+		RepositoryURL: "https://example.com/repo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.ID == "" || project.HackathonID != hackathon.ID || project.UserID == nil || *project.UserID != founder.ID {
+		t.Fatalf("CreateProject returned %+v, want a project of hackathon %s owned by %s", project, hackathon.ID, founder.ID)
+	}
+	// Platforms that hold submissions for review only let the team see the project until it's approved
+	if err := db.RawQuery("UPDATE projects SET review_status = ? WHERE id = ?", models.ProjectReviewApproved, project.ID).Exec(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := New(url, joinerToken).GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != project.Name || got.RepositoryURL != "https://example.com/repo" {
+		t.Errorf("GetProject returned %+v, want the created project", got)
+	}
+
+	membership, err := New(url, joinerToken).JoinProject(ctx, project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if membership.ProjectID != project.ID || membership.UserID != joiner.ID {
+		t.Errorf("JoinProject returned %+v, want %s in project %s", membership, joiner.ID, project.ID)
+	}
+
+	members := map[uuid.UUID]bool{}
+	for member, err := range New(url, readToken).ListProjectMembers(ctx, project.ID) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		members[member.UserID] = true
+	}
+	if len(members) != 2 || !members[founder.ID] || !members[joiner.ID] {
+		t.Errorf("project members are %v, want the founder and the joiner", members)
+	}
+
+	_, err = New(url, joinerToken).JoinProject(ctx, project.ID)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict || apiErr.Code != "already_member" {
+		t.Errorf("joining twice returned %v, want a 409 already_member error", err)
+	}
+}
+
+func TestTokenAuthentication(t *testing.T) {
+	db := testDB(t)
+	user, readToken := createUser(t, db, models.APIScopeRead)
+	hackathon := createHackathon(t, db, user, "Client test tokens")
+	url := testServer(t, nil)
+	ctx := context.Background()
+
+	me, err := New(url, readToken).Me(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.ID != user.ID || me.Email != user.Email {
+		t.Errorf("Me returned %s (%s), want %s (%s)", me.ID, me.Email, user.ID, user.Email)
+	}
+
+	_, err = New(url, "not-a-token").Me(ctx)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Code != "invalid_token" {
+		t.Errorf("an unknown token returned %v, want a 401 invalid_token error", err)
+	}
+
+	_, err = New(url, readToken).CreateProject(ctx, hackathon.ID, models.Project{Name: "Read only", Description: "Not allowed"})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden || apiErr.Code != "insufficient_scope" {
+		t.Errorf("creating a project with a read token returned %v, want a 403 insufficient_scope error", err)
+	}
+
+	_, err = New(url, readToken).GetHackathon(ctx, "missing")
+	if !IsNotFound(err) {
+		t.Errorf("GetHackathon of a missing hackathon returned %v, want a not found error", err)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	db := testDB(t)
+	organizer, _ := createUser(t, db, models.APIScopeRead)
+	founder, token := createUser(t, db, models.APIScopeProjectsWrite)
+	hackathon := createHackathon(t, db, organizer, "Client test files")
+	url := testServer(t, nil)
+	c := New(url, token)
+	ctx := context.Background()
+
+	project, err := c.CreateProject(ctx, hackathon.ID, models.Project{Name: "Client test uploads", Description: "Has files"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("# Pitch\n\nUploaded through the Go client.\n")
+	file, err := c.UploadFile(ctx, models.File{
+		Filename:    "pitch.md",
+		ContentType: "text/markdown",
+		Data:        content,
+		ProjectID:   &project.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.ID == "" || file.Filename != "pitch.md" || file.Size != len(content) || file.UserID != founder.ID {
+		t.Errorf("UploadFile returned %+v, want pitch.md of %d bytes uploaded by %s", file, len(content), founder.ID)
+	}
+	if file.ProjectID == nil || *file.ProjectID != project.ID || file.HackathonID == nil || *file.HackathonID != hackathon.ID {
+		t.Errorf("UploadFile returned project %v and hackathon %v, want %s and %s", file.ProjectID, file.HackathonID, project.ID, hackathon.ID)
+	}
+	if len(file.Data) != 0 {
+		t.Errorf("UploadFile returned %d bytes of content, want only metadata", len(file.Data))
+	}
+
+	var stored models.File
+	if err := db.Find(&stored, file.ID); err != nil {
+		t.Fatal(err)
+	}
+	if string(stored.Data) != string(content) {
+		t.Errorf("stored content is %q, want %q", stored.Data, content)
+	}
+
+	listed := 0
+	for f, err := range c.ListFiles(ctx, "", project.ID) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.ID != file.ID {
+			t.Errorf("ListFiles of project %s returned file %s of project %v", project.ID, f.ID, f.ProjectID)
+		}
+		listed++
+	}
+	if listed != 1 {
+		t.Errorf("ListFiles of project %s returned %d files, want 1", project.ID, listed)
+	}
+}
+
+// stubToken is the only token stubAPI accepts
+const stubToken = "stub-token"
+
+// stubAPI answers like the API for the account of stubToken, without a database. It only
+// knows /me, the hackathon list and project submissions, and counts the projects it created.
+type stubAPI struct {
+	userID  uuid.UUID
+	created atomic.Int32
+}
+
+func (s *stubAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Header.Get("Authorization") != "Bearer "+stubToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"status": 401, "code": "invalid_token", "message": "The bearer token is invalid, expired or has been revoked"}}`)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/me":
+		json.NewEncoder(w).Encode(envelope[models.User]{Data: models.User{ID: s.userID, Name: "Stub"}})
+	case r.Method == http.MethodGet && r.URL.Path == "/api/v1/hackathons":
+		fmt.Fprint(w, `{"data": []}`)
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/projects"):
+		var fields map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"status": 400, "code": "invalid_json", "message": "The body must be a JSON object"}}`)
+			return
+		}
+		n := s.created.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(envelope[models.Project]{Data: models.Project{ID: fmt.Sprintf("stub-%d", n), Name: fields["name"], UserID: &s.userID}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": {"status": 404, "code": "not_found", "message": "Not found"}}`)
+	}
+}
+
+// stubServer serves a stubAPI behind server and returns its URL
+func stubServer(t *testing.T, server *flaky) (string, *stubAPI) {
+	t.Helper()
+	api := &stubAPI{userID: uuid.Must(uuid.NewV4())}
+	server.next = api
+	s := httptest.NewServer(server)
+	t.Cleanup(s.Close)
+	return s.URL, api
+}
+
+// roundTripFunc is an http.RoundTripper made of a function
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestContextCancellation(t *testing.T) {
+	url, _ := stubServer(t, &flaky{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := New(url, stubToken)
+	if _, err := c.Me(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Me with a canceled context returned %v, want context.Canceled", err)
+	}
+	for _, err := range c.ListHackathons(ctx) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ListHackathons with a canceled context returned %v, want context.Canceled", err)
+		}
+	}
+
+	// Cancelling stops waiting for the next retry
+	server := &flaky{status: http.StatusServiceUnavailable, failures: 1 << 30}
+	url, _ = stubServer(t, server)
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := New(url, stubToken, WithRetries(5, time.Hour)).Me(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Me with a retry after the deadline returned %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Me returned %s after the deadline", elapsed)
+	}
+	if server.requests.Load() != 1 {
+		t.Errorf("sent %d requests, want 1 before the deadline", server.requests.Load())
+	}
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+	server := &flaky{status: http.StatusServiceUnavailable, failures: 2}
+	url, api := stubServer(t, server)
+
+	me, err := New(url, stubToken, WithRetries(3, time.Millisecond)).Me(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if me.ID != api.userID {
+		t.Errorf("Me returned %s, want %s", me.ID, api.userID)
+	}
+	if server.requests.Load() != 3 {
+		t.Errorf("sent %d requests, want 3", server.requests.Load())
+	}
+
+	// Changes are retried too, a failed request didn't change anything
+	server.requests.Store(0)
+	project, err := New(url, stubToken, WithRetries(3, time.Millisecond)).CreateProject(ctx, "stub", models.Project{Name: "Client test retried", Description: "Created on the third try"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.requests.Load() != 3 {
+		t.Errorf("sent %d requests, want 3", server.requests.Load())
+	}
+	if created := api.created.Load(); created != 1 || project.Name != "Client test retried" {
+		t.Errorf("created %d projects and returned %+v, want only the retried one", created, project)
+	}
+
+	// Giving up returns the last error
+	server.requests.Store(0)
+	_, err = New(url, stubToken, WithRetries(1, time.Millisecond)).Me(ctx)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Me returned %v, want a 503 error", err)
+	}
+	if server.requests.Load() != 2 {
+		t.Errorf("sent %d requests, want 2", server.requests.Load())
+	}
+
+	// Client errors aren't retried
+	server.requests.Store(server.failures)
+	_, err = New(url, "not-a-token", WithRetries(3, time.Millisecond)).Me(ctx)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Code != "invalid_token" {
+		t.Errorf("Me with an unknown token returned %v, want a 401 error", err)
+	}
+	if requests := server.requests.Load() - server.failures; requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+
+	// Requests that got no answer are only sent again when they don't change anything
+	var sent atomic.Int32
+	unreachable := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		sent.Add(1)
+		return nil, errors.New("connection refused")
+	})}
+	c := New("http://hackathon.invalid", stubToken, WithRetries(2, time.Millisecond), WithHTTPClient(unreachable))
+	if _, err := c.Me(ctx); err == nil {
+		t.Error("Me without an answer succeeded")
+	}
+	if sent.Load() != 3 {
+		t.Errorf("Me without an answer was sent %d times, want 3", sent.Load())
+	}
+	sent.Store(0)
+	if _, err := c.CreateProject(ctx, "stub", models.Project{Name: "Maybe created"}); err == nil {
+		t.Error("CreateProject without an answer succeeded")
+	}
+	if sent.Load() != 1 {
+		t.Errorf("CreateProject without an answer was sent %d times, want 1", sent.Load())
+	}
+}
@@ -0,0 +1,589 @@
+// Command hackctl does admin work from a terminal instead of the /admin pages: managing users
+// and allowed email domains, changing hackathon statuses and exporting the audit log. It reads
+// through the repository layer and connects to the database of GO_ENV from database.yml like the
+// app does, so run it from the project directory. Every command runs in one transaction and every
+// change is recorded in the audit log with cli as the actor, along with the operator given by
+// -actor, which defaults to $USER.
+//
+//	go run ./cmd/hackctl users list -role owner
+//	go run ./cmd/hackctl -actor ada users force-reset grace@example.com
+//	go run ./cmd/hackctl users promote ada@example.com
+//	go run ./cmd/hackctl domains add -block mailinator.com
+//	go run ./cmd/hackctl audit export -since 2026-01-01 -format csv > audit.csv
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/arxdsilva/hackathon/repository"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+)
+
+// auditActor is the user agent of audit log entries hackctl writes, the admin pages show it as the actor
+const auditActor = "cli"
+
+// actor is who runs hackctl, set with -actor and recorded with every change
+var actor string
+
+// command is a subcommand, run gets the arguments after its name
+type command struct {
+	usage       string
+	description string
+	run         func(repoManager *repository.RepositoryManager, args []string) error
+}
+
+var commands = map[string]command{
+	"users list":        {"users list [-role hacker|judge|owner]", "List users", usersList},
+	"users create":      {"users create -email EMAIL -name NAME [-role hacker|judge|owner] [-team TEAM]", "Create a verified user with a temporary password", usersCreate},
+	"users force-reset": {"users force-reset EMAIL", "Sign a user out and make them pick a new password", usersForceReset},
+	"users promote":     {"users promote EMAIL", "Give a user the owner role", usersPromote},
+	"domains list":      {"domains list", "List the email domain rules of registration", domainsList},
+	"domains add":       {"domains add [-block] [-description TEXT] DOMAIN", "Allow, or with -block refuse, a domain like example.com or *.example.com", domainsAdd},
+	"domains enable":    {"domains enable DOMAIN", "Turn a domain rule back on", domainsEnable},
+	"domains disable":   {"domains disable DOMAIN", "Turn a domain rule off without deleting it", domainsDisable},
+	"domains remove":    {"domains remove DOMAIN", "Delete a domain rule", domainsRemove},
+	"hackathons list":   {"hackathons list", "List hackathons with their IDs and statuses", hackathonsList},
+	"hackathons status": {"hackathons status HACKATHON_ID upcoming|active|completed|hidden", "Change the status of a hackathon", hackathonsStatus},
+	"audit export":      {"audit export [-since DATE] [-until DATE] [-action ACTION] [-format json|csv]", "Write audit log entries to stdout, dates are 2006-01-02 or RFC 3339", auditExport},
+}
+
+func main() {
+	flag.StringVar(&actor, "actor", defaultActor(), "who is running hackctl, recorded in the audit log")
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[args[0]+" "+args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		fmt.Fprintln(os.Stderr, "hackctl: -actor is empty and $USER isn't set, pass -actor NAME")
+		os.Exit(2)
+	}
+
+	// SQL logging would end up in exports
+	pop.Debug = false
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		return cmd.run(repository.NewRepositoryManager(tx), args[2:])
+	})
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "hackctl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage: hackctl [-actor NAME] COMMAND [flags] [arguments]")
+	fmt.Fprintln(os.Stderr)
+	w := tabwriter.NewWriter(os.Stderr, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "  -actor NAME\t%s (default $USER)\n\n", flag.Lookup("actor").Usage)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%s\n", commands[name].usage, commands[name].description)
+	}
+	w.Flush()
+}
+
+// parse parses the flags of a command and checks it got the expected number of arguments
+func parse(flags *flag.FlagSet, args []string, want int) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() != want {
+		return nil, fmt.Errorf("%s takes %d argument(s), got %d", flags.Name(), want, flags.NArg())
+	}
+	return flags.Args(), nil
+}
+
+// defaultActor is $USER, or the account of the process when it isn't set
+func defaultActor() string {
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+// audit records a change made with hackctl in the audit log, resourceID can be empty
+func audit(repoManager *repository.RepositoryManager, action, resourceType, resourceID, details string) error {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	auditLog := &models.AuditLog{
+		Action:       action,
+		ResourceType: resourceType,
+		Details:      fmt.Sprintf("%s (hackctl run by %s on %s)", details, actor, host),
+		UserAgent:    auditActor,
+	}
+	if resourceID != "" {
+		auditLog.ResourceID = &resourceID
+	}
+	return repoManager.AuditLogCreate(auditLog)
+}
+
+// findUser finds a user by email address
+func findUser(repoManager *repository.RepositoryManager, email string) (*models.User, error) {
+	u, err := repoManager.UserFindByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, fmt.Errorf("no user with the email %s", email)
+	}
+	return u, nil
+}
+
+// temporaryPassword returns a random password that meets any password policy
+func temporaryPassword() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b) + "Aa1!", nil
+}
+
+func usersList(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("users list", flag.ContinueOnError)
+	role := flags.String("role", "", "only list users with this role")
+	if _, err := parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	var users models.Users
+	if *role != "" {
+		found, err := repoManager.UserFindByRole(*role)
+		if err != nil {
+			return err
+		}
+		users = *found
+	} else {
+		for offset := 0; ; offset += 100 {
+			page, total, err := repoManager.UserFindPage(offset, 100)
+			if err != nil {
+				return err
+			}
+			users = append(users, *page...)
+			if len(*page) == 0 || offset+len(*page) >= total {
+				break
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEMAIL\tNAME\tROLE\tNOTES")
+	for _, u := range users {
+		var notes []string
+		if u.IsServiceAccount {
+			notes = append(notes, "service account")
+		}
+		if u.IsDeactivated() {
+			notes = append(notes, "deactivated")
+		}
+		if u.ForcePasswordReset {
+			notes = append(notes, "must reset password")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", u.ID, u.Email, u.Name, u.Role, strings.Join(notes, ", "))
+	}
+	return w.Flush()
+}
+
+func usersCreate(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("users create", flag.ContinueOnError)
+	email := flags.String("email", "", "email address of the user")
+	name := flags.String("name", "", "full name of the user")
+	role := flags.String("role", models.RoleHacker, "hacker, judge or owner")
+	team := flags.String("team", "", "company team of the user")
+	if _, err := parse(flags, args, 0); err != nil {
+		return err
+	}
+	if *email == "" || *name == "" {
+		return errors.New("users create needs -email and -name")
+	}
+	if !models.IsValidRole(*role) {
+		return fmt.Errorf("%q isn't a role, use hacker, judge or owner", *role)
+	}
+
+	password, err := temporaryPassword()
+	if err != nil {
+		return err
+	}
+	// Like users created on the admin pages the address counts as verified, and the
+	// temporary password has to be replaced at the first sign-in
+	u := &models.User{
+		Email:                *email,
+		Name:                 *name,
+		Role:                 *role,
+		CompanyTeam:          *team,
+		Password:             password,
+		PasswordConfirmation: password,
+		ForcePasswordReset:   true,
+		EmailVerifiedAt:      nulls.NewTime(time.Now()),
+	}
+	verrs, err := repoManager.UserCreate(u)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return errors.New(verrs.Error())
+	}
+
+	if err := audit(repoManager, "create", "user", u.ID.String(), fmt.Sprintf("Created user %s (%s) with role %s", u.Name, u.Email, u.Role)); err != nil {
+		return err
+	}
+	fmt.Printf("Created %s (%s) with role %s\n", u.Name, u.Email, u.Role)
+	fmt.Printf("Temporary password: %s\n", password)
+	return nil
+}
+
+func usersForceReset(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("users force-reset", flag.ContinueOnError)
+	rest, err := parse(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	u, err := findUser(repoManager, rest[0])
+	if err != nil {
+		return err
+	}
+	if err := repoManager.UserSetForcePasswordReset(u.ID, true); err != nil {
+		return err
+	}
+	// Sign the user out so the reset applies right away
+	if err := repoManager.UserSessionDeleteByUserID(u.ID); err != nil {
+		return err
+	}
+
+	if err := audit(repoManager, "force_password_reset", "user", u.ID.String(), fmt.Sprintf("Forced password reset for user %s (%s)", u.Name, u.Email)); err != nil {
+		return err
+	}
+	fmt.Printf("%s has been signed out and must pick a new password at the next sign-in\n", u.Email)
+	return nil
+}
+
+func usersPromote(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("users promote", flag.ContinueOnError)
+	rest, err := parse(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	u, err := findUser(repoManager, rest[0])
+	if err != nil {
+		return err
+	}
+	if u.IsOwner() {
+		fmt.Printf("%s is already an owner\n", u.Email)
+		return nil
+	}
+	if u.IsDeactivated() {
+		return fmt.Errorf("%s is deactivated, reactivate the account first", u.Email)
+	}
+
+	previous := u.Role
+	if err := repoManager.UserSetRole(u.ID, models.RoleOwner); err != nil {
+		return err
+	}
+
+	if err := audit(repoManager, "promote_owner", "user", u.ID.String(), fmt.Sprintf("Promoted user %s (%s) from %s to owner", u.Name, u.Email, previous)); err != nil {
+		return err
+	}
+	fmt.Printf("%s is now an owner\n", u.Email)
+	return nil
+}
+
+func domainsList(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("domains list", flag.ContinueOnError)
+	if _, err := parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	domains, err := repoManager.CompanyAllowedDomainFindAll()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tRULE\tACTIVE\tDESCRIPTION")
+	for _, domain := range *domains {
+		rule := "allow"
+		if domain.IsBlocked {
+			rule = "block"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\n", domain.Domain, rule, domain.IsActive, domain.Description)
+	}
+	return w.Flush()
+}
+
+func domainsAdd(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("domains add", flag.ContinueOnError)
+	block := flags.Bool("block", false, "refuse registrations from the domain instead of allowing them")
+	description := flags.String("description", "", "why the rule exists")
+	rest, err := parse(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	name := models.NormalizeDomain(rest[0])
+	if _, err := repoManager.CompanyAllowedDomainFindByDomain(name); err == nil {
+		return fmt.Errorf("there already is a rule for %s", name)
+	}
+
+	domain := &models.CompanyAllowedDomain{
+		Domain:      name,
+		IsActive:    true,
+		IsBlocked:   *block,
+		Description: *description,
+	}
+	verrs, err := repoManager.CompanyAllowedDomainCreate(domain)
+	if err != nil {
+		return err
+	}
+	if verrs.HasAny() {
+		return errors.New(verrs.Error())
+	}
+
+	rule := "Allowed"
+	if domain.IsBlocked {
+		rule = "Blocked"
+	}
+	if err := audit(repoManager, "create", "company_allowed_domain", domain.ID.String(), fmt.Sprintf("%s registrations from %s", rule, domain.Domain)); err != nil {
+		return err
+	}
+	fmt.Printf("%s registrations from %s\n", rule, domain.Domain)
+	return nil
+}
+
+func domainsEnable(repoManager *repository.RepositoryManager, args []string) error {
+	return setDomainActive(repoManager, "domains enable", args, true)
+}
+
+func domainsDisable(repoManager *repository.RepositoryManager, args []string) error {
+	return setDomainActive(repoManager, "domains disable", args, false)
+}
+
+// setDomainActive turns a domain rule on or off
+func setDomainActive(repoManager *repository.RepositoryManager, name string, args []string, active bool) error {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	rest, err := parse(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	domain, err := repoManager.CompanyAllowedDomainFindByDomain(models.NormalizeDomain(rest[0]))
+	if err != nil {
+		return fmt.Errorf("there is no rule for %s", rest[0])
+	}
+	if err := repoManager.CompanyAllowedDomainSetActive(domain.ID, active); err != nil {
+		return err
+	}
+
+	state := "Disabled"
+	if active {
+		state = "Enabled"
+	}
+	if err := audit(repoManager, "update", "company_allowed_domain", domain.ID.String(), fmt.Sprintf("%s the rule for %s", state, domain.Domain)); err != nil {
+		return err
+	}
+	fmt.Printf("%s the rule for %s\n", state, domain.Domain)
+	return nil
+}
+
+func domainsRemove(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("domains remove", flag.ContinueOnError)
+	rest, err := parse(flags, args, 1)
+	if err != nil {
+		return err
+	}
+
+	domain, err := repoManager.CompanyAllowedDomainFindByDomain(models.NormalizeDomain(rest[0]))
+	if err != nil {
+		return fmt.Errorf("there is no rule for %s", rest[0])
+	}
+	if err := repoManager.CompanyAllowedDomainDelete(domain.ID); err != nil {
+		return err
+	}
+
+	if err := audit(repoManager, "delete", "company_allowed_domain", domain.ID.String(), fmt.Sprintf("Deleted the rule for %s", domain.Domain)); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted the rule for %s\n", domain.Domain)
+	return nil
+}
+
+func hackathonsList(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("hackathons list", flag.ContinueOnError)
+	if _, err := parse(flags, args, 0); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tSTATUS\tSTART\tEND")
+	var after *repository.PageCursor
+	for {
+		hackathons, err := repoManager.HackathonFindPage(uuid.Nil, true, after, 100)
+		if err != nil {
+			return err
+		}
+		for _, hackathon := range *hackathons {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", hackathon.ID, hackathon.Title, hackathon.Status, hackathon.StartDate.Format("2006-01-02"), hackathon.EndDate.Format("2006-01-02"))
+		}
+		if len(*hackathons) < 100 {
+			break
+		}
+		last := (*hackathons)[len(*hackathons)-1]
+		after = &repository.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	return w.Flush()
+}
+
+func hackathonsStatus(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("hackathons status", flag.ContinueOnError)
+	rest, err := parse(flags, args, 2)
+	if err != nil {
+		return err
+	}
+
+	hackathon, err := repoManager.HackathonFindByID(rest[0])
+	if err != nil {
+		return fmt.Errorf("no hackathon with the ID %s", rest[0])
+	}
+	switch rest[1] {
+	case models.HackathonStatusUpcoming, models.HackathonStatusActive, models.HackathonStatusCompleted, models.HackathonStatusHidden:
+	default:
+		return fmt.Errorf("%q isn't a status, use upcoming, active, completed or hidden", rest[1])
+	}
+	if hackathon.Status == rest[1] {
+		fmt.Printf("%s is already %s\n", hackathon.Title, hackathon.Status)
+		return nil
+	}
+
+	previous := hackathon.Status
+	if err := repoManager.HackathonSetStatus(hackathon.ID, rest[1]); err != nil {
+		return err
+	}
+	hackathon.Status = rest[1]
+
+	if err := audit(repoManager, "change_status", "hackathon", hackathon.ID, fmt.Sprintf("Hackathon %s moved from %s to %s", hackathon.Title, previous, hackathon.Status)); err != nil {
+		return err
+	}
+	fmt.Printf("%s moved from %s to %s\n", hackathon.Title, previous, hackathon.Status)
+	return nil
+}
+
+// parseTime reads a date or an RFC 3339 time, an empty string is the zero time
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q isn't a date like 2006-01-02 or a time like 2006-01-02T15:04:05Z", value)
+	}
+	return t, nil
+}
+
+func auditExport(repoManager *repository.RepositoryManager, args []string) error {
+	flags := flag.NewFlagSet("audit export", flag.ContinueOnError)
+	sinceFlag := flags.String("since", "", "only entries from this date or time on")
+	untilFlag := flags.String("until", "", "only entries before this date or time")
+	action := flags.String("action", "", "only entries with this action, like login_failed")
+	format := flags.String("format", "json", "json for one object per line, or csv")
+	if _, err := parse(flags, args, 0); err != nil {
+		return err
+	}
+	since, err := parseTime(*sinceFlag)
+	if err != nil {
+		return err
+	}
+	until, err := parseTime(*untilFlag)
+	if err != nil {
+		return err
+	}
+	if *format != "json" && *format != "csv" {
+		return fmt.Errorf("%q isn't a format, use json or csv", *format)
+	}
+
+	auditLogs, err := repoManager.AuditLogFindBetween(since, until, *action)
+	if err != nil {
+		return err
+	}
+	if *format == "csv" {
+		err = writeAuditCSV(os.Stdout, *auditLogs)
+	} else {
+		for _, auditLog := range *auditLogs {
+			if _, err = fmt.Fprintln(os.Stdout, auditLog.String()); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	details := fmt.Sprintf("Exported %d audit log entries as %s", len(*auditLogs), *format)
+	if *sinceFlag != "" || *untilFlag != "" || *action != "" {
+		details += fmt.Sprintf(" with since=%q until=%q action=%q", *sinceFlag, *untilFlag, *action)
+	}
+	return audit(repoManager, "export_audit_logs", "audit_log", "", details)
+}
+
+// writeAuditCSV writes audit log entries as CSV with a header row
+func writeAuditCSV(out io.Writer, auditLogs models.AuditLogs) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"created_at", "user_id", "action", "resource_type", "resource_id", "details", "ip_address", "user_agent"})
+	for _, auditLog := range auditLogs {
+		userID, resourceID := "", ""
+		if auditLog.UserID != nil {
+			userID = auditLog.UserID.String()
+		}
+		if auditLog.ResourceID != nil {
+			resourceID = *auditLog.ResourceID
+		}
+		w.Write([]string{
+			auditLog.CreatedAt.UTC().Format(time.RFC3339),
+			userID,
+			auditLog.Action,
+			auditLog.ResourceType,
+			resourceID,
+			auditLog.Details,
+			auditLog.IPAddress,
+			auditLog.UserAgent,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,42 @@
+// Command mockldap is a minimal in-memory LDAP server for trying LDAP sign-in locally without a directory.
+// It serves the entries of an LDIF file and only supports simple binds and searches, so never expose it
+// outside a development machine.
+//
+//	go run ./cmd/mockldap -addr :3389 -ldif config/ldap/seed.ldif
+//
+// Then set the server URL to ldap://localhost:3389 in the admin configuration.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+
+	"github.com/arxdsilva/hackathon/internal/mockldap"
+)
+
+func main() {
+	addr := flag.String("addr", ":3389", "address to listen on")
+	ldif := flag.String("ldif", "config/ldap/seed.ldif", "LDIF file with the entries to serve")
+	bindDN := flag.String("bind-dn", "cn=readonly,dc=example,dc=com", "DN of the service account")
+	bindPassword := flag.String("bind-password", "readonly", "password of the service account")
+	flag.Parse()
+
+	file, err := os.Open(*ldif)
+	if err != nil {
+		log.Fatal(err)
+	}
+	directory, err := mockldap.NewDirectory(file, *bindDN, *bindPassword)
+	file.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Mock LDAP server with %d entries listening on %s, the service account is %s", directory.Len(), *addr, *bindDN)
+	log.Fatal(directory.Serve(listener))
+}
@@ -0,0 +1,302 @@
+// Command mockoidc is a minimal OpenID Connect provider for trying single sign-on locally.
+// It signs in anyone who fills in its form, so never expose it outside a development machine.
+//
+//	go run ./cmd/mockoidc -addr :9999 -client-id hackathon -client-secret secret
+//
+// Then set the issuer URL to http://localhost:9999 in the admin configuration.
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// authorization is a code handed out by the authorize endpoint and not yet redeemed
+type authorization struct {
+	clientID    string
+	redirectURI string
+	nonce       string
+	challenge   string
+	claims      map[string]interface{}
+	expiresAt   time.Time
+}
+
+type provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+var authorizeForm = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Mock OIDC Provider</title></head>
+<body style="font-family: sans-serif; max-width: 32rem; margin: 3rem auto;">
+  <h1>Mock OIDC Provider</h1>
+  <p>Sign in to <code>{{.ClientID}}</code> as anyone.</p>
+  <form method="POST" action="/authorize">
+    {{range $name, $value := .Params}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+    {{end}}
+    <p><label>Email<br><input name="email" type="email" value="dev@example.com" required style="width: 100%"></label></p>
+    <p><label>Name<br><input name="name" value="Dev User" style="width: 100%"></label></p>
+    <p><label>Groups (comma separated)<br><input name="groups" value="" style="width: 100%"></label></p>
+    <p><label><input name="email_verified" type="checkbox" value="true" checked> Email verified</label></p>
+    <p><button type="submit">Sign in</button></p>
+  </form>
+</body>
+</html>`))
+
+func main() {
+	addr := flag.String("addr", ":9999", "address to listen on")
+	issuer := flag.String("issuer", "http://localhost:9999", "issuer URL, as the app reaches this server")
+	clientID := flag.String("client-id", "hackathon", "client ID the app is configured with")
+	clientSecret := flag.String("client-secret", "secret", "client secret the app is configured with")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	p := &provider{
+		issuer:       strings.TrimSuffix(*issuer, "/"),
+		clientID:     *clientID,
+		clientSecret: *clientSecret,
+		key:          key,
+		codes:        map[string]authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+
+	log.Printf("Mock OIDC provider for client %q listening on %s with issuer %s", p.clientID, *addr, p.issuer)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (p *provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+	})
+}
+
+func (p *provider) authorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Form.Get("client_id") != p.clientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("response_type") != "code" {
+		http.Error(w, "only the authorization code flow is supported", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("code_challenge") == "" || r.Form.Get("code_challenge_method") != "S256" {
+		http.Error(w, "a S256 PKCE code challenge is required", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		params := map[string]string{}
+		for _, name := range []string{"client_id", "redirect_uri", "response_type", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+			params[name] = r.Form.Get(name)
+		}
+		if err := authorizeForm.Execute(w, map[string]interface{}{"ClientID": p.clientID, "Params": params}); err != nil {
+			log.Printf("Failed to render the sign-in form: %v", err)
+		}
+		return
+	}
+
+	email := strings.TrimSpace(r.Form.Get("email"))
+	groups := []string{}
+	for _, group := range strings.Split(r.Form.Get("groups"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.codes[code] = authorization{
+		clientID:    p.clientID,
+		redirectURI: r.Form.Get("redirect_uri"),
+		nonce:       r.Form.Get("nonce"),
+		challenge:   r.Form.Get("code_challenge"),
+		claims: map[string]interface{}{
+			// The subject is derived from the email so signing in again maps to the same identity
+			"sub":            "mock-" + base64.RawURLEncoding.EncodeToString([]byte(strings.ToLower(email))),
+			"email":          email,
+			"email_verified": r.Form.Get("email_verified") == "true",
+			"name":           strings.TrimSpace(r.Form.Get("name")),
+			"groups":         groups,
+		},
+		expiresAt: time.Now().Add(time.Minute),
+	}
+	p.mu.Unlock()
+
+	redirect, err := url.Parse(r.Form.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", r.Form.Get("state"))
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request", err.Error())
+		return
+	}
+
+	clientID, clientSecret := r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	if user, password, ok := r.BasicAuth(); ok {
+		// Basic credentials are form encoded first, see RFC 6749 section 2.3.1
+		clientID, _ = url.QueryUnescape(user)
+		clientSecret, _ = url.QueryUnescape(password)
+	}
+	if clientID != p.clientID || clientSecret != p.clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+
+	p.mu.Lock()
+	code, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	if !ok || time.Now().After(code.expiresAt) {
+		tokenError(w, "invalid_grant", "unknown or expired code")
+		return
+	}
+	if code.redirectURI != r.PostForm.Get("redirect_uri") {
+		tokenError(w, "invalid_grant", "redirect_uri does not match the authorization request")
+		return
+	}
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != code.challenge {
+		tokenError(w, "invalid_grant", "code_verifier does not match the code challenge")
+		return
+	}
+
+	now := time.Now()
+	claims := map[string]interface{}{
+		"iss":   p.issuer,
+		"aud":   code.clientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": code.nonce,
+	}
+	for name, value := range code.claims {
+		claims[name] = value
+	}
+
+	idToken, err := p.sign(claims)
+	if err != nil {
+		tokenError(w, "server_error", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// sign returns the claims as a RS256 JSON web token
+func (p *provider) sign(claims map[string]interface{}) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "mock"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (p *provider) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "mock",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
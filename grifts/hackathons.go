@@ -0,0 +1,31 @@
+package grifts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arxdsilva/hackathon/actions"
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/grift/grift"
+	"github.com/gobuffalo/pop/v6"
+)
+
+var _ = grift.Namespace("hackathons", func() {
+
+	grift.Desc("transition", "Moves hackathons between upcoming, active and completed based on their dates")
+	grift.Add("transition", func(c *grift.Context) error {
+		return models.DB.Transaction(func(tx *pop.Connection) error {
+			transitions, err := actions.TransitionHackathonStatuses(tx, time.Now())
+			if err != nil {
+				return err
+			}
+			for _, t := range transitions {
+				fmt.Printf("%s (%s): %s -> %s\n", t.Hackathon.Title, t.Hackathon.ID, t.From, t.To)
+			}
+			fmt.Printf("%d hackathon(s) updated\n", len(transitions))
+			return nil
+		})
+	})
+
+})
@@ -0,0 +1,28 @@
+package grifts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/arxdsilva/hackathon/actions"
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/grift/grift"
+	"github.com/gobuffalo/pop/v6"
+)
+
+var _ = grift.Namespace("sessions", func() {
+
+	grift.Desc("cleanup", "Deletes sessions that have outlived the configured idle or absolute timeout")
+	grift.Add("cleanup", func(c *grift.Context) error {
+		return models.DB.Transaction(func(tx *pop.Connection) error {
+			deleted, err := actions.CleanupExpiredSessions(tx, time.Now())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d expired session(s) deleted\n", deleted)
+			return nil
+		})
+	})
+
+})
@@ -0,0 +1,334 @@
+// Package mockldap is a minimal in-memory LDAP server for trying LDAP sign-in without a directory.
+// It only supports simple binds and searches, so never expose it outside a development machine
+// or a test.
+package mockldap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// LDAP protocol operations, see RFC 4511 section 4.2
+const (
+	opBindRequest      = 0
+	opBindResponse     = 1
+	opUnbindRequest    = 2
+	opSearchRequest    = 3
+	opSearchEntry      = 4
+	opSearchDone       = 5
+	opExtendedRequest  = 23
+	opExtendedResponse = 24
+)
+
+// LDAP result codes used by this server
+const (
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultNoSuchObject       = 32
+	resultInvalidCredentials = 49
+	resultUnwillingToPerform = 53
+)
+
+// Search scopes
+const (
+	scopeBase    = 0
+	scopeOne     = 1
+	scopeSubtree = 2
+)
+
+// entry is a directory entry, attribute names are lowercased and values keep their case
+type entry struct {
+	dn         string
+	names      []string
+	attributes map[string][]string
+}
+
+// Directory serves a fixed set of entries. The service account can bind with its password
+// and every entry with one of its userPassword values.
+type Directory struct {
+	entries      []*entry
+	bindDN       string
+	bindPassword string
+}
+
+// NewDirectory returns a directory of the entries of an LDIF document
+func NewDirectory(ldif io.Reader, bindDN, bindPassword string) (*Directory, error) {
+	entries, err := parseLDIF(ldif)
+	if err != nil {
+		return nil, err
+	}
+	return &Directory{entries: entries, bindDN: normalizeDN(bindDN), bindPassword: bindPassword}, nil
+}
+
+// Len returns the number of entries in the directory
+func (d *Directory) Len() int {
+	return len(d.entries)
+}
+
+// Serve answers the LDAP clients that connect to the listener until it is closed
+func (d *Directory) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go d.serve(conn)
+	}
+}
+
+// parseLDIF reads the records of an LDIF document. Folded lines and base64 values aren't supported.
+func parseLDIF(r io.Reader) ([]*entry, error) {
+	entries := []*entry{}
+	var current *entry
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "" {
+			current = nil
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid LDIF line: %q", line)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+
+		if current == nil {
+			if !strings.EqualFold(name, "dn") {
+				return nil, fmt.Errorf("LDIF record starts with %q instead of dn", name)
+			}
+			current = &entry{dn: value, attributes: map[string][]string{}}
+			entries = append(entries, current)
+			continue
+		}
+
+		key := strings.ToLower(name)
+		if _, ok := current.attributes[key]; !ok {
+			current.names = append(current.names, name)
+		}
+		current.attributes[key] = append(current.attributes[key], value)
+	}
+	return entries, scanner.Err()
+}
+
+// normalizeDN makes DNs comparable, it lowercases them and drops the spaces around separators
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		name, value, _ := strings.Cut(part, "=")
+		parts[i] = strings.ToLower(strings.TrimSpace(name)) + "=" + strings.ToLower(strings.TrimSpace(value))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (d *Directory) find(dn string) *entry {
+	dn = normalizeDN(dn)
+	for _, e := range d.entries {
+		if normalizeDN(e.dn) == dn {
+			return e
+		}
+	}
+	return nil
+}
+
+func (d *Directory) serve(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			if err != io.EOF {
+				log.Printf("Failed to read request: %v", err)
+			}
+			return
+		}
+		if len(packet.Children) < 2 {
+			log.Printf("Malformed request from %s", conn.RemoteAddr())
+			return
+		}
+
+		messageID, _ := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case opBindRequest:
+			d.bind(conn, messageID, op)
+		case opSearchRequest:
+			d.search(conn, messageID, op)
+		case opUnbindRequest:
+			return
+		case opExtendedRequest:
+			// StartTLS is the only extended operation clients send and there's no certificate to offer
+			respond(conn, messageID, opExtendedResponse, resultProtocolError, "extended operations are not supported")
+		default:
+			respond(conn, messageID, op.Tag, resultUnwillingToPerform, "operation not supported")
+		}
+	}
+}
+
+func (d *Directory) bind(conn net.Conn, messageID int64, op *ber.Packet) {
+	if len(op.Children) < 3 {
+		respond(conn, messageID, opBindResponse, resultProtocolError, "malformed bind request")
+		return
+	}
+	dn := op.Children[1].Data.String()
+	password := op.Children[2].Data.String()
+
+	if password == "" {
+		// Unauthenticated binds are accepted like most servers do
+		respond(conn, messageID, opBindResponse, resultSuccess, "")
+		return
+	}
+	if normalizeDN(dn) == d.bindDN && password == d.bindPassword {
+		respond(conn, messageID, opBindResponse, resultSuccess, "")
+		return
+	}
+	if e := d.find(dn); e != nil {
+		for _, value := range e.attributes["userpassword"] {
+			if value == password {
+				respond(conn, messageID, opBindResponse, resultSuccess, "")
+				return
+			}
+		}
+	}
+	log.Printf("Rejected bind as %s", dn)
+	respond(conn, messageID, opBindResponse, resultInvalidCredentials, "invalid credentials")
+}
+
+func (d *Directory) search(conn net.Conn, messageID int64, op *ber.Packet) {
+	if len(op.Children) < 8 {
+		respond(conn, messageID, opSearchDone, resultProtocolError, "malformed search request")
+		return
+	}
+	base := normalizeDN(op.Children[0].Data.String())
+	scope, _ := op.Children[1].Value.(int64)
+	filter := op.Children[6]
+	requested := map[string]bool{}
+	for _, attribute := range op.Children[7].Children {
+		requested[strings.ToLower(attribute.Data.String())] = true
+	}
+
+	if base != "" && d.find(base) == nil && !d.isSuffix(base) {
+		respond(conn, messageID, opSearchDone, resultNoSuchObject, "no such object")
+		return
+	}
+
+	for _, e := range d.entries {
+		dn := normalizeDN(e.dn)
+		switch scope {
+		case scopeBase:
+			if dn != base {
+				continue
+			}
+		case scopeOne:
+			_, parent, _ := strings.Cut(dn, ",")
+			if parent != base {
+				continue
+			}
+		case scopeSubtree:
+			if base != "" && dn != base && !strings.HasSuffix(dn, ","+base) {
+				continue
+			}
+		}
+		if !matches(e, filter) {
+			continue
+		}
+
+		result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, opSearchEntry, nil, "Search Result Entry")
+		result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "DN"))
+		attributes := ber.NewSequence("Attributes")
+		for _, name := range e.names {
+			key := strings.ToLower(name)
+			if key == "userpassword" || (len(requested) > 0 && !requested[key] && !requested["*"]) {
+				continue
+			}
+			attribute := ber.NewSequence("Attribute")
+			attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+			values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, value := range e.attributes[key] {
+				values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+			}
+			attribute.AppendChild(values)
+			attributes.AppendChild(attribute)
+		}
+		result.AppendChild(attributes)
+		write(conn, messageID, result)
+	}
+	respond(conn, messageID, opSearchDone, resultSuccess, "")
+}
+
+// isSuffix returns true when the DN is above some entry, like the naming context of the directory
+func (d *Directory) isSuffix(dn string) bool {
+	for _, e := range d.entries {
+		if strings.HasSuffix(normalizeDN(e.dn), ","+dn) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches evaluates and, or, not, equality and presence filters, see RFC 4511 section 4.5.1.7
+func matches(e *entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case 0:
+		for _, child := range filter.Children {
+			if !matches(e, child) {
+				return false
+			}
+		}
+		return true
+	case 1:
+		for _, child := range filter.Children {
+			if matches(e, child) {
+				return true
+			}
+		}
+		return false
+	case 2:
+		return len(filter.Children) == 1 && !matches(e, filter.Children[0])
+	case 3:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		key := strings.ToLower(filter.Children[0].Data.String())
+		want := filter.Children[1].Data.String()
+		for _, value := range e.attributes[key] {
+			if strings.EqualFold(value, want) || (strings.Contains(value, "=") && normalizeDN(value) == normalizeDN(want)) {
+				return true
+			}
+		}
+		return false
+	case 7:
+		key := strings.ToLower(filter.Data.String())
+		return key == "objectclass" || len(e.attributes[key]) > 0
+	default:
+		return false
+	}
+}
+
+// respond writes a response that only carries a result
+func respond(conn net.Conn, messageID int64, op ber.Tag, code int, message string) {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "Diagnostic Message"))
+	write(conn, messageID, response)
+}
+
+func write(conn net.Conn, messageID int64, op *ber.Packet) {
+	packet := ber.NewSequence("LDAP Message")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	packet.AppendChild(op)
+	if _, err := conn.Write(packet.Bytes()); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package mailers
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo/mail"
+	"github.com/gobuffalo/buffalo/render"
+)
+
+// SendEmailVerification emails a user the link that confirms they own their address
+func SendEmailVerification(user models.User, verifyURL string, expiresAt time.Time) error {
+	m := mail.NewMessage()
+	m.Subject = "Confirm your email address"
+	m.From = fromAddress()
+	m.To = []string{user.Email}
+
+	data := render.Data{
+		"user":      user,
+		"verifyURL": verifyURL,
+		"expiresAt": expiresAt,
+	}
+	if err := m.AddBody(r.HTML("mail/email_verification.plush.html"), data); err != nil {
+		return err
+	}
+	return sender.Send(m)
+}
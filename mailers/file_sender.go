@@ -0,0 +1,46 @@
+package mailers
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/buffalo/mail"
+)
+
+// FileSender keeps outgoing email on the local machine for development. Messages are
+// written to Dir, or to the application log when Dir is empty.
+type FileSender struct {
+	Dir string
+}
+
+// Send writes a message with its headers and bodies
+func (s FileSender) Send(m mail.Message) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\n", m.From)
+	fmt.Fprintf(&buf, "To: %s\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\n", m.Subject)
+	for _, body := range m.Bodies {
+		fmt.Fprintf(&buf, "\n--- %s\n%s\n", body.ContentType, body.Content)
+	}
+
+	if s.Dir == "" {
+		log.Printf("mail:\n%s", buf.String())
+		return nil
+	}
+
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), strings.Join(m.To, "_"))
+	path := filepath.Join(s.Dir, filepath.Base(name))
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	log.Printf("mail: wrote %q to %s", m.Subject, path)
+	return nil
+}
@@ -0,0 +1,79 @@
+package mailers
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/arxdsilva/hackathon/templates"
+
+	"github.com/gobuffalo/buffalo/mail"
+	"github.com/gobuffalo/buffalo/render"
+	"github.com/gobuffalo/envy"
+)
+
+var (
+	sender mail.Sender
+	r      *render.Engine
+)
+
+func init() {
+	r = render.New(render.Options{
+		HTMLLayout:  "mail/layout.plush.html",
+		TemplatesFS: templates.FS(),
+		Helpers:     render.Helpers{},
+	})
+
+	var err error
+	if sender, err = NewSender(); err != nil {
+		// Logged emails carry sign-in links in plain text, so only development and test fall back to it
+		if !logByDefault() {
+			log.Fatalf("mailers: %v", err)
+		}
+		log.Printf("mailers: %v, falling back to logging emails", err)
+		sender = FileSender{}
+	}
+}
+
+// logByDefault returns true when emails can be logged without MAIL_DELIVERY asking for it
+func logByDefault() bool {
+	env := envy.Get("GO_ENV", "development")
+	return env == "development" || env == "test"
+}
+
+// NewSender builds the sender selected by MAIL_DELIVERY: "smtp" delivers through
+// SMTP_HOST, "file" writes messages to MAIL_DIR and "log" logs them. Development and
+// test log emails when MAIL_DELIVERY isn't set, other environments have to set it.
+func NewSender() (mail.Sender, error) {
+	delivery := envy.Get("MAIL_DELIVERY", "")
+	if delivery == "" && logByDefault() {
+		delivery = "log"
+	}
+
+	switch delivery {
+	case "smtp":
+		return mail.NewSMTPSender(
+			envy.Get("SMTP_HOST", "localhost"),
+			envy.Get("SMTP_PORT", "587"),
+			envy.Get("SMTP_USER", ""),
+			envy.Get("SMTP_PASSWORD", ""),
+		)
+	case "file":
+		return FileSender{Dir: envy.Get("MAIL_DIR", "tmp/mail")}, nil
+	case "log":
+		return FileSender{}, nil
+	case "":
+		return nil, fmt.Errorf("MAIL_DELIVERY has to be set to smtp, file or log")
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DELIVERY %q, use smtp, file or log", delivery)
+	}
+}
+
+// SetSender replaces the sender used for outgoing email
+func SetSender(s mail.Sender) {
+	sender = s
+}
+
+// fromAddress returns the sender address of outgoing email
+func fromAddress() string {
+	return envy.Get("MAIL_FROM", "noreply@hackathon.com")
+}
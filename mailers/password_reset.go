@@ -0,0 +1,28 @@
+package mailers
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+
+	"github.com/gobuffalo/buffalo/mail"
+	"github.com/gobuffalo/buffalo/render"
+)
+
+// SendPasswordReset emails a user the one-time link that lets them choose a new password
+func SendPasswordReset(user models.User, resetURL string, expiresAt time.Time) error {
+	m := mail.NewMessage()
+	m.Subject = "Reset your password"
+	m.From = fromAddress()
+	m.To = []string{user.Email}
+
+	data := render.Data{
+		"user":      user,
+		"resetURL":  resetURL,
+		"expiresAt": expiresAt,
+	}
+	if err := m.AddBody(r.HTML("mail/password_reset.plush.html"), data); err != nil {
+		return err
+	}
+	return sender.Send(m)
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// API token scopes. Every token can read, projects:write also lets it change projects and
+// teams, and admin lets it do everything its user can, including managing hackathons.
+const (
+	APIScopeRead          = "read"
+	APIScopeProjectsWrite = "projects:write"
+	APIScopeAdmin         = "admin"
+)
+
+// APIScopes are the scopes a token can be given, from least to most access
+var APIScopes = []string{APIScopeRead, APIScopeProjectsWrite, APIScopeAdmin}
+
+// IsValidAPIScope returns true if scope is one of the known API token scopes
+func IsValidAPIScope(scope string) bool {
+	for _, s := range APIScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken is a bearer token a user authenticates API requests with. Only a hash of the
+// token is kept, it is shown once when it is created.
+type APIToken struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	User       *User      `json:"user,omitempty" belongs_to:"user"`
+	Name       string     `json:"name" db:"name"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	LastUsedAt nulls.Time `json:"last_used_at" db:"last_used_at"`
+
+	// Scopes is a space-separated list of APIScopes
+	Scopes    string     `json:"scopes" db:"scopes"`
+	ExpiresAt nulls.Time `json:"expires_at" db:"expires_at"`
+
+	// CreatedByID is the owner who issued the token when it belongs to a service account
+	CreatedByID nulls.UUID `json:"created_by_id" db:"created_by_id"`
+}
+
+// HashAPIToken returns the stored form of an API token
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ScopeList returns the scopes of the token
+func (t APIToken) ScopeList() []string {
+	return strings.Fields(t.Scopes)
+}
+
+// HasScope returns true when the token may do what scope allows. Any token can read and
+// admin tokens can do everything.
+func (t APIToken) HasScope(scope string) bool {
+	if scope == APIScopeRead {
+		return true
+	}
+	for _, s := range t.ScopeList() {
+		if s == scope || s == APIScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired returns true when the token has an expiry that has passed
+func (t APIToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt.Valid && !now.Before(t.ExpiresAt.Time)
+}
+
+// String is not required by pop and may be deleted
+func (t APIToken) String() string {
+	jt, _ := json.Marshal(t)
+	return string(jt)
+}
+
+// APITokens is not required by pop and may be deleted
+type APITokens []APIToken
+
+// String is not required by pop and may be deleted
+func (t APITokens) String() string {
+	jt, _ := json.Marshal(t)
+	return string(jt)
+}
+
+// Validate runs on Validate* calls
+func (t *APIToken) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: t.UserID, Name: "UserID"},
+		&validators.StringIsPresent{Field: t.Name, Name: "Name"},
+		&validators.StringIsPresent{Field: t.TokenHash, Name: "TokenHash"},
+		&validators.FuncValidator{
+			Field:   t.Scopes,
+			Name:    "Scopes",
+			Message: "%q isn't a valid list of scopes, use read, projects:write or admin",
+			Fn: func() bool {
+				scopes := t.ScopeList()
+				for _, scope := range scopes {
+					if !IsValidAPIScope(scope) {
+						return false
+					}
+				}
+				return len(scopes) > 0
+			},
+		},
+	), nil
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func TestAPITokenHasScope(t *testing.T) {
+	tests := []struct {
+		scopes string
+		scope  string
+		want   bool
+	}{
+		{scopes: APIScopeRead, scope: APIScopeRead, want: true},
+		{scopes: APIScopeRead, scope: APIScopeProjectsWrite, want: false},
+		{scopes: APIScopeRead, scope: APIScopeAdmin, want: false},
+		{scopes: "", scope: APIScopeRead, want: true},
+		{scopes: APIScopeRead + " " + APIScopeProjectsWrite, scope: APIScopeProjectsWrite, want: true},
+		{scopes: APIScopeRead + " " + APIScopeProjectsWrite, scope: APIScopeAdmin, want: false},
+		{scopes: APIScopeAdmin, scope: APIScopeProjectsWrite, want: true},
+		{scopes: APIScopeAdmin, scope: APIScopeAdmin, want: true},
+		{scopes: "projects:writer", scope: APIScopeProjectsWrite, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := (APIToken{Scopes: tt.scopes}).HasScope(tt.scope); got != tt.want {
+			t.Errorf("token with scopes %q HasScope(%q) = %v, want %v", tt.scopes, tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestAPITokenIsExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		expiresAt nulls.Time
+		want      bool
+	}{
+		{name: "never expires", want: false},
+		{name: "expires later", expiresAt: nulls.NewTime(now.Add(time.Minute)), want: false},
+		{name: "expires now", expiresAt: nulls.NewTime(now), want: true},
+		{name: "expired", expiresAt: nulls.NewTime(now.Add(-time.Minute)), want: true},
+	}
+
+	for _, tt := range tests {
+		if got := (APIToken{ExpiresAt: tt.expiresAt}).IsExpired(now); got != tt.want {
+			t.Errorf("%s: IsExpired = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Award is a prize category of a hackathon, e.g. "Best Use of AI"
+type Award struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	HackathonID string       `json:"hackathon_id" db:"hackathon_id"`
+	Name        string       `json:"name" db:"name"`
+	Description nulls.String `json:"description" db:"description"`
+	Prize       nulls.String `json:"prize" db:"prize"`
+
+	Winners AwardWinners `json:"winners,omitempty" has_many:"award_winners" fk_id:"award_id"`
+}
+
+// String is not required by pop and may be deleted
+func (a Award) String() string {
+	ja, _ := json.Marshal(a)
+	return string(ja)
+}
+
+// Awards is not required by pop and may be deleted
+type Awards []Award
+
+// String is not required by pop and may be deleted
+func (a Awards) String() string {
+	ja, _ := json.Marshal(a)
+	return string(ja)
+}
+
+// Validate runs on Validate* calls
+func (a *Award) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: a.HackathonID, Name: "HackathonID"},
+		&validators.StringIsPresent{Field: a.Name, Name: "Name"},
+	), nil
+}
+
+// AwardWinner links a winning project to an award
+type AwardWinner struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	AwardID uuid.UUID `json:"award_id" db:"award_id"`
+
+	ProjectID string   `json:"project_id" db:"project_id"`
+	Project   *Project `json:"project,omitempty" belongs_to:"project" fk_id:"project_id"`
+}
+
+// String is not required by pop and may be deleted
+func (a AwardWinner) String() string {
+	ja, _ := json.Marshal(a)
+	return string(ja)
+}
+
+// AwardWinners is not required by pop and may be deleted
+type AwardWinners []AwardWinner
+
+// String is not required by pop and may be deleted
+func (a AwardWinners) String() string {
+	ja, _ := json.Marshal(a)
+	return string(ja)
+}
+
+// Validate runs on Validate* calls
+func (a *AwardWinner) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: a.AwardID, Name: "AwardID"},
+		&validators.StringIsPresent{Field: a.ProjectID, Name: "ProjectID"},
+	), nil
+}
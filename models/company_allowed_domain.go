@@ -2,6 +2,9 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/gobuffalo/pop/v6"
@@ -10,7 +13,11 @@ import (
 	"github.com/gofrs/uuid"
 )
 
-// CompanyAllowedDomain represents allowed email domains for user registration
+// domainPattern accepts a domain name, optionally prefixed with *. to cover its subdomains
+var domainPattern = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// CompanyAllowedDomain represents an email domain rule for user registration. Rules allow the
+// domain unless IsBlocked is set, and a domain starting with *. matches any of its subdomains.
 type CompanyAllowedDomain struct {
 	ID        uuid.UUID `json:"id" db:"id"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
@@ -19,6 +26,7 @@ type CompanyAllowedDomain struct {
 	Domain      string `json:"domain" db:"domain" form:"domain"`
 	IsActive    bool   `json:"is_active" db:"is_active" form:"is_active"`
 	Description string `json:"description" db:"description" form:"description"`
+	IsBlocked   bool   `json:"is_blocked" db:"is_blocked" form:"-"`
 }
 
 // String returns the JSON representation of the company allowed domain
@@ -40,17 +48,84 @@ func (c CompanyAllowedDomains) String() string {
 func (c *CompanyAllowedDomain) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	return validate.Validate(
 		&validators.StringIsPresent{Field: c.Domain, Name: "Domain"},
+		&validators.FuncValidator{
+			Field:   c.Domain,
+			Name:    "Domain",
+			Message: "%s is not a domain name, use example.com or *.example.com for its subdomains",
+			Fn: func() bool {
+				return c.Domain == "" || domainPattern.MatchString(c.Domain)
+			},
+		},
 	), nil
 }
 
-// IsDomainAllowed checks if a given domain is in the allowed domains list
-func IsDomainAllowed(tx *pop.Connection, domain string) (bool, error) {
-	var count int
-	err := tx.RawQuery("SELECT COUNT(*) FROM company_allowed_domains WHERE domain = ? AND is_active = true", domain).First(&count)
-	if err != nil {
-		return false, err
+// NormalizeDomain lowercases a domain and drops surrounding spaces, dots and a leading @
+func NormalizeDomain(domain string) string {
+	return strings.Trim(strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "@"), ".")
+}
+
+// EmailDomain returns the normalized domain of an email address, or an empty string when there is none
+func EmailDomain(email string) string {
+	_, domain, found := strings.Cut(strings.TrimSpace(email), "@")
+	if !found {
+		return ""
+	}
+	return NormalizeDomain(domain)
+}
+
+// Matches returns true when the rule covers the domain. A wildcard rule like *.example.com
+// covers a.example.com and a.b.example.com but not example.com itself.
+func (c CompanyAllowedDomain) Matches(domain string) bool {
+	domain = NormalizeDomain(domain)
+	pattern := NormalizeDomain(c.Domain)
+	if suffix, wildcard := strings.CutPrefix(pattern, "*"); wildcard {
+		return len(domain) > len(suffix) && strings.HasSuffix(domain, suffix)
+	}
+	return domain == pattern
+}
+
+// DomainCheck is the outcome of checking an email domain against the domain rules
+type DomainCheck struct {
+	Domain  string
+	Allowed bool
+	// Rule is the entry that decided the outcome, nil when no entry matched
+	Rule   *CompanyAllowedDomain
+	Reason string
+}
+
+// Check decides whether an email domain may register. Nothing is accepted unless
+// openRegistration is true, which is the case for public registration, invitations and
+// accounts created by owners, and the domain matches an allowed entry: without allowed
+// entries nobody can register. Blocked entries always win and inactive entries are ignored.
+func (c CompanyAllowedDomains) Check(domain string, openRegistration bool) DomainCheck {
+	domain = NormalizeDomain(domain)
+	check := DomainCheck{Domain: domain}
+
+	for i := range c {
+		rule := &c[i]
+		if !rule.IsActive || !rule.Matches(domain) {
+			continue
+		}
+		if rule.IsBlocked {
+			check.Rule = rule
+			check.Reason = fmt.Sprintf("Email addresses at %s can't be used to register.", domain)
+			return check
+		}
+		if check.Rule == nil {
+			check.Rule = rule
+		}
+	}
+
+	switch {
+	case !openRegistration:
+		check.Reason = "Registration is closed. Ask an administrator to create your account."
+	case check.Rule != nil:
+		check.Allowed = true
+		check.Reason = fmt.Sprintf("%s matches the allowed domain %s.", domain, check.Rule.Domain)
+	default:
+		check.Reason = fmt.Sprintf("Registration is limited to approved email domains and %s isn't one of them.", domain)
 	}
-	return count > 0, nil
+	return check
 }
 
 // GetActiveDomains returns all active allowed domains
@@ -0,0 +1,72 @@
+package models
+
+import "testing"
+
+func TestCompanyAllowedDomainsCheck_ClosedRegistration(t *testing.T) {
+	domains := CompanyAllowedDomains{
+		{Domain: "example.com", IsActive: true},
+		{Domain: "*.example.org", IsActive: true},
+	}
+
+	for _, domain := range []string{"example.com", "team.example.org", "other.com"} {
+		if check := domains.Check(domain, false); check.Allowed {
+			t.Errorf("Check(%q) with registration closed is allowed: %s", domain, check.Reason)
+		}
+	}
+
+	if check := domains.Check("example.com", true); !check.Allowed {
+		t.Errorf("Check(%q) with registration open isn't allowed: %s", "example.com", check.Reason)
+	}
+	if check := domains.Check("other.com", true); check.Allowed {
+		t.Errorf("Check(%q) without a matching rule is allowed: %s", "other.com", check.Reason)
+	}
+}
+
+func TestCompanyAllowedDomainsCheck(t *testing.T) {
+	domains := CompanyAllowedDomains{
+		{Domain: "example.com", IsActive: true},
+		{Domain: "*.corp.example.org", IsActive: true},
+		{Domain: "blocked.corp.example.org", IsActive: true, IsBlocked: true},
+		{Domain: "inactive.com", IsActive: false},
+		{Domain: "*.example.com", IsActive: false, IsBlocked: true},
+	}
+
+	tests := []struct {
+		name    string
+		domains CompanyAllowedDomains
+		domain  string
+		allowed bool
+		rule    string
+	}{
+		{name: "exact match", domains: domains, domain: "example.com", allowed: true, rule: "example.com"},
+		{name: "case and spaces", domains: domains, domain: " Example.COM ", allowed: true, rule: "example.com"},
+		{name: "subdomain of an exact rule", domains: domains, domain: "team.example.com"},
+		{name: "wildcard subdomain", domains: domains, domain: "team.corp.example.org", allowed: true, rule: "*.corp.example.org"},
+		{name: "nested wildcard subdomain", domains: domains, domain: "a.b.corp.example.org", allowed: true, rule: "*.corp.example.org"},
+		{name: "wildcard doesn't cover its base", domains: domains, domain: "corp.example.org"},
+		{name: "blocked wins over the wildcard", domains: domains, domain: "blocked.corp.example.org", rule: "blocked.corp.example.org"},
+		{name: "inactive rule", domains: domains, domain: "inactive.com"},
+		{name: "no matching rule", domains: domains, domain: "other.com"},
+		{name: "no rules", domains: nil, domain: "example.com"},
+		{name: "only blocked rules", domains: CompanyAllowedDomains{{Domain: "spam.com", IsActive: true, IsBlocked: true}}, domain: "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := tt.domains.Check(tt.domain, true)
+			if check.Allowed != tt.allowed {
+				t.Errorf("Check(%q).Allowed = %v, want %v: %s", tt.domain, check.Allowed, tt.allowed, check.Reason)
+			}
+			rule := ""
+			if check.Rule != nil {
+				rule = check.Rule.Domain
+			}
+			if rule != tt.rule {
+				t.Errorf("Check(%q) matched %q, want %q", tt.domain, rule, tt.rule)
+			}
+			if check.Reason == "" {
+				t.Errorf("Check(%q) has no reason", tt.domain)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/gobuffalo/pop/v6"
@@ -45,6 +46,36 @@ type CompanyConfiguration struct {
 	SessionTimeoutMinutes       int  `json:"session_timeout_minutes" db:"session_timeout_minutes" form:"session_timeout_minutes"`
 	TwoFactorRequired           bool `json:"two_factor_required" db:"two_factor_required" form:"two_factor_required"`
 
+	// SessionAbsoluteTimeoutMinutes ends sessions this long after sign in, even when they are in use
+	SessionAbsoluteTimeoutMinutes int `json:"session_absolute_timeout_minutes" db:"session_absolute_timeout_minutes" form:"session_absolute_timeout_minutes"`
+
+	// LoginLockoutThreshold locks an account after this many failed sign-ins, 0 turns lockout off
+	LoginLockoutThreshold int `json:"login_lockout_threshold" db:"login_lockout_threshold" form:"login_lockout_threshold"`
+	LoginLockoutMinutes   int `json:"login_lockout_minutes" db:"login_lockout_minutes" form:"login_lockout_minutes"`
+
+	// Single Sign-On. OIDCOwnerClaim and OIDCOwnerValue name the ID token claim that makes a user an owner.
+	OIDCEnabled           bool   `json:"oidc_enabled" db:"oidc_enabled" form:"oidc_enabled"`
+	OIDCProviderName      string `json:"oidc_provider_name" db:"oidc_provider_name" form:"oidc_provider_name"`
+	OIDCIssuerURL         string `json:"oidc_issuer_url" db:"oidc_issuer_url" form:"oidc_issuer_url"`
+	OIDCClientID          string `json:"oidc_client_id" db:"oidc_client_id" form:"oidc_client_id"`
+	OIDCClientSecret      string `json:"-" db:"oidc_client_secret" form:"oidc_client_secret"`
+	OIDCOwnerClaim        string `json:"oidc_owner_claim" db:"oidc_owner_claim" form:"oidc_owner_claim"`
+	OIDCOwnerValue        string `json:"oidc_owner_value" db:"oidc_owner_value" form:"oidc_owner_value"`
+	PasswordLoginDisabled bool   `json:"password_login_disabled" db:"password_login_disabled" form:"password_login_disabled"`
+
+	// LDAP. LDAPUserFilter finds the entry of the email address signing in, which replaces every %s.
+	LDAPEnabled       bool   `json:"ldap_enabled" db:"ldap_enabled" form:"ldap_enabled"`
+	LDAPURL           string `json:"ldap_url" db:"ldap_url" form:"ldap_url"`
+	LDAPStartTLS      bool   `json:"ldap_start_tls" db:"ldap_start_tls" form:"ldap_start_tls"`
+	LDAPBindDN        string `json:"ldap_bind_dn" db:"ldap_bind_dn" form:"ldap_bind_dn"`
+	LDAPBindPassword  string `json:"-" db:"ldap_bind_password" form:"ldap_bind_password"`
+	LDAPBaseDN        string `json:"ldap_base_dn" db:"ldap_base_dn" form:"ldap_base_dn"`
+	LDAPUserFilter    string `json:"ldap_user_filter" db:"ldap_user_filter" form:"ldap_user_filter"`
+	LDAPNameAttribute string `json:"ldap_name_attribute" db:"ldap_name_attribute" form:"ldap_name_attribute"`
+	LDAPTeamAttribute string `json:"ldap_team_attribute" db:"ldap_team_attribute" form:"ldap_team_attribute"`
+	LDAPOwnerGroupDN  string `json:"ldap_owner_group_dn" db:"ldap_owner_group_dn" form:"ldap_owner_group_dn"`
+	LDAPHackerGroupDN string `json:"ldap_hacker_group_dn" db:"ldap_hacker_group_dn" form:"ldap_hacker_group_dn"`
+
 	// Legal & Compliance
 	TermsOfServiceURL string `json:"terms_of_service_url" db:"terms_of_service_url" form:"terms_of_service_url"`
 	PrivacyPolicyURL  string `json:"privacy_policy_url" db:"privacy_policy_url" form:"privacy_policy_url"`
@@ -87,6 +118,22 @@ func (c *CompanyConfiguration) Validate(tx *pop.Connection) (*validate.Errors, e
 				return c.DefaultUserRole == RoleHacker || c.DefaultUserRole == RoleOwner
 			},
 		},
+		&validators.FuncValidator{
+			Field:   "Single sign-on",
+			Name:    "OIDCIssuerURL",
+			Message: "%s needs an issuer URL and a client ID",
+			Fn: func() bool {
+				return !c.OIDCEnabled || (c.OIDCIssuerURL != "" && c.OIDCClientID != "")
+			},
+		},
+		&validators.FuncValidator{
+			Field:   "LDAP sign-in",
+			Name:    "LDAPURL",
+			Message: "%s needs a server URL, a base DN and a user filter with a %%s placeholder",
+			Fn: func() bool {
+				return !c.LDAPEnabled || c.LDAPConfigured()
+			},
+		},
 	), nil
 }
 
@@ -180,6 +227,96 @@ func (oldConfig *CompanyConfiguration) UpdateChangedFields(newConfig *CompanyCon
 		updated.SessionTimeoutMinutes = newConfig.SessionTimeoutMinutes
 		changed = true
 	}
+	if newConfig.SessionAbsoluteTimeoutMinutes != oldConfig.SessionAbsoluteTimeoutMinutes {
+		updated.SessionAbsoluteTimeoutMinutes = newConfig.SessionAbsoluteTimeoutMinutes
+		changed = true
+	}
+	if newConfig.LoginLockoutThreshold != oldConfig.LoginLockoutThreshold {
+		updated.LoginLockoutThreshold = newConfig.LoginLockoutThreshold
+		changed = true
+	}
+	if newConfig.LoginLockoutMinutes != oldConfig.LoginLockoutMinutes {
+		updated.LoginLockoutMinutes = newConfig.LoginLockoutMinutes
+		changed = true
+	}
+	if newConfig.OIDCEnabled != oldConfig.OIDCEnabled {
+		updated.OIDCEnabled = newConfig.OIDCEnabled
+		changed = true
+	}
+	if newConfig.OIDCProviderName != oldConfig.OIDCProviderName {
+		updated.OIDCProviderName = newConfig.OIDCProviderName
+		changed = true
+	}
+	if newConfig.OIDCIssuerURL != oldConfig.OIDCIssuerURL {
+		updated.OIDCIssuerURL = newConfig.OIDCIssuerURL
+		changed = true
+	}
+	if newConfig.OIDCClientID != oldConfig.OIDCClientID {
+		updated.OIDCClientID = newConfig.OIDCClientID
+		changed = true
+	}
+	// The secret is never sent back to the form, so an empty field keeps the stored one
+	if newConfig.OIDCClientSecret != "" && newConfig.OIDCClientSecret != oldConfig.OIDCClientSecret {
+		updated.OIDCClientSecret = newConfig.OIDCClientSecret
+		changed = true
+	}
+	if newConfig.OIDCOwnerClaim != oldConfig.OIDCOwnerClaim {
+		updated.OIDCOwnerClaim = newConfig.OIDCOwnerClaim
+		changed = true
+	}
+	if newConfig.OIDCOwnerValue != oldConfig.OIDCOwnerValue {
+		updated.OIDCOwnerValue = newConfig.OIDCOwnerValue
+		changed = true
+	}
+	if newConfig.PasswordLoginDisabled != oldConfig.PasswordLoginDisabled {
+		updated.PasswordLoginDisabled = newConfig.PasswordLoginDisabled
+		changed = true
+	}
+	if newConfig.LDAPEnabled != oldConfig.LDAPEnabled {
+		updated.LDAPEnabled = newConfig.LDAPEnabled
+		changed = true
+	}
+	if newConfig.LDAPURL != oldConfig.LDAPURL {
+		updated.LDAPURL = newConfig.LDAPURL
+		changed = true
+	}
+	if newConfig.LDAPStartTLS != oldConfig.LDAPStartTLS {
+		updated.LDAPStartTLS = newConfig.LDAPStartTLS
+		changed = true
+	}
+	if newConfig.LDAPBindDN != oldConfig.LDAPBindDN {
+		updated.LDAPBindDN = newConfig.LDAPBindDN
+		changed = true
+	}
+	// Like the OIDC client secret, an empty bind password field keeps the stored one
+	if newConfig.LDAPBindPassword != "" && newConfig.LDAPBindPassword != oldConfig.LDAPBindPassword {
+		updated.LDAPBindPassword = newConfig.LDAPBindPassword
+		changed = true
+	}
+	if newConfig.LDAPBaseDN != oldConfig.LDAPBaseDN {
+		updated.LDAPBaseDN = newConfig.LDAPBaseDN
+		changed = true
+	}
+	if newConfig.LDAPUserFilter != oldConfig.LDAPUserFilter {
+		updated.LDAPUserFilter = newConfig.LDAPUserFilter
+		changed = true
+	}
+	if newConfig.LDAPNameAttribute != oldConfig.LDAPNameAttribute {
+		updated.LDAPNameAttribute = newConfig.LDAPNameAttribute
+		changed = true
+	}
+	if newConfig.LDAPTeamAttribute != oldConfig.LDAPTeamAttribute {
+		updated.LDAPTeamAttribute = newConfig.LDAPTeamAttribute
+		changed = true
+	}
+	if newConfig.LDAPOwnerGroupDN != oldConfig.LDAPOwnerGroupDN {
+		updated.LDAPOwnerGroupDN = newConfig.LDAPOwnerGroupDN
+		changed = true
+	}
+	if newConfig.LDAPHackerGroupDN != oldConfig.LDAPHackerGroupDN {
+		updated.LDAPHackerGroupDN = newConfig.LDAPHackerGroupDN
+		changed = true
+	}
 	if newConfig.TwoFactorRequired != oldConfig.TwoFactorRequired {
 		updated.TwoFactorRequired = newConfig.TwoFactorRequired
 		changed = true
@@ -223,6 +360,54 @@ func (oldConfig *CompanyConfiguration) UpdateChangedFields(newConfig *CompanyCon
 	return changed
 }
 
+// SessionIdleTimeout returns how long a session may go unused before it ends
+func (c CompanyConfiguration) SessionIdleTimeout() time.Duration {
+	if c.SessionTimeoutMinutes <= 0 {
+		return 480 * time.Minute
+	}
+	return time.Duration(c.SessionTimeoutMinutes) * time.Minute
+}
+
+// SessionAbsoluteTimeout returns how long a session may last after sign in
+func (c CompanyConfiguration) SessionAbsoluteTimeout() time.Duration {
+	if c.SessionAbsoluteTimeoutMinutes <= 0 {
+		return 10080 * time.Minute
+	}
+	return time.Duration(c.SessionAbsoluteTimeoutMinutes) * time.Minute
+}
+
+// LoginLockoutDuration returns how long an account stays locked after too many failed sign-ins
+func (c CompanyConfiguration) LoginLockoutDuration() time.Duration {
+	if c.LoginLockoutMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.LoginLockoutMinutes) * time.Minute
+}
+
+// OIDCConfigured returns true when single sign-on is turned on and has the settings it needs
+func (c CompanyConfiguration) OIDCConfigured() bool {
+	return c.OIDCEnabled && c.OIDCIssuerURL != "" && c.OIDCClientID != ""
+}
+
+// OIDCDisplayName returns the name of the identity provider shown on the sign-in page
+func (c CompanyConfiguration) OIDCDisplayName() string {
+	if c.OIDCProviderName != "" {
+		return c.OIDCProviderName
+	}
+	return "single sign-on"
+}
+
+// LDAPConfigured returns true when LDAP sign-in is turned on and has the settings it needs
+func (c CompanyConfiguration) LDAPConfigured() bool {
+	return c.LDAPEnabled && c.LDAPURL != "" && c.LDAPBaseDN != "" && strings.Contains(c.LDAPUserFilter, "%s")
+}
+
+// PasswordLoginAllowed returns true when users may sign in with a password. Password
+// sign-in can only be turned off while single sign-on is available, so owners can't lock themselves out.
+func (c CompanyConfiguration) PasswordLoginAllowed() bool {
+	return !c.PasswordLoginDisabled || !c.OIDCConfigured()
+}
+
 // GetDefaultConfig returns the default company configuration, loading from DB if exists
 func GetDefaultConfig(tx *pop.Connection) (*CompanyConfiguration, error) {
 	config := &CompanyConfiguration{}
@@ -244,6 +429,12 @@ func GetDefaultConfig(tx *pop.Connection) (*CompanyConfiguration, error) {
 			PasswordRequireUppercase:      true,
 			PasswordRequireNumbers:        true,
 			SessionTimeoutMinutes:         480,
+			SessionAbsoluteTimeoutMinutes: 10080,
+			LoginLockoutThreshold:         5,
+			LoginLockoutMinutes:           15,
+			LDAPUserFilter:                "(mail=%s)",
+			LDAPNameAttribute:             "cn",
+			LDAPTeamAttribute:             "department",
 			DataRetentionDays:             2555, // ~7 years
 			FileUploadsEnabled:            true,
 			ProjectImagesEnabled:          true,
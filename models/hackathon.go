@@ -13,16 +13,85 @@ import (
 
 // Hackathon represents a hackathon event
 type Hackathon struct {
-	ID          string       `json:"id" db:"id"`
-	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
-	Title       string       `json:"title" db:"title"`
-	Description string       `json:"description" db:"description"`
-	StartDate   time.Time    `json:"start_date" db:"start_date"`
-	EndDate     time.Time    `json:"end_date" db:"end_date"`
-	Status      string       `json:"status" db:"status"`
-	OwnerID     uuid.UUID    `json:"owner_id" db:"owner_id"`
-	Schedule    nulls.String `json:"schedule" db:"schedule"`
+	ID          string    `json:"id" db:"id"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	StartDate   time.Time `json:"start_date" db:"start_date"`
+	EndDate     time.Time `json:"end_date" db:"end_date"`
+	Status      string    `json:"status" db:"status"`
+	OwnerID     uuid.UUID `json:"owner_id" db:"owner_id"`
+
+	ScheduleItems ScheduleItems `json:"schedule_items,omitempty" has_many:"schedule_items" order_by:"starts_at asc, position asc"`
+
+	ResultsPublished bool `json:"results_published" db:"results_published"`
+	VotingOpen       bool `json:"voting_open" db:"voting_open"`
+	VotesPerUser     int  `json:"votes_per_user" db:"votes_per_user"`
+	AwardsPublished  bool `json:"awards_published" db:"awards_published"`
+	OpenTeams        bool `json:"open_teams" db:"open_teams"`
+
+	// MaxTeamSize overrides CompanyConfiguration.MaxTeamSize when set
+	MaxTeamSize nulls.Int `json:"max_team_size" db:"max_team_size"`
+
+	// SubmissionDeadline freezes projects before EndDate when set
+	SubmissionDeadline nulls.Time `json:"submission_deadline" db:"submission_deadline"`
+
+	// VotingClosedAt is when people's-choice voting was last closed, unset until it has run
+	VotingClosedAt nulls.Time `json:"voting_closed_at" db:"voting_closed_at" form:"-"`
+}
+
+// Hackathon statuses
+const (
+	HackathonStatusUpcoming  = "upcoming"
+	HackathonStatusActive    = "active"
+	HackathonStatusCompleted = "completed"
+	HackathonStatusHidden    = "hidden"
+)
+
+// NextStatus returns the status the hackathon should move to at the given time,
+// or an empty string if it should stay as it is. Only upcoming and active hackathons
+// move forward on their dates; hidden and completed ones are never changed.
+func (h Hackathon) NextStatus(now time.Time) string {
+	switch h.Status {
+	case HackathonStatusUpcoming:
+		if !now.Before(h.EndDate) {
+			return HackathonStatusCompleted
+		}
+		if !now.Before(h.StartDate) {
+			return HackathonStatusActive
+		}
+	case HackathonStatusActive:
+		if !now.Before(h.EndDate) {
+			return HackathonStatusCompleted
+		}
+	}
+	return ""
+}
+
+// SubmissionsCloseAt returns when projects of the hackathon are frozen: the organizer's
+// submission deadline if there is one, the end of the hackathon otherwise
+func (h Hackathon) SubmissionsCloseAt() time.Time {
+	if h.SubmissionDeadline.Valid {
+		return h.SubmissionDeadline.Time
+	}
+	return h.EndDate
+}
+
+// VotingClosed returns true once people's-choice voting has been opened and closed again
+func (h Hackathon) VotingClosed() bool {
+	return !h.VotingOpen && h.VotingClosedAt.Valid
+}
+
+// DefaultVotesPerUser is the people's-choice vote budget of a new hackathon
+const DefaultVotesPerUser = 3
+
+// TeamSizeLimit returns the maximum number of members per project, preferring the hackathon's override
+func (h Hackathon) TeamSizeLimit(config *CompanyConfiguration) int {
+	if h.MaxTeamSize.Valid && h.MaxTeamSize.Int > 0 {
+		return h.MaxTeamSize.Int
+	}
+	return config.MaxTeamSize
 }
 
 // String is not required by pop and may be deleted
@@ -48,6 +117,21 @@ func (h *Hackathon) Validate(tx *pop.Connection) (*validate.Errors, error) {
 		&validators.TimeIsPresent{Field: h.StartDate, Name: "StartDate"},
 		&validators.TimeIsPresent{Field: h.EndDate, Name: "EndDate"},
 		&validators.UUIDIsPresent{Field: h.OwnerID, Name: "OwnerID"},
+		&validators.IntIsGreaterThan{Field: h.VotesPerUser, Name: "VotesPerUser", Compared: 0},
+		&validators.FuncValidator{
+			Name:    "MaxTeamSize",
+			Message: "Max team size must be at least 1",
+			Fn: func() bool {
+				return !h.MaxTeamSize.Valid || h.MaxTeamSize.Int > 0
+			},
+		},
+		&validators.FuncValidator{
+			Name:    "SubmissionDeadline",
+			Message: "Submission deadline must be after the start date",
+			Fn: func() bool {
+				return !h.SubmissionDeadline.Valid || h.SubmissionDeadline.Time.After(h.StartDate)
+			},
+		},
 		&validators.FuncValidator{
 			Field:   h.Status,
 			Name:    "Status",
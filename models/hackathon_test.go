@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+)
+
+func TestHackathonVotingClosed(t *testing.T) {
+	closedAt := nulls.NewTime(time.Now())
+	tests := []struct {
+		name      string
+		hackathon Hackathon
+		want      bool
+	}{
+		{"never opened", Hackathon{}, false},
+		{"open for the first time", Hackathon{VotingOpen: true}, false},
+		{"closed", Hackathon{VotingClosedAt: closedAt}, true},
+		{"reopened", Hackathon{VotingOpen: true, VotingClosedAt: closedAt}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.hackathon.VotingClosed(); got != tt.want {
+			t.Errorf("%s: VotingClosed() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHackathonNextStatus(t *testing.T) {
+	start := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+	before, during, after := start.Add(-time.Minute), start.Add(time.Hour), end.Add(time.Minute)
+
+	tests := []struct {
+		status string
+		now    time.Time
+		want   string
+	}{
+		{HackathonStatusUpcoming, before, ""},
+		{HackathonStatusUpcoming, start, HackathonStatusActive},
+		{HackathonStatusUpcoming, during, HackathonStatusActive},
+		{HackathonStatusUpcoming, end, HackathonStatusCompleted},
+		{HackathonStatusUpcoming, after, HackathonStatusCompleted},
+		{HackathonStatusActive, before, ""},
+		{HackathonStatusActive, during, ""},
+		{HackathonStatusActive, end, HackathonStatusCompleted},
+		{HackathonStatusActive, after, HackathonStatusCompleted},
+		{HackathonStatusCompleted, before, ""},
+		{HackathonStatusCompleted, during, ""},
+		{HackathonStatusCompleted, after, ""},
+		{HackathonStatusHidden, before, ""},
+		{HackathonStatusHidden, during, ""},
+		{HackathonStatusHidden, after, ""},
+	}
+	for _, tt := range tests {
+		hackathon := Hackathon{Status: tt.status, StartDate: start, EndDate: end}
+		if got := hackathon.NextStatus(tt.now); got != tt.want {
+			t.Errorf("NextStatus of %s hackathon at %s = %q, want %q", tt.status, tt.now.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Invitation is a registration link an owner hands out when public registration is closed.
+// Accounts created with it get its role and company team. A MaxUses of 0 means the link can
+// be used any number of times until it expires.
+type Invitation struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	Note        string     `json:"note" db:"note"`
+	Role        string     `json:"role" db:"role"`
+	CompanyTeam string     `json:"company_team" db:"company_team"`
+	MaxUses     int        `json:"max_uses" db:"max_uses"`
+	UseCount    int        `json:"use_count" db:"use_count"`
+	ExpiresAt   nulls.Time `json:"expires_at" db:"expires_at"`
+	CreatedByID nulls.UUID `json:"created_by_id" db:"created_by_id"`
+
+	// HackathonID scopes the invitation to a hackathon, it stops working once the hackathon is completed
+	HackathonID nulls.String `json:"hackathon_id" db:"hackathon_id"`
+}
+
+// IsExpired returns true when the invitation has an expiry that has passed
+func (i Invitation) IsExpired(now time.Time) bool {
+	return i.ExpiresAt.Valid && !now.Before(i.ExpiresAt.Time)
+}
+
+// IsUsedUp returns true when a limited invitation has been used as often as allowed
+func (i Invitation) IsUsedUp() bool {
+	return i.MaxUses > 0 && i.UseCount >= i.MaxUses
+}
+
+// IsUsable returns true when the invitation can still create accounts
+func (i Invitation) IsUsable(now time.Time) bool {
+	return !i.IsExpired(now) && !i.IsUsedUp()
+}
+
+// String is not required by pop and may be deleted
+func (i Invitation) String() string {
+	ji, _ := json.Marshal(i)
+	return string(ji)
+}
+
+// Invitations is not required by pop and may be deleted
+type Invitations []Invitation
+
+// String is not required by pop and may be deleted
+func (i Invitations) String() string {
+	ji, _ := json.Marshal(i)
+	return string(ji)
+}
+
+// Validate runs on Validate* calls
+func (i *Invitation) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.FuncValidator{
+			Field:   i.Role,
+			Name:    "Role",
+			Message: "%s is not a valid role",
+			Fn: func() bool {
+				return IsValidRole(i.Role)
+			},
+		},
+		&validators.FuncValidator{
+			Name:    "MaxUses",
+			Message: "Uses can't be negative",
+			Fn: func() bool {
+				return i.MaxUses >= 0
+			},
+		},
+	), nil
+}
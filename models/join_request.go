@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Team request statuses shared by join requests and project invitations
+const (
+	TeamRequestPending  = "pending"
+	TeamRequestApproved = "approved"
+	TeamRequestDeclined = "declined"
+	TeamRequestAccepted = "accepted"
+)
+
+// JoinRequest is a user's request to join a project, decided by the project owner
+type JoinRequest struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	ProjectID string   `json:"project_id" db:"project_id"`
+	Project   *Project `json:"project,omitempty" belongs_to:"project" fk_id:"project_id"`
+
+	UserID uuid.UUID `json:"user_id" db:"user_id"`
+	User   *User     `json:"user,omitempty" belongs_to:"user" fk_id:"user_id"`
+
+	Message     nulls.String `json:"message" db:"message"`
+	Status      string       `json:"status" db:"status"`
+	DecidedByID nulls.UUID   `json:"decided_by_id" db:"decided_by_id"`
+}
+
+// String is not required by pop and may be deleted
+func (j JoinRequest) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// JoinRequests is not required by pop and may be deleted
+type JoinRequests []JoinRequest
+
+// String is not required by pop and may be deleted
+func (j JoinRequests) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// Validate runs on Validate* calls
+func (j *JoinRequest) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: j.ProjectID, Name: "ProjectID"},
+		&validators.UUIDIsPresent{Field: j.UserID, Name: "UserID"},
+		&validators.StringInclusion{Field: j.Status, Name: "Status", List: []string{TeamRequestPending, TeamRequestApproved, TeamRequestDeclined}},
+	), nil
+}
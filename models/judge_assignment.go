@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// JudgeAssignment assigns a judge to score a project
+type JudgeAssignment struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	HackathonID string `json:"hackathon_id" db:"hackathon_id"`
+
+	ProjectID string   `json:"project_id" db:"project_id"`
+	Project   *Project `json:"project,omitempty" belongs_to:"project" fk_id:"project_id"`
+
+	JudgeID uuid.UUID `json:"judge_id" db:"judge_id"`
+	Judge   *User     `json:"judge,omitempty" belongs_to:"user" fk_id:"judge_id"`
+}
+
+// String is not required by pop and may be deleted
+func (j JudgeAssignment) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// JudgeAssignments is not required by pop and may be deleted
+type JudgeAssignments []JudgeAssignment
+
+// String is not required by pop and may be deleted
+func (j JudgeAssignments) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// Validate runs on Validate* calls
+func (j *JudgeAssignment) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: j.HackathonID, Name: "HackathonID"},
+		&validators.StringIsPresent{Field: j.ProjectID, Name: "ProjectID"},
+		&validators.UUIDIsPresent{Field: j.JudgeID, Name: "JudgeID"},
+	), nil
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Score bounds accepted for a single criterion
+const (
+	JudgeScoreMin = 1
+	JudgeScoreMax = 10
+)
+
+// JudgeScore is the score a judge gave a project for one criterion
+type JudgeScore struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	ProjectID   string       `json:"project_id" db:"project_id"`
+	CriterionID uuid.UUID    `json:"criterion_id" db:"criterion_id"`
+	JudgeID     uuid.UUID    `json:"judge_id" db:"judge_id"`
+	Score       int          `json:"score" db:"score"`
+	Comment     nulls.String `json:"comment" db:"comment"`
+}
+
+// String is not required by pop and may be deleted
+func (j JudgeScore) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// JudgeScores is not required by pop and may be deleted
+type JudgeScores []JudgeScore
+
+// String is not required by pop and may be deleted
+func (j JudgeScores) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// Validate runs on Validate* calls
+func (j *JudgeScore) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: j.ProjectID, Name: "ProjectID"},
+		&validators.UUIDIsPresent{Field: j.CriterionID, Name: "CriterionID"},
+		&validators.UUIDIsPresent{Field: j.JudgeID, Name: "JudgeID"},
+		&validators.FuncValidator{
+			Field:   j.CriterionID.String(),
+			Name:    "Score",
+			Message: "Score must be between 1 and 10",
+			Fn: func() bool {
+				return j.Score >= JudgeScoreMin && j.Score <= JudgeScoreMax
+			},
+		},
+	), nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// JudgingCriterion is a weighted rubric line used to score projects of a hackathon
+type JudgingCriterion struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	HackathonID string       `json:"hackathon_id" db:"hackathon_id"`
+	Name        string       `json:"name" db:"name"`
+	Description nulls.String `json:"description" db:"description"`
+	Weight      int          `json:"weight" db:"weight"`
+}
+
+// String is not required by pop and may be deleted
+func (j JudgingCriterion) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// JudgingCriteria is not required by pop and may be deleted
+type JudgingCriteria []JudgingCriterion
+
+// String is not required by pop and may be deleted
+func (j JudgingCriteria) String() string {
+	jj, _ := json.Marshal(j)
+	return string(jj)
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (j *JudgingCriterion) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: j.HackathonID, Name: "HackathonID"},
+		&validators.StringIsPresent{Field: j.Name, Name: "Name"},
+		&validators.IntIsGreaterThan{Field: j.Weight, Name: "Weight", Compared: 0},
+	), nil
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gofrs/uuid"
+)
+
+// ProjectResult is the aggregated judging outcome for a single project
+type ProjectResult struct {
+	Rank       int     `json:"rank"`
+	Project    Project `json:"project"`
+	Score      float64 `json:"score"`
+	JudgeCount int     `json:"judge_count"`
+}
+
+// FormattedScore returns the score rounded to two decimals for display
+func (p ProjectResult) FormattedScore() string {
+	if p.JudgeCount == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", p.Score)
+}
+
+// ProjectResults is a ranked list of project results
+type ProjectResults []ProjectResult
+
+// RankProjects aggregates judge scores into a ranked list.
+// Each judge's scores are combined into a weighted average using the criterion
+// weights, and a project's score is the mean of its judges' weighted averages.
+// Projects without scores are listed last and left unranked.
+func RankProjects(projects Projects, criteria JudgingCriteria, scores JudgeScores) ProjectResults {
+	weights := make(map[uuid.UUID]int, len(criteria))
+	for _, criterion := range criteria {
+		weights[criterion.ID] = criterion.Weight
+	}
+
+	type judgeTotal struct {
+		weighted int
+		weight   int
+	}
+	totals := make(map[string]map[uuid.UUID]*judgeTotal)
+	for _, score := range scores {
+		weight, ok := weights[score.CriterionID]
+		if !ok {
+			continue
+		}
+		if totals[score.ProjectID] == nil {
+			totals[score.ProjectID] = make(map[uuid.UUID]*judgeTotal)
+		}
+		t := totals[score.ProjectID][score.JudgeID]
+		if t == nil {
+			t = &judgeTotal{}
+			totals[score.ProjectID][score.JudgeID] = t
+		}
+		t.weighted += score.Score * weight
+		t.weight += weight
+	}
+
+	results := make(ProjectResults, 0, len(projects))
+	for _, project := range projects {
+		result := ProjectResult{Project: project}
+		var sum float64
+		for _, t := range totals[project.ID] {
+			if t.weight == 0 {
+				continue
+			}
+			sum += float64(t.weighted) / float64(t.weight)
+			result.JudgeCount++
+		}
+		if result.JudgeCount > 0 {
+			result.Score = sum / float64(result.JudgeCount)
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].JudgeCount > 0) != (results[j].JudgeCount > 0) {
+			return results[i].JudgeCount > 0
+		}
+		return results[i].Score > results[j].Score
+	})
+
+	for i := range results {
+		if results[i].JudgeCount == 0 {
+			break
+		}
+		if i > 0 && results[i].Score == results[i-1].Score {
+			results[i].Rank = results[i-1].Rank
+		} else {
+			results[i].Rank = i + 1
+		}
+	}
+	return results
+}
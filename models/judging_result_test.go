@@ -0,0 +1,108 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestRankProjects(t *testing.T) {
+	design := JudgingCriterion{ID: uuid.Must(uuid.NewV4()), Weight: 1}
+	impact := JudgingCriterion{ID: uuid.Must(uuid.NewV4()), Weight: 3}
+	criteria := JudgingCriteria{design, impact}
+	alice, bob := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+
+	score := func(projectID string, judgeID uuid.UUID, criterion JudgingCriterion, value int) JudgeScore {
+		return JudgeScore{ProjectID: projectID, JudgeID: judgeID, CriterionID: criterion.ID, Score: value}
+	}
+
+	tests := []struct {
+		name   string
+		scores JudgeScores
+		want   map[string]ProjectResult
+		order  []string
+	}{
+		{
+			name: "weighted average of one judge",
+			scores: JudgeScores{
+				// (10*1 + 2*3) / 4 = 4 and (2*1 + 10*3) / 4 = 8
+				score("a", alice, design, 10), score("a", alice, impact, 2),
+				score("b", alice, design, 2), score("b", alice, impact, 10),
+			},
+			want: map[string]ProjectResult{
+				"a": {Rank: 2, Score: 4, JudgeCount: 1},
+				"b": {Rank: 1, Score: 8, JudgeCount: 1},
+				"c": {Rank: 0, Score: 0, JudgeCount: 0},
+			},
+			order: []string{"b", "a", "c"},
+		},
+		{
+			name: "mean of the judges",
+			scores: JudgeScores{
+				// Alice gives 4, Bob scored only impact and gives 10
+				score("a", alice, design, 10), score("a", alice, impact, 2),
+				score("a", bob, impact, 10),
+				score("b", bob, design, 6), score("b", bob, impact, 6),
+			},
+			want: map[string]ProjectResult{
+				"a": {Rank: 1, Score: 7, JudgeCount: 2},
+				"b": {Rank: 2, Score: 6, JudgeCount: 1},
+				"c": {Rank: 0, Score: 0, JudgeCount: 0},
+			},
+			order: []string{"a", "b", "c"},
+		},
+		{
+			name: "ties share a rank",
+			scores: JudgeScores{
+				score("a", alice, design, 5), score("b", bob, impact, 5), score("c", alice, design, 3),
+			},
+			want: map[string]ProjectResult{
+				"a": {Rank: 1, Score: 5, JudgeCount: 1},
+				"b": {Rank: 1, Score: 5, JudgeCount: 1},
+				"c": {Rank: 3, Score: 3, JudgeCount: 1},
+			},
+			order: []string{"a", "b", "c"},
+		},
+		{
+			name: "scores of removed criteria are ignored",
+			scores: JudgeScores{
+				score("a", alice, JudgingCriterion{ID: uuid.Must(uuid.NewV4()), Weight: 1}, 10),
+				score("b", alice, design, 1),
+			},
+			want: map[string]ProjectResult{
+				"a": {Rank: 0, Score: 0, JudgeCount: 0},
+				"b": {Rank: 1, Score: 1, JudgeCount: 1},
+				"c": {Rank: 0, Score: 0, JudgeCount: 0},
+			},
+			order: []string{"b", "a", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := RankProjects(Projects{{ID: "a"}, {ID: "b"}, {ID: "c"}}, criteria, tt.scores)
+			if len(results) != len(tt.order) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.order))
+			}
+			for i, result := range results {
+				if result.Project.ID != tt.order[i] {
+					t.Errorf("result %d is project %s, want %s", i, result.Project.ID, tt.order[i])
+				}
+				want := tt.want[result.Project.ID]
+				if result.Rank != want.Rank || result.Score != want.Score || result.JudgeCount != want.JudgeCount {
+					t.Errorf("project %s: rank %d, score %v, judges %d; want rank %d, score %v, judges %d",
+						result.Project.ID, result.Rank, result.Score, result.JudgeCount, want.Rank, want.Score, want.JudgeCount)
+				}
+			}
+		})
+	}
+}
+
+func TestProjectResultFormattedScore(t *testing.T) {
+	if got := (ProjectResult{}).FormattedScore(); got != "-" {
+		t.Errorf("FormattedScore without judges = %q, want %q", got, "-")
+	}
+	if got := (ProjectResult{Score: 20.0 / 3, JudgeCount: 2}).FormattedScore(); got != "6.67" {
+		t.Errorf("FormattedScore = %q, want %q", got, "6.67")
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// LoginAttempt records a failed sign-in. Attempts are kept by email address rather than
+// by user so unknown addresses are throttled and locked exactly like real accounts.
+type LoginAttempt struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	Email     string `json:"email" db:"email"`
+	IPAddress string `json:"ip_address" db:"ip_address"`
+}
+
+// String is not required by pop and may be deleted
+func (l LoginAttempt) String() string {
+	jl, _ := json.Marshal(l)
+	return string(jl)
+}
+
+// LoginAttempts is not required by pop and may be deleted
+type LoginAttempts []LoginAttempt
+
+// String is not required by pop and may be deleted
+func (l LoginAttempts) String() string {
+	jl, _ := json.Marshal(l)
+	return string(jl)
+}
+
+// Validate runs on Validate* calls
+func (l *LoginAttempt) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: l.Email, Name: "Email"},
+	), nil
+}
+
+// LockedAccount is an account that failed to sign in too many times
+type LockedAccount struct {
+	Email        string    `json:"email" db:"email"`
+	UserID       string    `json:"user_id" db:"user_id"`
+	Name         string    `json:"name" db:"name"`
+	Failures     int       `json:"failures" db:"failures"`
+	LastFailedAt time.Time `json:"last_failed_at" db:"last_failed_at"`
+}
+
+// LockedAccounts is a collection of LockedAccount
+type LockedAccounts []LockedAccount
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// PasswordResetTTL is how long an emailed reset link can be used
+const PasswordResetTTL = time.Hour
+
+// PasswordReset records a forgot-password request. Requests for unknown addresses are
+// kept without a user or token so they still count towards rate limits.
+type PasswordReset struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	UserID    nulls.UUID   `json:"user_id" db:"user_id"`
+	Email     string       `json:"email" db:"email"`
+	TokenHash nulls.String `json:"-" db:"token_hash"`
+	IPAddress string       `json:"ip_address" db:"ip_address"`
+	ExpiresAt time.Time    `json:"expires_at" db:"expires_at"`
+	UsedAt    nulls.Time   `json:"used_at" db:"used_at"`
+}
+
+// HashPasswordResetToken returns the stored form of a reset token
+func HashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsUsable returns true when the reset link has not been used and has not expired
+func (p PasswordReset) IsUsable(now time.Time) bool {
+	return p.UserID.Valid && p.TokenHash.Valid && !p.UsedAt.Valid && now.Before(p.ExpiresAt)
+}
+
+// String is not required by pop and may be deleted
+func (p PasswordReset) String() string {
+	jp, _ := json.Marshal(p)
+	return string(jp)
+}
+
+// PasswordResets is not required by pop and may be deleted
+type PasswordResets []PasswordReset
+
+// String is not required by pop and may be deleted
+func (p PasswordResets) String() string {
+	jp, _ := json.Marshal(p)
+	return string(jp)
+}
+
+// Validate runs on Validate* calls
+func (p *PasswordReset) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: p.Email, Name: "Email"},
+		&validators.TimeIsPresent{Field: p.ExpiresAt, Name: "ExpiresAt"},
+	), nil
+}
@@ -7,12 +7,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gobuffalo/validate/v3"
 	"github.com/gobuffalo/validate/v3/validators"
 	"github.com/gofrs/uuid"
 )
 
+// Review states of a project submission
+const (
+	ProjectReviewPending  = "pending_review"
+	ProjectReviewApproved = "approved"
+	ProjectReviewRejected = "rejected"
+)
+
 // Project represents a hackathon project submission
 type Project struct {
 	ID                string     `json:"id" db:"id"`
@@ -31,6 +39,15 @@ type Project struct {
 	ImageContentType  *string    `json:"image_content_type" db:"image_content_type"`
 	Presenting        bool       `json:"presenting" db:"presenting"`
 	PresentationOrder *time.Time `json:"presentation_order" db:"presentation_order"`
+
+	// Review fields are decided by organizers and never bound from forms
+	ReviewStatus string       `json:"review_status" db:"review_status" form:"-"`
+	ReviewReason nulls.String `json:"review_reason" db:"review_reason" form:"-"`
+	ReviewedByID nulls.UUID   `json:"reviewed_by_id" db:"reviewed_by_id" form:"-"`
+	ReviewedAt   nulls.Time   `json:"reviewed_at" db:"reviewed_at" form:"-"`
+
+	// ExtendedUntil is an organizer-granted extension of the hackathon's submission deadline
+	ExtendedUntil nulls.Time `json:"extended_until" db:"extended_until" form:"-"`
 }
 
 // String is not required by pop and may be deleted
@@ -39,6 +56,35 @@ func (p Project) String() string {
 	return string(jp)
 }
 
+// IsApproved returns true if the project is visible to everyone
+func (p Project) IsApproved() bool {
+	return p.ReviewStatus == "" || p.ReviewStatus == ProjectReviewApproved
+}
+
+// IsPendingReview returns true if the project is waiting for an organizer's decision
+func (p Project) IsPendingReview() bool {
+	return p.ReviewStatus == ProjectReviewPending
+}
+
+// IsRejected returns true if an organizer rejected the project
+func (p Project) IsRejected() bool {
+	return p.ReviewStatus == ProjectReviewRejected
+}
+
+// SubmissionsCloseAt returns when the project is frozen, taking its extension into account
+func (p Project) SubmissionsCloseAt(h Hackathon) time.Time {
+	closeAt := h.SubmissionsCloseAt()
+	if p.ExtendedUntil.Valid && p.ExtendedUntil.Time.After(closeAt) {
+		return p.ExtendedUntil.Time
+	}
+	return closeAt
+}
+
+// IsFrozen returns true if the project can no longer be edited, uploaded to or have its team changed
+func (p Project) IsFrozen(h Hackathon, now time.Time) bool {
+	return !now.Before(p.SubmissionsCloseAt(h))
+}
+
 // Projects is not required by pop and may be deleted
 type Projects []Project
 
@@ -65,6 +111,9 @@ func (p *Project) ValidateCreate(tx *pop.Connection) (*validate.Errors, error) {
 	if p.ID == "" {
 		p.ID = generateUniqueID()
 	}
+	if p.ReviewStatus == "" {
+		p.ReviewStatus = ProjectReviewApproved
+	}
 
 	// Ensure a user is set on creation
 	if p.UserID == nil {
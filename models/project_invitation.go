@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// ProjectInvitation invites a user, identified by email, to join a project
+type ProjectInvitation struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	ProjectID string   `json:"project_id" db:"project_id"`
+	Project   *Project `json:"project,omitempty" belongs_to:"project" fk_id:"project_id"`
+
+	Email       string    `json:"email" db:"email"`
+	InvitedByID uuid.UUID `json:"invited_by_id" db:"invited_by_id"`
+	Status      string    `json:"status" db:"status"`
+}
+
+// String is not required by pop and may be deleted
+func (p ProjectInvitation) String() string {
+	jp, _ := json.Marshal(p)
+	return string(jp)
+}
+
+// ProjectInvitations is not required by pop and may be deleted
+type ProjectInvitations []ProjectInvitation
+
+// String is not required by pop and may be deleted
+func (p ProjectInvitations) String() string {
+	jp, _ := json.Marshal(p)
+	return string(jp)
+}
+
+// IsFor returns true if the invitation was sent to the user's email address
+func (p ProjectInvitation) IsFor(user User) bool {
+	return strings.EqualFold(p.Email, user.Email)
+}
+
+// Validate runs on Validate* calls
+func (p *ProjectInvitation) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: p.ProjectID, Name: "ProjectID"},
+		&validators.EmailIsPresent{Field: p.Email, Name: "Email"},
+		&validators.UUIDIsPresent{Field: p.InvitedByID, Name: "InvitedByID"},
+		&validators.StringInclusion{Field: p.Status, Name: "Status", List: []string{TeamRequestPending, TeamRequestAccepted, TeamRequestDeclined}},
+	), nil
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// RecoveryCode is a single-use code that signs a user in when their authenticator app is unavailable
+type RecoveryCode struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	UserID   uuid.UUID  `json:"user_id" db:"user_id"`
+	CodeHash string     `json:"-" db:"code_hash"`
+	UsedAt   nulls.Time `json:"used_at" db:"used_at"`
+}
+
+// HashRecoveryCode returns the stored form of a recovery code. Codes are random and
+// long enough that a plain SHA-256 digest cannot be brute-forced.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// String is not required by pop and may be deleted
+func (r RecoveryCode) String() string {
+	jr, _ := json.Marshal(r)
+	return string(jr)
+}
+
+// RecoveryCodes is not required by pop and may be deleted
+type RecoveryCodes []RecoveryCode
+
+// String is not required by pop and may be deleted
+func (r RecoveryCodes) String() string {
+	jr, _ := json.Marshal(r)
+	return string(jr)
+}
+
+// Validate runs on Validate* calls
+func (r *RecoveryCode) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: r.UserID, Name: "UserID"},
+		&validators.StringIsPresent{Field: r.CodeHash, Name: "CodeHash"},
+	), nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Schedule item types
+const (
+	ScheduleItemKickoff  = "kickoff"
+	ScheduleItemWorkshop = "workshop"
+	ScheduleItemDeadline = "deadline"
+	ScheduleItemDemo     = "demo"
+)
+
+// ScheduleItemTypes lists the valid schedule item types in display order
+var ScheduleItemTypes = []string{ScheduleItemKickoff, ScheduleItemWorkshop, ScheduleItemDeadline, ScheduleItemDemo}
+
+// ScheduleItem is a single entry of a hackathon's event schedule
+type ScheduleItem struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	HackathonID string       `json:"hackathon_id" db:"hackathon_id"`
+	Title       string       `json:"title" db:"title"`
+	ItemType    string       `json:"item_type" db:"item_type"`
+	StartsAt    time.Time    `json:"starts_at" db:"starts_at"`
+	EndsAt      nulls.Time   `json:"ends_at" db:"ends_at"`
+	Location    nulls.String `json:"location" db:"location"`
+	Link        nulls.String `json:"link" db:"link"`
+	Position    int          `json:"position" db:"position"`
+}
+
+// String is not required by pop and may be deleted
+func (s ScheduleItem) String() string {
+	js, _ := json.Marshal(s)
+	return string(js)
+}
+
+// ScheduleItems is not required by pop and may be deleted
+type ScheduleItems []ScheduleItem
+
+// String is not required by pop and may be deleted
+func (s ScheduleItems) String() string {
+	js, _ := json.Marshal(s)
+	return string(js)
+}
+
+// Icon returns the Font Awesome icon of the item type
+func (s ScheduleItem) Icon() string {
+	switch s.ItemType {
+	case ScheduleItemKickoff:
+		return "fa-flag-checkered"
+	case ScheduleItemDeadline:
+		return "fa-hourglass-end"
+	case ScheduleItemDemo:
+		return "fa-desktop"
+	default:
+		return "fa-chalkboard-teacher"
+	}
+}
+
+// Validate runs on Validate* calls
+func (s *ScheduleItem) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: s.HackathonID, Name: "HackathonID"},
+		&validators.StringIsPresent{Field: s.Title, Name: "Title"},
+		&validators.TimeIsPresent{Field: s.StartsAt, Name: "StartsAt"},
+		&validators.StringInclusion{Field: s.ItemType, Name: "ItemType", List: ScheduleItemTypes},
+		&validators.FuncValidator{
+			Name:    "EndsAt",
+			Message: "End time must be after the start time",
+			Fn: func() bool {
+				return !s.EndsAt.Valid || s.EndsAt.Time.After(s.StartsAt)
+			},
+		},
+	), nil
+}
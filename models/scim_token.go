@@ -0,0 +1,56 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// SCIMToken is a bearer token an identity provider provisions users with. Only a hash of
+// the token is kept, it is shown once when an owner creates it.
+type SCIMToken struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	Name        string     `json:"name" db:"name"`
+	TokenHash   string     `json:"-" db:"token_hash"`
+	CreatedByID nulls.UUID `json:"created_by_id" db:"created_by_id"`
+	LastUsedAt  nulls.Time `json:"last_used_at" db:"last_used_at"`
+}
+
+// HashSCIMToken returns the stored form of a SCIM bearer token
+func HashSCIMToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// String is not required by pop and may be deleted
+func (s SCIMToken) String() string {
+	js, _ := json.Marshal(s)
+	return string(js)
+}
+
+// SCIMTokens is not required by pop and may be deleted
+type SCIMTokens []SCIMToken
+
+// String is not required by pop and may be deleted
+func (s SCIMTokens) String() string {
+	js, _ := json.Marshal(s)
+	return string(js)
+}
+
+// Validate runs on Validate* calls
+func (s *SCIMToken) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: s.Name, Name: "Name"},
+		&validators.StringIsPresent{Field: s.TokenHash, Name: "TokenHash"},
+	), nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gobuffalo/validate/v3"
 	"github.com/gobuffalo/validate/v3/validators"
@@ -18,8 +19,14 @@ import (
 const (
 	RoleOwner  = "owner"
 	RoleHacker = "hacker"
+	RoleJudge  = "judge"
 )
 
+// IsValidRole returns true if role is one of the known user roles.
+func IsValidRole(role string) bool {
+	return role == RoleOwner || role == RoleHacker || role == RoleJudge
+}
+
 // User represents a registered account in the system.
 type User struct {
 	ID                   uuid.UUID `db:"id" json:"id"`
@@ -28,11 +35,38 @@ type User struct {
 	Email                string    `db:"email" json:"email"`
 	Name                 string    `db:"name" json:"name"`
 	CompanyTeam          string    `db:"company_team" json:"company_team"`
-	Role                 string    `db:"role" json:"role"`
+	Role                 string    `db:"role" json:"role" form:"-"`
 	PasswordHash         string    `db:"password_hash" json:"-"`
 	Password             string    `db:"-" json:"password"`
 	PasswordConfirmation string    `db:"-" json:"password_confirmation"`
 	ForcePasswordReset   bool      `db:"force_password_reset" json:"force_password_reset"`
+
+	CalendarToken nulls.String `db:"calendar_token" json:"-" form:"-"`
+
+	// TOTPSecret is only set once the user confirmed enrollment with a valid code
+	TOTPSecret   nulls.String `db:"totp_secret" json:"-" form:"-"`
+	TOTPEnabled  bool         `db:"totp_enabled" json:"totp_enabled" form:"-"`
+	TOTPLastStep nulls.Int64  `db:"totp_last_step" json:"-" form:"-"`
+
+	EmailVerifiedAt nulls.Time `db:"email_verified_at" json:"email_verified_at" form:"-"`
+
+	// PasswordChangedAt records when the password was last reset from an emailed link
+	PasswordChangedAt nulls.Time `db:"password_changed_at" json:"-" form:"-"`
+
+	// OIDCSubject links the account to its identity at the single sign-on provider
+	OIDCSubject nulls.String `db:"oidc_subject" json:"-" form:"-"`
+
+	// LDAPDN is the directory entry of accounts that sign in through LDAP
+	LDAPDN nulls.String `db:"ldap_dn" json:"-" form:"-"`
+
+	// DeactivatedAt is set when the account was switched off, deactivated users can't sign in
+	DeactivatedAt nulls.Time `db:"deactivated_at" json:"deactivated_at" form:"-"`
+
+	// SCIMExternalID is the ID the identity provider that provisioned the account knows it by
+	SCIMExternalID nulls.String `db:"scim_external_id" json:"-" form:"-"`
+
+	// IsServiceAccount marks accounts for automation, they can't sign in and only use API tokens
+	IsServiceAccount bool `db:"is_service_account" json:"is_service_account" form:"-"`
 }
 
 // IsOwner returns true if the user is an owner.
@@ -45,6 +79,21 @@ func (u User) IsHacker() bool {
 	return u.Role == RoleHacker
 }
 
+// IsJudge returns true if the user is a judge.
+func (u User) IsJudge() bool {
+	return u.Role == RoleJudge
+}
+
+// IsEmailVerified returns true once the user confirmed they own their email address.
+func (u User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt.Valid
+}
+
+// IsDeactivated returns true when the account was switched off and can't sign in.
+func (u User) IsDeactivated() bool {
+	return u.DeactivatedAt.Valid
+}
+
 // String returns the JSON representation of the user.
 func (u User) String() string {
 	ju, _ := json.Marshal(u)
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// UserSession is a signed-in browser. The cookie session only carries its ID, so
+// deleting the row signs that browser out.
+type UserSession struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	IPAddress  string    `json:"ip_address" db:"ip_address"`
+	UserAgent  string    `json:"user_agent" db:"user_agent"`
+	LastSeenAt time.Time `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// IsExpired returns true when the session has been idle or alive for longer than the configuration allows
+func (s UserSession) IsExpired(config *CompanyConfiguration, now time.Time) bool {
+	return now.Sub(s.LastSeenAt) > config.SessionIdleTimeout() || now.Sub(s.CreatedAt) > config.SessionAbsoluteTimeout()
+}
+
+// Device returns a short description of the browser and operating system of the session
+func (s UserSession) Device() string {
+	ua := s.UserAgent
+
+	browser := ""
+	for _, b := range []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+	} {
+		if strings.Contains(ua, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	platform := ""
+	for _, o := range []struct{ token, name string }{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	} {
+		if strings.Contains(ua, o.token) {
+			platform = o.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	case ua != "":
+		return strings.SplitN(ua, " ", 2)[0]
+	default:
+		return "Unknown device"
+	}
+}
+
+// String is not required by pop and may be deleted
+func (s UserSession) String() string {
+	js, _ := json.Marshal(s)
+	return string(js)
+}
+
+// UserSessions is not required by pop and may be deleted
+type UserSessions []UserSession
+
+// String is not required by pop and may be deleted
+func (s UserSessions) String() string {
+	js, _ := json.Marshal(s)
+	return string(js)
+}
+
+// Validate runs on Validate* calls
+func (s *UserSession) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.UUIDIsPresent{Field: s.UserID, Name: "UserID"},
+	), nil
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gobuffalo/buffalo/binding"
+)
+
+// bindUserForm binds a submitted form to a user the way the account handlers do
+func bindUserForm(t *testing.T, form url.Values) User {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var user User
+	if err := binding.Exec(req, &user); err != nil {
+		t.Fatal(err)
+	}
+	return user
+}
+
+func TestUserFormBinding_CalendarToken(t *testing.T) {
+	user := bindUserForm(t, url.Values{
+		"Name":          {"Mallory"},
+		"CalendarToken": {"known-feed-token"},
+	})
+	if user.Name != "Mallory" {
+		t.Fatalf("Name = %q, the form wasn't bound", user.Name)
+	}
+	if user.CalendarToken.Valid {
+		t.Errorf("CalendarToken = %q, want it left unset by forms", user.CalendarToken.String)
+	}
+}
+
+func TestUserFormBinding_Role(t *testing.T) {
+	user := bindUserForm(t, url.Values{
+		"Name": {"Mallory"},
+		"Role": {RoleOwner},
+	})
+	if user.Name != "Mallory" {
+		t.Fatalf("Name = %q, the form wasn't bound", user.Name)
+	}
+	if user.Role != "" {
+		t.Errorf("Role = %q, want it left unset by forms", user.Role)
+	}
+}
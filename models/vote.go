@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gobuffalo/validate/v3/validators"
+	"github.com/gofrs/uuid"
+)
+
+// Vote is a people's-choice vote a user cast for a presenting project
+type Vote struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	HackathonID string    `json:"hackathon_id" db:"hackathon_id"`
+	ProjectID   string    `json:"project_id" db:"project_id"`
+	UserID      uuid.UUID `json:"user_id" db:"user_id"`
+}
+
+// String is not required by pop and may be deleted
+func (v Vote) String() string {
+	jv, _ := json.Marshal(v)
+	return string(jv)
+}
+
+// Votes is not required by pop and may be deleted
+type Votes []Vote
+
+// String is not required by pop and may be deleted
+func (v Votes) String() string {
+	jv, _ := json.Marshal(v)
+	return string(jv)
+}
+
+// Validate runs on Validate* calls
+func (v *Vote) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validate.Validate(
+		&validators.StringIsPresent{Field: v.HackathonID, Name: "HackathonID"},
+		&validators.StringIsPresent{Field: v.ProjectID, Name: "ProjectID"},
+		&validators.UUIDIsPresent{Field: v.UserID, Name: "UserID"},
+	), nil
+}
+
+// VoteTally is the number of votes a project received
+type VoteTally struct {
+	ProjectID string `json:"project_id" db:"project_id"`
+	Votes     int    `json:"votes" db:"votes"`
+}
+
+// VoteTallies is a collection of VoteTally
+type VoteTallies []VoteTally
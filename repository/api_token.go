@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// APITokenRepository handles API token database operations
+type APITokenRepository struct {
+	*BaseRepository
+}
+
+// NewAPITokenRepository creates a new API token repository
+func NewAPITokenRepository(conn *pop.Connection) *APITokenRepository {
+	return &APITokenRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByUserID returns the API tokens of a user, newest first
+func (r *APITokenRepository) FindByUserID(userID interface{}) (*models.APITokens, error) {
+	tokens := &models.APITokens{}
+	err := r.conn.Where("user_id = ?", userID).Order("created_at desc").All(tokens)
+	return tokens, err
+}
+
+// FindByTokenHash finds an API token by the hash of its bearer token
+func (r *APITokenRepository) FindByTokenHash(tokenHash string) (*models.APIToken, error) {
+	token := &models.APIToken{}
+	err := r.conn.Where("token_hash = ?", tokenHash).First(token)
+	return token, err
+}
+
+// Touch records that an API token was just used
+func (r *APITokenRepository) Touch(id interface{}, at time.Time) error {
+	return r.conn.RawQuery("UPDATE api_tokens SET last_used_at = ? WHERE id = ?", at, id).Exec()
+}
+
+// FindByID finds an API token by ID
+func (r *APITokenRepository) FindByID(id interface{}) (*models.APIToken, error) {
+	token := &models.APIToken{}
+	err := r.conn.Find(token, id)
+	return token, err
+}
+
+// FindAll returns every API token with its user, newest first
+func (r *APITokenRepository) FindAll() (*models.APITokens, error) {
+	tokens := &models.APITokens{}
+	err := r.conn.Eager("User").Order("created_at desc").All(tokens)
+	return tokens, err
+}
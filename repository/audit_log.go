@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// AuditLogRepository handles audit log database operations
+type AuditLogRepository struct {
+	*BaseRepository
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(conn *pop.Connection) *AuditLogRepository {
+	return &AuditLogRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindBetween returns the entries created from since until before until, oldest first.
+// A zero time leaves that end open and an empty action matches every action.
+func (r *AuditLogRepository) FindBetween(since, until time.Time, action string) (*models.AuditLogs, error) {
+	auditLogs := &models.AuditLogs{}
+	q := r.conn.Q()
+	if !since.IsZero() {
+		q = q.Where("created_at >= ?", since)
+	}
+	if !until.IsZero() {
+		q = q.Where("created_at < ?", until)
+	}
+	if action != "" {
+		q = q.Where("action = ?", action)
+	}
+	err := q.Order("created_at asc").All(auditLogs)
+	return auditLogs, err
+}
+
+// Create records an audit log entry
+func (r *AuditLogRepository) Create(auditLog *models.AuditLog) error {
+	return r.conn.Create(auditLog)
+}
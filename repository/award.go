@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// AwardRepository handles award-related database operations
+type AwardRepository struct {
+	*BaseRepository
+}
+
+// NewAwardRepository creates a new award repository
+func NewAwardRepository(conn *pop.Connection) *AwardRepository {
+	return &AwardRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByID finds an award by ID
+func (r *AwardRepository) FindByID(id interface{}) (*models.Award, error) {
+	award := &models.Award{}
+	err := r.conn.Find(award, id)
+	return award, err
+}
+
+// FindByHackathonID finds the award categories of a hackathon with their winning projects
+func (r *AwardRepository) FindByHackathonID(hackathonID interface{}) (*models.Awards, error) {
+	awards := &models.Awards{}
+	err := r.conn.Where("hackathon_id = ?", hackathonID).Order("created_at asc").Eager("Winners.Project").All(awards)
+	return awards, err
+}
+
+// FindWinnerByID finds an award winner by ID
+func (r *AwardRepository) FindWinnerByID(id interface{}) (*models.AwardWinner, error) {
+	winner := &models.AwardWinner{}
+	err := r.conn.Find(winner, id)
+	return winner, err
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/gobuffalo/pop/v6"
 )
 
@@ -23,3 +25,18 @@ func NewBaseRepository(conn *pop.Connection) *BaseRepository {
 func (r *BaseRepository) GetConnection() *pop.Connection {
 	return r.conn
 }
+
+// PageCursor marks the last row of a page for keyset pagination. Paged queries order rows
+// by creation time and then ID, so rows added while a client pages through don't shift pages.
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// pageAfter orders a query for keyset pagination and keeps the rows after the cursor, if any
+func pageAfter(q *pop.Query, table string, after *PageCursor, limit int) *pop.Query {
+	if after != nil {
+		q = q.Where("("+table+".created_at, "+table+".id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+	return q.Order(table + ".created_at asc, " + table + ".id asc").Limit(limit)
+}
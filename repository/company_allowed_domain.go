@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/arxdsilva/hackathon/models"
 	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
 )
 
 // CompanyAllowedDomainRepository handles company allowed domain-related database operations
@@ -17,28 +20,24 @@ func NewCompanyAllowedDomainRepository(conn *pop.Connection) *CompanyAllowedDoma
 	}
 }
 
-// IsDomainAllowed checks if a domain is in the allowed domains list
-// If no domains are configured, all domains are allowed
+// IsDomainAllowed checks if a domain matches the allowed domains list and no blocked entry
+// Without allowed domains configured no domain is allowed
 func (r *CompanyAllowedDomainRepository) IsDomainAllowed(domain string) (bool, error) {
-	// First check if there are any active domains configured
-	var totalActive int
-	err := r.conn.RawQuery("SELECT COUNT(*) FROM company_allowed_domains WHERE is_active = true").First(&totalActive)
+	check, err := r.CheckDomain(domain, true)
 	if err != nil {
 		return false, err
 	}
+	return check.Allowed, nil
+}
 
-	// If no domains are configured, allow all domains
-	if totalActive == 0 {
-		return true, nil
-	}
-
-	// If domains are configured, check if this specific domain is allowed
-	var count int
-	err = r.conn.RawQuery("SELECT COUNT(*) FROM company_allowed_domains WHERE domain = ? AND is_active = true", domain).First(&count)
+// CheckDomain matches a domain against the active rules and returns the rule that decided it
+func (r *CompanyAllowedDomainRepository) CheckDomain(domain string, openRegistration bool) (*models.DomainCheck, error) {
+	domains, err := r.FindAllActive()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-	return count > 0, nil
+	check := domains.Check(domain, openRegistration)
+	return &check, nil
 }
 
 // FindAllActive returns all active allowed domains
@@ -54,3 +53,25 @@ func (r *CompanyAllowedDomainRepository) FindAll() (*models.CompanyAllowedDomain
 	err := r.conn.Order("domain asc").All(domains)
 	return domains, err
 }
+
+// FindByDomain finds the rule for a domain, wildcards included
+func (r *CompanyAllowedDomainRepository) FindByDomain(domain string) (*models.CompanyAllowedDomain, error) {
+	allowedDomain := &models.CompanyAllowedDomain{}
+	err := r.conn.Where("domain = ?", domain).First(allowedDomain)
+	return allowedDomain, err
+}
+
+// Create validates and creates a domain rule
+func (r *CompanyAllowedDomainRepository) Create(domain *models.CompanyAllowedDomain) (*validate.Errors, error) {
+	return r.conn.ValidateAndCreate(domain)
+}
+
+// SetActive turns a domain rule on or off
+func (r *CompanyAllowedDomainRepository) SetActive(id interface{}, active bool) error {
+	return r.conn.RawQuery("UPDATE company_allowed_domains SET is_active = ?, updated_at = ? WHERE id = ?", active, time.Now(), id).Exec()
+}
+
+// Delete deletes a domain rule
+func (r *CompanyAllowedDomainRepository) Delete(id interface{}) error {
+	return r.conn.RawQuery("DELETE FROM company_allowed_domains WHERE id = ?", id).Exec()
+}
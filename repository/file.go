@@ -44,3 +44,26 @@ func (r *FileRepository) FindAllProjects() (*models.Projects, error) {
 	err := r.conn.All(projects)
 	return projects, err
 }
+
+// FindVisiblePage returns a page of files, optionally of one hackathon or project. Unless
+// includeAll is true, files of hidden hackathons are only included for the hackathon's owner
+// and files of projects waiting for review or rejected only for the project's team and
+// their hackathon's owner, like FindVisiblePage of projects.
+func (r *FileRepository) FindVisiblePage(hackathonID, projectID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Files, error) {
+	files := &models.Files{}
+	q := r.conn.Q()
+	if hackathonID != "" {
+		q = q.Where("hackathon_id = ?", hackathonID)
+	}
+	if projectID != "" {
+		q = q.Where("project_id = ?", projectID)
+	}
+	if !includeAll {
+		visibleHackathons := "SELECT id FROM hackathons WHERE status <> ? OR owner_id = ?"
+		q = q.Where("(hackathon_id IS NULL OR hackathon_id IN ("+visibleHackathons+"))", models.HackathonStatusHidden, viewerID)
+		q = q.Where("(project_id IS NULL OR project_id IN (SELECT id FROM projects WHERE hackathon_id IN ("+visibleHackathons+") AND (review_status = ? OR id IN (SELECT project_id FROM project_memberships WHERE user_id = ?) OR hackathon_id IN (SELECT id FROM hackathons WHERE owner_id = ?))))",
+			models.HackathonStatusHidden, viewerID, models.ProjectReviewApproved, viewerID, viewerID)
+	}
+	err := pageAfter(q, "files", after, limit).All(files)
+	return files, err
+}
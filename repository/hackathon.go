@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/arxdsilva/hackathon/models"
 	"github.com/gobuffalo/pop/v6"
 )
@@ -48,7 +50,7 @@ func (r *HackathonRepository) GetRecent(limit int) (*models.Hackathons, error) {
 // GetActiveWithSchedule returns hackathons that are active/upcoming and have schedules
 func (r *HackathonRepository) GetActiveWithSchedule() (*models.Hackathons, error) {
 	hackathons := &models.Hackathons{}
-	err := r.conn.Where("status IN (?, ?) AND schedule IS NOT NULL AND schedule != ''", "upcoming", "active").Order("start_date asc").All(hackathons)
+	err := r.conn.Where("status IN (?, ?) AND EXISTS (SELECT 1 FROM schedule_items WHERE schedule_items.hackathon_id = hackathons.id)", "upcoming", "active").Order("start_date asc").Eager("ScheduleItems").All(hackathons)
 	return hackathons, err
 }
 
@@ -58,3 +60,48 @@ func (r *HackathonRepository) GetActiveHackathonIDs() ([]int, error) {
 	err := r.conn.RawQuery("SELECT id FROM hackathons WHERE status IN ('active', 'upcoming')").All(&ids)
 	return ids, err
 }
+
+// FindUnfinished returns the hackathons that aren't completed yet, soonest first
+func (r *HackathonRepository) FindUnfinished() (*models.Hackathons, error) {
+	hackathons := &models.Hackathons{}
+	err := r.conn.Where("status <> ?", models.HackathonStatusCompleted).Order("start_date asc").All(hackathons)
+	return hackathons, err
+}
+
+// FindByIDsWithSchedule finds multiple hackathons by their IDs with their schedule items
+func (r *HackathonRepository) FindByIDsWithSchedule(ids []interface{}) (*models.Hackathons, error) {
+	hackathons := &models.Hackathons{}
+	if len(ids) == 0 {
+		return hackathons, nil
+	}
+	err := r.conn.Where("id IN (?)", ids...).Order("start_date asc").Eager("ScheduleItems").All(hackathons)
+	return hackathons, err
+}
+
+// LockDueForStatusTransition finds upcoming hackathons that have started and active hackathons
+// that have ended, locking their rows so concurrent schedulers skip them
+func (r *HackathonRepository) LockDueForStatusTransition(now time.Time) (*models.Hackathons, error) {
+	hackathons := &models.Hackathons{}
+	err := r.conn.RawQuery(
+		"SELECT * FROM hackathons WHERE (status = ? AND start_date <= ?) OR (status = ? AND end_date <= ?) ORDER BY start_date ASC FOR UPDATE SKIP LOCKED",
+		models.HackathonStatusUpcoming, now, models.HackathonStatusActive, now,
+	).All(hackathons)
+	return hackathons, err
+}
+
+// FindPage returns a page of hackathons. Hidden hackathons are only included for the
+// viewer's own hackathons, or for every hackathon when includeHidden is true.
+func (r *HackathonRepository) FindPage(viewerID interface{}, includeHidden bool, after *PageCursor, limit int) (*models.Hackathons, error) {
+	hackathons := &models.Hackathons{}
+	q := r.conn.Q()
+	if !includeHidden {
+		q = q.Where("(status <> ? OR owner_id = ?)", models.HackathonStatusHidden, viewerID)
+	}
+	err := pageAfter(q, "hackathons", after, limit).All(hackathons)
+	return hackathons, err
+}
+
+// SetStatus changes the status of a hackathon
+func (r *HackathonRepository) SetStatus(id interface{}, status string) error {
+	return r.conn.RawQuery("UPDATE hackathons SET status = ?, updated_at = ? WHERE id = ?", status, time.Now(), id).Exec()
+}
@@ -1,15 +1,31 @@
 package repository
 
-import "github.com/arxdsilva/hackathon/models"
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/validate/v3"
+)
 
 // RepositoryInterface unifies all repository interfaces with namespaced methods
 type RepositoryInterface interface {
 	// User operations
 	UserCount() (int, error)
 	UserFindByEmail(email string) (*models.User, error)
+	UserFindByOIDCSubject(subject string) (*models.User, error)
+	UserFindByLDAPDN(dn string) (*models.User, error)
+	UserFindBySCIMExternalID(externalID string) (*models.User, error)
 	UserFindByID(id interface{}) (*models.User, error)
+	UserLockByID(id interface{}) (*models.User, error)
 	UserFindByIDs(ids []interface{}) (*models.Users, error)
 	UserGetRecent(limit int) (*models.Users, error)
+	UserFindPage(offset, limit int) (*models.Users, int, error)
+	UserFindByRole(role string) (*models.Users, error)
+	UserFindByCalendarToken(token string) (*models.User, error)
+	UserFindServiceAccounts() (*models.Users, error)
+	UserCreate(user *models.User) (*validate.Errors, error)
+	UserSetRole(id interface{}, role string) error
+	UserSetForcePasswordReset(id interface{}, force bool) error
 
 	// Hackathon operations
 	HackathonCount() (int, error)
@@ -18,6 +34,11 @@ type RepositoryInterface interface {
 	HackathonGetRecent(limit int) (*models.Hackathons, error)
 	HackathonGetActiveWithSchedule() (*models.Hackathons, error)
 	HackathonGetActiveHackathonIDs() ([]int, error)
+	HackathonFindByIDsWithSchedule(ids []interface{}) (*models.Hackathons, error)
+	HackathonLockDueForStatusTransition(now time.Time) (*models.Hackathons, error)
+	HackathonFindUnfinished() (*models.Hackathons, error)
+	HackathonFindPage(viewerID interface{}, includeHidden bool, after *PageCursor, limit int) (*models.Hackathons, error)
+	HackathonSetStatus(id interface{}, status string) error
 
 	// Project operations
 	ProjectCount() (int, error)
@@ -25,6 +46,7 @@ type RepositoryInterface interface {
 	ProjectCountPresenting() (int, error)
 	ProjectFindByID(id interface{}) (*models.Project, error)
 	ProjectFindByHackathonID(hackathonID interface{}) (*models.Projects, error)
+	ProjectFindApprovedByHackathonID(hackathonID interface{}) (*models.Projects, error)
 	ProjectFindByUserID(userID interface{}) (*models.Projects, error)
 	ProjectFindByUserIDWithHackathon(userID interface{}) (*models.Projects, error)
 	ProjectFindPresentingByHackathonID(hackathonID interface{}) (*models.Projects, error)
@@ -34,31 +56,129 @@ type RepositoryInterface interface {
 	ProjectGetMembershipsByProjectID(projectID interface{}) (*models.ProjectMemberships, error)
 	ProjectCountMembershipsByProjectID(projectID interface{}) (int, error)
 	ProjectIsUserMemberOfProject(projectID, userID interface{}) (bool, error)
+	ProjectLockByID(id interface{}) (*models.Project, error)
+	ProjectFindPendingReview() (*models.Projects, error)
+	ProjectCountPendingReview() (int, error)
+	ProjectFindVisiblePage(hackathonID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Projects, error)
 
 	// Project Membership operations
 	ProjectMembershipFindByProjectIDAndUserID(projectID, userID interface{}) (*models.ProjectMembership, error)
 	ProjectMembershipCountByProjectID(projectID interface{}) (int, error)
 	ProjectMembershipIsUserMember(projectID, userID interface{}) (bool, error)
+	ProjectMembershipFindPageByProjectID(projectID interface{}, after *PageCursor, limit int) (*models.ProjectMemberships, error)
 
 	// File operations
 	FileFindByID(id interface{}) (*models.File, error)
 	FileFindAll() (*models.Files, error)
 	FileFindAllHackathons() (*models.Hackathons, error)
 	FileFindAllProjects() (*models.Projects, error)
+	FileFindVisiblePage(hackathonID, projectID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Files, error)
 
 	// Company Allowed Domain operations
 	CompanyAllowedDomainIsDomainAllowed(domain string) (bool, error)
+	CompanyAllowedDomainCheckDomain(domain string, openRegistration bool) (*models.DomainCheck, error)
 	CompanyAllowedDomainFindAllActive() (*models.CompanyAllowedDomains, error)
 	CompanyAllowedDomainFindAll() (*models.CompanyAllowedDomains, error)
+	CompanyAllowedDomainFindByDomain(domain string) (*models.CompanyAllowedDomain, error)
+	CompanyAllowedDomainCreate(domain *models.CompanyAllowedDomain) (*validate.Errors, error)
+	CompanyAllowedDomainSetActive(id interface{}, active bool) error
+	CompanyAllowedDomainDelete(id interface{}) error
+
+	// Judging operations
+	JudgingFindCriteriaByHackathonID(hackathonID interface{}) (*models.JudgingCriteria, error)
+	JudgingFindAssignmentsByHackathonID(hackathonID interface{}) (*models.JudgeAssignments, error)
+	JudgingFindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID interface{}) (*models.JudgeAssignments, error)
+	JudgingIsJudgeAssigned(projectID, judgeID interface{}) (bool, error)
+	JudgingFindScoresByProjectIDAndJudgeID(projectID, judgeID interface{}) (*models.JudgeScores, error)
+	JudgingFindScoresByHackathonID(hackathonID interface{}) (*models.JudgeScores, error)
+
+	// Vote operations
+	VoteFindByHackathonIDAndUserID(hackathonID, userID interface{}) (*models.Votes, error)
+	VoteFindByProjectIDAndUserID(projectID, userID interface{}) (*models.Vote, error)
+	VoteCountByHackathonIDAndUserID(hackathonID, userID interface{}) (int, error)
+	VoteTallyByHackathonID(hackathonID interface{}) (*models.VoteTallies, error)
+
+	// Award operations
+	AwardFindByID(id interface{}) (*models.Award, error)
+	AwardFindByHackathonID(hackathonID interface{}) (*models.Awards, error)
+	AwardFindWinnerByID(id interface{}) (*models.AwardWinner, error)
+
+	// ScheduleItem operations
+	ScheduleItemFindByID(id interface{}) (*models.ScheduleItem, error)
+	ScheduleItemFindByHackathonID(hackathonID interface{}) (*models.ScheduleItems, error)
+
+	// TeamRequest operations
+	TeamRequestFindJoinRequestByID(id interface{}) (*models.JoinRequest, error)
+	TeamRequestFindPendingJoinRequestsByProjectID(projectID interface{}) (*models.JoinRequests, error)
+	TeamRequestHasPendingJoinRequest(projectID, userID interface{}) (bool, error)
+	TeamRequestFindInvitationByID(id interface{}) (*models.ProjectInvitation, error)
+	TeamRequestFindPendingInvitationsByProjectID(projectID interface{}) (*models.ProjectInvitations, error)
+	TeamRequestFindPendingInvitationsByEmail(email string) (*models.ProjectInvitations, error)
+
+	// RecoveryCode operations
+	RecoveryCodeFindUnusedByUserIDAndHash(userID interface{}, codeHash string) (*models.RecoveryCode, error)
+	RecoveryCodeCountUnusedByUserID(userID interface{}) (int, error)
+	RecoveryCodeDeleteByUserID(userID interface{}) error
+
+	// PasswordReset operations
+	PasswordResetFindByTokenHash(tokenHash string) (*models.PasswordReset, error)
+	PasswordResetCountByEmailSince(email string, since time.Time) (int, error)
+	PasswordResetCountByIPAddressSince(ipAddress string, since time.Time) (int, error)
+	PasswordResetExpireByUserID(userID interface{}) error
+
+	// UserSession operations
+	UserSessionFindByID(id interface{}) (*models.UserSession, error)
+	UserSessionFindByUserID(userID interface{}) (*models.UserSessions, error)
+	UserSessionCountByUserID(userID interface{}) (int, error)
+	UserSessionDeleteByUserID(userID interface{}) error
+	UserSessionDeleteExpired(idleBefore, createdBefore time.Time) (int, error)
+
+	// LoginAttempt operations
+	LoginAttemptFindByEmailSince(email string, since time.Time) (*models.LoginAttempts, error)
+	LoginAttemptFindByIPAddressSince(ipAddress string, since time.Time) (*models.LoginAttempts, error)
+	LoginAttemptFindLocked(since time.Time, threshold int) (*models.LockedAccounts, error)
+	LoginAttemptDeleteByEmail(email string) error
+
+	// SCIMToken operations
+	SCIMTokenFindAll() (*models.SCIMTokens, error)
+	SCIMTokenFindByTokenHash(tokenHash string) (*models.SCIMToken, error)
+	SCIMTokenTouch(id interface{}, at time.Time) error
+
+	// Invitation operations
+	InvitationFindByID(id interface{}) (*models.Invitation, error)
+	InvitationFindAll() (*models.Invitations, error)
+	InvitationRedeem(id interface{}, now time.Time) (bool, error)
+
+	// APIToken operations
+	APITokenFindByUserID(userID interface{}) (*models.APITokens, error)
+	APITokenFindByTokenHash(tokenHash string) (*models.APIToken, error)
+	APITokenTouch(id interface{}, at time.Time) error
+	APITokenFindByID(id interface{}) (*models.APIToken, error)
+	APITokenFindAll() (*models.APITokens, error)
+
+	// AuditLog operations
+	AuditLogFindBetween(since, until time.Time, action string) (*models.AuditLogs, error)
+	AuditLogCreate(auditLog *models.AuditLog) error
 }
 
 // UserRepositoryInterface defines the interface for user repository operations
 type UserRepositoryInterface interface {
 	Count() (int, error)
 	FindByEmail(email string) (*models.User, error)
+	FindByOIDCSubject(subject string) (*models.User, error)
+	FindByLDAPDN(dn string) (*models.User, error)
+	FindBySCIMExternalID(externalID string) (*models.User, error)
 	FindByID(id interface{}) (*models.User, error)
+	LockByID(id interface{}) (*models.User, error)
 	FindByIDs(ids []interface{}) (*models.Users, error)
 	GetRecent(limit int) (*models.Users, error)
+	FindPage(offset, limit int) (*models.Users, int, error)
+	FindByRole(role string) (*models.Users, error)
+	FindByCalendarToken(token string) (*models.User, error)
+	FindServiceAccounts() (*models.Users, error)
+	Create(user *models.User) (*validate.Errors, error)
+	SetRole(id interface{}, role string) error
+	SetForcePasswordReset(id interface{}, force bool) error
 }
 
 // HackathonRepositoryInterface defines the interface for hackathon repository operations
@@ -69,6 +189,11 @@ type HackathonRepositoryInterface interface {
 	GetRecent(limit int) (*models.Hackathons, error)
 	GetActiveWithSchedule() (*models.Hackathons, error)
 	GetActiveHackathonIDs() ([]int, error)
+	FindByIDsWithSchedule(ids []interface{}) (*models.Hackathons, error)
+	LockDueForStatusTransition(now time.Time) (*models.Hackathons, error)
+	FindUnfinished() (*models.Hackathons, error)
+	FindPage(viewerID interface{}, includeHidden bool, after *PageCursor, limit int) (*models.Hackathons, error)
+	SetStatus(id interface{}, status string) error
 }
 
 // ProjectRepositoryInterface defines the interface for project repository operations
@@ -78,6 +203,7 @@ type ProjectRepositoryInterface interface {
 	CountPresenting() (int, error)
 	FindByID(id interface{}) (*models.Project, error)
 	FindByHackathonID(hackathonID interface{}) (*models.Projects, error)
+	FindApprovedByHackathonID(hackathonID interface{}) (*models.Projects, error)
 	FindByUserID(userID interface{}) (*models.Projects, error)
 	FindByUserIDWithHackathon(userID interface{}) (*models.Projects, error)
 	FindPresentingByHackathonID(hackathonID interface{}) (*models.Projects, error)
@@ -87,6 +213,10 @@ type ProjectRepositoryInterface interface {
 	GetMembershipsByProjectID(projectID interface{}) (*models.ProjectMemberships, error)
 	CountMembershipsByProjectID(projectID interface{}) (int, error)
 	IsUserMemberOfProject(projectID, userID interface{}) (bool, error)
+	LockByID(id interface{}) (*models.Project, error)
+	FindPendingReview() (*models.Projects, error)
+	CountPendingReview() (int, error)
+	FindVisiblePage(hackathonID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Projects, error)
 }
 
 // ProjectMembershipRepositoryInterface defines the interface for project membership repository operations
@@ -94,6 +224,7 @@ type ProjectMembershipRepositoryInterface interface {
 	FindByProjectIDAndUserID(projectID, userID interface{}) (*models.ProjectMembership, error)
 	CountByProjectID(projectID interface{}) (int, error)
 	IsUserMember(projectID, userID interface{}) (bool, error)
+	FindPageByProjectID(projectID interface{}, after *PageCursor, limit int) (*models.ProjectMemberships, error)
 }
 
 // FileRepositoryInterface defines the interface for file repository operations
@@ -102,11 +233,119 @@ type FileRepositoryInterface interface {
 	FindAll() (*models.Files, error)
 	FindAllHackathons() (*models.Hackathons, error)
 	FindAllProjects() (*models.Projects, error)
+	FindVisiblePage(hackathonID, projectID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Files, error)
 }
 
 // CompanyAllowedDomainRepositoryInterface defines the interface for company allowed domain repository operations
 type CompanyAllowedDomainRepositoryInterface interface {
 	IsDomainAllowed(domain string) (bool, error)
+	CheckDomain(domain string, openRegistration bool) (*models.DomainCheck, error)
 	FindAllActive() (*models.CompanyAllowedDomains, error)
 	FindAll() (*models.CompanyAllowedDomains, error)
+	FindByDomain(domain string) (*models.CompanyAllowedDomain, error)
+	Create(domain *models.CompanyAllowedDomain) (*validate.Errors, error)
+	SetActive(id interface{}, active bool) error
+	Delete(id interface{}) error
+}
+
+// JudgingRepositoryInterface defines the interface for judging repository operations
+type JudgingRepositoryInterface interface {
+	FindCriteriaByHackathonID(hackathonID interface{}) (*models.JudgingCriteria, error)
+	FindAssignmentsByHackathonID(hackathonID interface{}) (*models.JudgeAssignments, error)
+	FindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID interface{}) (*models.JudgeAssignments, error)
+	IsJudgeAssigned(projectID, judgeID interface{}) (bool, error)
+	FindScoresByProjectIDAndJudgeID(projectID, judgeID interface{}) (*models.JudgeScores, error)
+	FindScoresByHackathonID(hackathonID interface{}) (*models.JudgeScores, error)
+}
+
+// VoteRepositoryInterface defines the interface for vote repository operations
+type VoteRepositoryInterface interface {
+	FindByHackathonIDAndUserID(hackathonID, userID interface{}) (*models.Votes, error)
+	FindByProjectIDAndUserID(projectID, userID interface{}) (*models.Vote, error)
+	CountByHackathonIDAndUserID(hackathonID, userID interface{}) (int, error)
+	TallyByHackathonID(hackathonID interface{}) (*models.VoteTallies, error)
+}
+
+// AwardRepositoryInterface defines the interface for award repository operations
+type AwardRepositoryInterface interface {
+	FindByID(id interface{}) (*models.Award, error)
+	FindByHackathonID(hackathonID interface{}) (*models.Awards, error)
+	FindWinnerByID(id interface{}) (*models.AwardWinner, error)
+}
+
+// ScheduleItemRepositoryInterface defines the interface for schedule item repository operations
+type ScheduleItemRepositoryInterface interface {
+	FindByID(id interface{}) (*models.ScheduleItem, error)
+	FindByHackathonID(hackathonID interface{}) (*models.ScheduleItems, error)
+}
+
+// TeamRequestRepositoryInterface defines the interface for join request and invitation repository operations
+type TeamRequestRepositoryInterface interface {
+	FindJoinRequestByID(id interface{}) (*models.JoinRequest, error)
+	FindPendingJoinRequestsByProjectID(projectID interface{}) (*models.JoinRequests, error)
+	HasPendingJoinRequest(projectID, userID interface{}) (bool, error)
+	FindInvitationByID(id interface{}) (*models.ProjectInvitation, error)
+	FindPendingInvitationsByProjectID(projectID interface{}) (*models.ProjectInvitations, error)
+	FindPendingInvitationsByEmail(email string) (*models.ProjectInvitations, error)
+}
+
+// RecoveryCodeRepositoryInterface defines the interface for recovery code repository operations
+type RecoveryCodeRepositoryInterface interface {
+	FindUnusedByUserIDAndHash(userID interface{}, codeHash string) (*models.RecoveryCode, error)
+	CountUnusedByUserID(userID interface{}) (int, error)
+	DeleteByUserID(userID interface{}) error
+}
+
+// PasswordResetRepositoryInterface defines the interface for password reset repository operations
+type PasswordResetRepositoryInterface interface {
+	FindByTokenHash(tokenHash string) (*models.PasswordReset, error)
+	CountByEmailSince(email string, since time.Time) (int, error)
+	CountByIPAddressSince(ipAddress string, since time.Time) (int, error)
+	ExpireByUserID(userID interface{}) error
+}
+
+// UserSessionRepositoryInterface defines the interface for user session repository operations
+type UserSessionRepositoryInterface interface {
+	FindByID(id interface{}) (*models.UserSession, error)
+	FindByUserID(userID interface{}) (*models.UserSessions, error)
+	CountByUserID(userID interface{}) (int, error)
+	DeleteByUserID(userID interface{}) error
+	DeleteExpired(idleBefore, createdBefore time.Time) (int, error)
+}
+
+// LoginAttemptRepositoryInterface defines the interface for login attempt repository operations
+type LoginAttemptRepositoryInterface interface {
+	FindByEmailSince(email string, since time.Time) (*models.LoginAttempts, error)
+	FindByIPAddressSince(ipAddress string, since time.Time) (*models.LoginAttempts, error)
+	FindLocked(since time.Time, threshold int) (*models.LockedAccounts, error)
+	DeleteByEmail(email string) error
+}
+
+// SCIMTokenRepositoryInterface defines the interface for SCIM token repository operations
+type SCIMTokenRepositoryInterface interface {
+	FindAll() (*models.SCIMTokens, error)
+	FindByTokenHash(tokenHash string) (*models.SCIMToken, error)
+	Touch(id interface{}, at time.Time) error
+}
+
+// InvitationRepositoryInterface defines the interface for invitation repository operations
+type InvitationRepositoryInterface interface {
+	FindByID(id interface{}) (*models.Invitation, error)
+	FindAll() (*models.Invitations, error)
+	Redeem(id interface{}, now time.Time) (bool, error)
+}
+
+// APITokenRepositoryInterface defines the interface for API token repository operations
+type APITokenRepositoryInterface interface {
+	FindByUserID(userID interface{}) (*models.APITokens, error)
+	FindByTokenHash(tokenHash string) (*models.APIToken, error)
+	Touch(id interface{}, at time.Time) error
+	FindByID(id interface{}) (*models.APIToken, error)
+	FindAll() (*models.APITokens, error)
+}
+
+// AuditLogRepositoryInterface defines the interface for audit log repository operations
+type AuditLogRepositoryInterface interface {
+	FindBetween(since, until time.Time, action string) (*models.AuditLogs, error)
+	Create(auditLog *models.AuditLog) error
 }
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// InvitationRepository handles registration invitation database operations
+type InvitationRepository struct {
+	*BaseRepository
+}
+
+// NewInvitationRepository creates a new invitation repository
+func NewInvitationRepository(conn *pop.Connection) *InvitationRepository {
+	return &InvitationRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByID finds an invitation by ID
+func (r *InvitationRepository) FindByID(id interface{}) (*models.Invitation, error) {
+	invitation := &models.Invitation{}
+	err := r.conn.Find(invitation, id)
+	return invitation, err
+}
+
+// FindAll returns every invitation, newest first
+func (r *InvitationRepository) FindAll() (*models.Invitations, error) {
+	invitations := &models.Invitations{}
+	err := r.conn.Order("created_at desc").All(invitations)
+	return invitations, err
+}
+
+// Redeem counts one use of an invitation. It returns false without changing anything when the
+// invitation expired or ran out of uses, so concurrent sign-ups can't go over the limit.
+func (r *InvitationRepository) Redeem(id interface{}, now time.Time) (bool, error) {
+	count, err := r.conn.RawQuery(
+		"UPDATE invitations SET use_count = use_count + 1, updated_at = ? WHERE id = ? AND (max_uses = 0 OR use_count < max_uses) AND (expires_at IS NULL OR expires_at > ?)",
+		now, id, now,
+	).ExecWithCount()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// JudgingRepository handles judging-related database operations
+type JudgingRepository struct {
+	*BaseRepository
+}
+
+// NewJudgingRepository creates a new judging repository
+func NewJudgingRepository(conn *pop.Connection) *JudgingRepository {
+	return &JudgingRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindCriteriaByHackathonID finds the rubric criteria of a hackathon
+func (r *JudgingRepository) FindCriteriaByHackathonID(hackathonID interface{}) (*models.JudgingCriteria, error) {
+	criteria := &models.JudgingCriteria{}
+	err := r.conn.Where("hackathon_id = ?", hackathonID).Order("created_at asc").All(criteria)
+	return criteria, err
+}
+
+// FindAssignmentsByHackathonID finds all judge assignments of a hackathon with judge and project data
+func (r *JudgingRepository) FindAssignmentsByHackathonID(hackathonID interface{}) (*models.JudgeAssignments, error) {
+	assignments := &models.JudgeAssignments{}
+	err := r.conn.Where("hackathon_id = ?", hackathonID).Order("created_at asc").Eager("Judge", "Project").All(assignments)
+	return assignments, err
+}
+
+// FindAssignmentsByHackathonIDAndJudgeID finds the projects a judge has to score in a hackathon
+func (r *JudgingRepository) FindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID interface{}) (*models.JudgeAssignments, error) {
+	assignments := &models.JudgeAssignments{}
+	err := r.conn.Where("hackathon_id = ? AND judge_id = ?", hackathonID, judgeID).Eager("Project").All(assignments)
+	return assignments, err
+}
+
+// IsJudgeAssigned checks if a judge is assigned to a project
+func (r *JudgingRepository) IsJudgeAssigned(projectID, judgeID interface{}) (bool, error) {
+	count, err := r.conn.Where("project_id = ? AND judge_id = ?", projectID, judgeID).Count(&models.JudgeAssignment{})
+	return count > 0, err
+}
+
+// FindScoresByProjectIDAndJudgeID finds the scores a judge gave to a project
+func (r *JudgingRepository) FindScoresByProjectIDAndJudgeID(projectID, judgeID interface{}) (*models.JudgeScores, error) {
+	scores := &models.JudgeScores{}
+	err := r.conn.Where("project_id = ? AND judge_id = ?", projectID, judgeID).All(scores)
+	return scores, err
+}
+
+// FindScoresByHackathonID finds all scores given to projects of a hackathon
+func (r *JudgingRepository) FindScoresByHackathonID(hackathonID interface{}) (*models.JudgeScores, error) {
+	scores := &models.JudgeScores{}
+	err := r.conn.Where("project_id IN (SELECT id FROM projects WHERE hackathon_id = ?)", hackathonID).All(scores)
+	return scores, err
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// LoginAttemptRepository handles failed sign-in database operations
+type LoginAttemptRepository struct {
+	*BaseRepository
+}
+
+// NewLoginAttemptRepository creates a new login attempt repository
+func NewLoginAttemptRepository(conn *pop.Connection) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByEmailSince finds the failed sign-ins for an email address since a point in time, newest first
+func (r *LoginAttemptRepository) FindByEmailSince(email string, since time.Time) (*models.LoginAttempts, error) {
+	attempts := &models.LoginAttempts{}
+	err := r.conn.Where("email = ? AND created_at >= ?", email, since).Order("created_at desc").All(attempts)
+	return attempts, err
+}
+
+// FindByIPAddressSince finds the failed sign-ins from an IP address since a point in time, newest first
+func (r *LoginAttemptRepository) FindByIPAddressSince(ipAddress string, since time.Time) (*models.LoginAttempts, error) {
+	attempts := &models.LoginAttempts{}
+	err := r.conn.Where("ip_address = ? AND created_at >= ?", ipAddress, since).Order("created_at desc").All(attempts)
+	return attempts, err
+}
+
+// FindLocked returns the email addresses with at least threshold failed sign-ins since a point in time
+func (r *LoginAttemptRepository) FindLocked(since time.Time, threshold int) (*models.LockedAccounts, error) {
+	accounts := &models.LockedAccounts{}
+	err := r.conn.RawQuery(`SELECT a.email, COALESCE(MAX(u.id::text), '') AS user_id, COALESCE(MAX(u.name), '') AS name,
+		COUNT(*) AS failures, MAX(a.created_at) AS last_failed_at
+		FROM login_attempts a LEFT JOIN users u ON LOWER(u.email) = a.email
+		WHERE a.created_at >= ?
+		GROUP BY a.email HAVING COUNT(*) >= ?
+		ORDER BY last_failed_at DESC`, since, threshold).All(accounts)
+	return accounts, err
+}
+
+// DeleteByEmail clears the failed sign-ins of an email address
+func (r *LoginAttemptRepository) DeleteByEmail(email string) error {
+	return r.conn.RawQuery("DELETE FROM login_attempts WHERE email = ?", email).Exec()
+}
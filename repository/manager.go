@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/arxdsilva/hackathon/models"
 	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
 )
 
 // RepositoryManager manages all repositories and provides access to them
@@ -15,6 +18,19 @@ type RepositoryManager struct {
 	projectMembershipRepo    *ProjectMembershipRepository
 	fileRepo                 *FileRepository
 	companyAllowedDomainRepo *CompanyAllowedDomainRepository
+	judgingRepo              *JudgingRepository
+	voteRepo                 *VoteRepository
+	awardRepo                *AwardRepository
+	scheduleItemRepo         *ScheduleItemRepository
+	teamRequestRepo          *TeamRequestRepository
+	recoveryCodeRepo         *RecoveryCodeRepository
+	passwordResetRepo        *PasswordResetRepository
+	userSessionRepo          *UserSessionRepository
+	loginAttemptRepo         *LoginAttemptRepository
+	scimTokenRepo            *SCIMTokenRepository
+	invitationRepo           *InvitationRepository
+	apiTokenRepo             *APITokenRepository
+	auditLogRepo             *AuditLogRepository
 }
 
 // NewRepositoryManager creates a new repository manager
@@ -72,6 +88,110 @@ func (rm *RepositoryManager) CompanyAllowedDomain() *CompanyAllowedDomainReposit
 	return rm.companyAllowedDomainRepo
 }
 
+// Judging returns the judging repository
+func (rm *RepositoryManager) Judging() *JudgingRepository {
+	if rm.judgingRepo == nil {
+		rm.judgingRepo = NewJudgingRepository(rm.conn)
+	}
+	return rm.judgingRepo
+}
+
+// Vote returns the vote repository
+func (rm *RepositoryManager) Vote() *VoteRepository {
+	if rm.voteRepo == nil {
+		rm.voteRepo = NewVoteRepository(rm.conn)
+	}
+	return rm.voteRepo
+}
+
+// Award returns the award repository
+func (rm *RepositoryManager) Award() *AwardRepository {
+	if rm.awardRepo == nil {
+		rm.awardRepo = NewAwardRepository(rm.conn)
+	}
+	return rm.awardRepo
+}
+
+// ScheduleItem returns the schedule item repository
+func (rm *RepositoryManager) ScheduleItem() *ScheduleItemRepository {
+	if rm.scheduleItemRepo == nil {
+		rm.scheduleItemRepo = NewScheduleItemRepository(rm.conn)
+	}
+	return rm.scheduleItemRepo
+}
+
+// TeamRequest returns the join request and invitation repository
+func (rm *RepositoryManager) TeamRequest() *TeamRequestRepository {
+	if rm.teamRequestRepo == nil {
+		rm.teamRequestRepo = NewTeamRequestRepository(rm.conn)
+	}
+	return rm.teamRequestRepo
+}
+
+// RecoveryCode returns the recovery code repository
+func (rm *RepositoryManager) RecoveryCode() *RecoveryCodeRepository {
+	if rm.recoveryCodeRepo == nil {
+		rm.recoveryCodeRepo = NewRecoveryCodeRepository(rm.conn)
+	}
+	return rm.recoveryCodeRepo
+}
+
+// PasswordReset returns the password reset repository
+func (rm *RepositoryManager) PasswordReset() *PasswordResetRepository {
+	if rm.passwordResetRepo == nil {
+		rm.passwordResetRepo = NewPasswordResetRepository(rm.conn)
+	}
+	return rm.passwordResetRepo
+}
+
+// UserSession returns the user session repository
+func (rm *RepositoryManager) UserSession() *UserSessionRepository {
+	if rm.userSessionRepo == nil {
+		rm.userSessionRepo = NewUserSessionRepository(rm.conn)
+	}
+	return rm.userSessionRepo
+}
+
+// LoginAttempt returns the login attempt repository
+func (rm *RepositoryManager) LoginAttempt() *LoginAttemptRepository {
+	if rm.loginAttemptRepo == nil {
+		rm.loginAttemptRepo = NewLoginAttemptRepository(rm.conn)
+	}
+	return rm.loginAttemptRepo
+}
+
+// SCIMToken returns the SCIM token repository
+func (rm *RepositoryManager) SCIMToken() *SCIMTokenRepository {
+	if rm.scimTokenRepo == nil {
+		rm.scimTokenRepo = NewSCIMTokenRepository(rm.conn)
+	}
+	return rm.scimTokenRepo
+}
+
+// Invitation returns the invitation repository
+func (rm *RepositoryManager) Invitation() *InvitationRepository {
+	if rm.invitationRepo == nil {
+		rm.invitationRepo = NewInvitationRepository(rm.conn)
+	}
+	return rm.invitationRepo
+}
+
+// APIToken returns the API token repository
+func (rm *RepositoryManager) APIToken() *APITokenRepository {
+	if rm.apiTokenRepo == nil {
+		rm.apiTokenRepo = NewAPITokenRepository(rm.conn)
+	}
+	return rm.apiTokenRepo
+}
+
+// AuditLog returns the audit log repository
+func (rm *RepositoryManager) AuditLog() *AuditLogRepository {
+	if rm.auditLogRepo == nil {
+		rm.auditLogRepo = NewAuditLogRepository(rm.conn)
+	}
+	return rm.auditLogRepo
+}
+
 // User operations
 func (rm *RepositoryManager) UserCount() (int, error) {
 	return rm.User().Count()
@@ -81,10 +201,26 @@ func (rm *RepositoryManager) UserFindByEmail(email string) (*models.User, error)
 	return rm.User().FindByEmail(email)
 }
 
+func (rm *RepositoryManager) UserFindByOIDCSubject(subject string) (*models.User, error) {
+	return rm.User().FindByOIDCSubject(subject)
+}
+
+func (rm *RepositoryManager) UserFindByLDAPDN(dn string) (*models.User, error) {
+	return rm.User().FindByLDAPDN(dn)
+}
+
+func (rm *RepositoryManager) UserFindBySCIMExternalID(externalID string) (*models.User, error) {
+	return rm.User().FindBySCIMExternalID(externalID)
+}
+
 func (rm *RepositoryManager) UserFindByID(id interface{}) (*models.User, error) {
 	return rm.User().FindByID(id)
 }
 
+func (rm *RepositoryManager) UserLockByID(id interface{}) (*models.User, error) {
+	return rm.User().LockByID(id)
+}
+
 func (rm *RepositoryManager) UserFindByIDs(ids []interface{}) (*models.Users, error) {
 	return rm.User().FindByIDs(ids)
 }
@@ -93,6 +229,34 @@ func (rm *RepositoryManager) UserGetRecent(limit int) (*models.Users, error) {
 	return rm.User().GetRecent(limit)
 }
 
+func (rm *RepositoryManager) UserFindPage(offset, limit int) (*models.Users, int, error) {
+	return rm.User().FindPage(offset, limit)
+}
+
+func (rm *RepositoryManager) UserFindByRole(role string) (*models.Users, error) {
+	return rm.User().FindByRole(role)
+}
+
+func (rm *RepositoryManager) UserFindByCalendarToken(token string) (*models.User, error) {
+	return rm.User().FindByCalendarToken(token)
+}
+
+func (rm *RepositoryManager) UserFindServiceAccounts() (*models.Users, error) {
+	return rm.User().FindServiceAccounts()
+}
+
+func (rm *RepositoryManager) UserCreate(user *models.User) (*validate.Errors, error) {
+	return rm.User().Create(user)
+}
+
+func (rm *RepositoryManager) UserSetRole(id interface{}, role string) error {
+	return rm.User().SetRole(id, role)
+}
+
+func (rm *RepositoryManager) UserSetForcePasswordReset(id interface{}, force bool) error {
+	return rm.User().SetForcePasswordReset(id, force)
+}
+
 // Hackathon operations
 func (rm *RepositoryManager) HackathonCount() (int, error) {
 	return rm.Hackathon().Count()
@@ -118,6 +282,26 @@ func (rm *RepositoryManager) HackathonGetActiveHackathonIDs() ([]int, error) {
 	return rm.Hackathon().GetActiveHackathonIDs()
 }
 
+func (rm *RepositoryManager) HackathonFindByIDsWithSchedule(ids []interface{}) (*models.Hackathons, error) {
+	return rm.Hackathon().FindByIDsWithSchedule(ids)
+}
+
+func (rm *RepositoryManager) HackathonLockDueForStatusTransition(now time.Time) (*models.Hackathons, error) {
+	return rm.Hackathon().LockDueForStatusTransition(now)
+}
+
+func (rm *RepositoryManager) HackathonFindUnfinished() (*models.Hackathons, error) {
+	return rm.Hackathon().FindUnfinished()
+}
+
+func (rm *RepositoryManager) HackathonFindPage(viewerID interface{}, includeHidden bool, after *PageCursor, limit int) (*models.Hackathons, error) {
+	return rm.Hackathon().FindPage(viewerID, includeHidden, after, limit)
+}
+
+func (rm *RepositoryManager) HackathonSetStatus(id interface{}, status string) error {
+	return rm.Hackathon().SetStatus(id, status)
+}
+
 // Project operations
 func (rm *RepositoryManager) ProjectCount() (int, error) {
 	return rm.Project().Count()
@@ -139,6 +323,10 @@ func (rm *RepositoryManager) ProjectFindByHackathonID(hackathonID interface{}) (
 	return rm.Project().FindByHackathonID(hackathonID)
 }
 
+func (rm *RepositoryManager) ProjectFindApprovedByHackathonID(hackathonID interface{}) (*models.Projects, error) {
+	return rm.Project().FindApprovedByHackathonID(hackathonID)
+}
+
 func (rm *RepositoryManager) ProjectFindByUserID(userID interface{}) (*models.Projects, error) {
 	return rm.Project().FindByUserID(userID)
 }
@@ -175,6 +363,22 @@ func (rm *RepositoryManager) ProjectIsUserMemberOfProject(projectID, userID inte
 	return rm.Project().IsUserMemberOfProject(projectID, userID)
 }
 
+func (rm *RepositoryManager) ProjectLockByID(id interface{}) (*models.Project, error) {
+	return rm.Project().LockByID(id)
+}
+
+func (rm *RepositoryManager) ProjectFindPendingReview() (*models.Projects, error) {
+	return rm.Project().FindPendingReview()
+}
+
+func (rm *RepositoryManager) ProjectCountPendingReview() (int, error) {
+	return rm.Project().CountPendingReview()
+}
+
+func (rm *RepositoryManager) ProjectFindVisiblePage(hackathonID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Projects, error) {
+	return rm.Project().FindVisiblePage(hackathonID, viewerID, includeAll, after, limit)
+}
+
 // Project Membership operations
 func (rm *RepositoryManager) ProjectMembershipFindByProjectIDAndUserID(projectID, userID interface{}) (*models.ProjectMembership, error) {
 	return rm.ProjectMembership().FindByProjectIDAndUserID(projectID, userID)
@@ -188,6 +392,10 @@ func (rm *RepositoryManager) ProjectMembershipIsUserMember(projectID, userID int
 	return rm.ProjectMembership().IsUserMember(projectID, userID)
 }
 
+func (rm *RepositoryManager) ProjectMembershipFindPageByProjectID(projectID interface{}, after *PageCursor, limit int) (*models.ProjectMemberships, error) {
+	return rm.ProjectMembership().FindPageByProjectID(projectID, after, limit)
+}
+
 // File operations
 func (rm *RepositoryManager) FileFindByID(id interface{}) (*models.File, error) {
 	return rm.File().FindByID(id)
@@ -205,11 +413,19 @@ func (rm *RepositoryManager) FileFindAllProjects() (*models.Projects, error) {
 	return rm.File().FindAllProjects()
 }
 
+func (rm *RepositoryManager) FileFindVisiblePage(hackathonID, projectID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Files, error) {
+	return rm.File().FindVisiblePage(hackathonID, projectID, viewerID, includeAll, after, limit)
+}
+
 // Company Allowed Domain operations
 func (rm *RepositoryManager) CompanyAllowedDomainIsDomainAllowed(domain string) (bool, error) {
 	return rm.CompanyAllowedDomain().IsDomainAllowed(domain)
 }
 
+func (rm *RepositoryManager) CompanyAllowedDomainCheckDomain(domain string, openRegistration bool) (*models.DomainCheck, error) {
+	return rm.CompanyAllowedDomain().CheckDomain(domain, openRegistration)
+}
+
 func (rm *RepositoryManager) CompanyAllowedDomainFindAllActive() (*models.CompanyAllowedDomains, error) {
 	return rm.CompanyAllowedDomain().FindAllActive()
 }
@@ -217,3 +433,232 @@ func (rm *RepositoryManager) CompanyAllowedDomainFindAllActive() (*models.Compan
 func (rm *RepositoryManager) CompanyAllowedDomainFindAll() (*models.CompanyAllowedDomains, error) {
 	return rm.CompanyAllowedDomain().FindAll()
 }
+
+func (rm *RepositoryManager) CompanyAllowedDomainFindByDomain(domain string) (*models.CompanyAllowedDomain, error) {
+	return rm.CompanyAllowedDomain().FindByDomain(domain)
+}
+
+func (rm *RepositoryManager) CompanyAllowedDomainCreate(domain *models.CompanyAllowedDomain) (*validate.Errors, error) {
+	return rm.CompanyAllowedDomain().Create(domain)
+}
+
+func (rm *RepositoryManager) CompanyAllowedDomainSetActive(id interface{}, active bool) error {
+	return rm.CompanyAllowedDomain().SetActive(id, active)
+}
+
+func (rm *RepositoryManager) CompanyAllowedDomainDelete(id interface{}) error {
+	return rm.CompanyAllowedDomain().Delete(id)
+}
+
+// Judging operations
+func (rm *RepositoryManager) JudgingFindCriteriaByHackathonID(hackathonID interface{}) (*models.JudgingCriteria, error) {
+	return rm.Judging().FindCriteriaByHackathonID(hackathonID)
+}
+
+func (rm *RepositoryManager) JudgingFindAssignmentsByHackathonID(hackathonID interface{}) (*models.JudgeAssignments, error) {
+	return rm.Judging().FindAssignmentsByHackathonID(hackathonID)
+}
+
+func (rm *RepositoryManager) JudgingFindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID interface{}) (*models.JudgeAssignments, error) {
+	return rm.Judging().FindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID)
+}
+
+func (rm *RepositoryManager) JudgingIsJudgeAssigned(projectID, judgeID interface{}) (bool, error) {
+	return rm.Judging().IsJudgeAssigned(projectID, judgeID)
+}
+
+func (rm *RepositoryManager) JudgingFindScoresByProjectIDAndJudgeID(projectID, judgeID interface{}) (*models.JudgeScores, error) {
+	return rm.Judging().FindScoresByProjectIDAndJudgeID(projectID, judgeID)
+}
+
+func (rm *RepositoryManager) JudgingFindScoresByHackathonID(hackathonID interface{}) (*models.JudgeScores, error) {
+	return rm.Judging().FindScoresByHackathonID(hackathonID)
+}
+
+// Vote operations
+func (rm *RepositoryManager) VoteFindByHackathonIDAndUserID(hackathonID, userID interface{}) (*models.Votes, error) {
+	return rm.Vote().FindByHackathonIDAndUserID(hackathonID, userID)
+}
+
+func (rm *RepositoryManager) VoteFindByProjectIDAndUserID(projectID, userID interface{}) (*models.Vote, error) {
+	return rm.Vote().FindByProjectIDAndUserID(projectID, userID)
+}
+
+func (rm *RepositoryManager) VoteCountByHackathonIDAndUserID(hackathonID, userID interface{}) (int, error) {
+	return rm.Vote().CountByHackathonIDAndUserID(hackathonID, userID)
+}
+
+func (rm *RepositoryManager) VoteTallyByHackathonID(hackathonID interface{}) (*models.VoteTallies, error) {
+	return rm.Vote().TallyByHackathonID(hackathonID)
+}
+
+// Award operations
+func (rm *RepositoryManager) AwardFindByID(id interface{}) (*models.Award, error) {
+	return rm.Award().FindByID(id)
+}
+
+func (rm *RepositoryManager) AwardFindByHackathonID(hackathonID interface{}) (*models.Awards, error) {
+	return rm.Award().FindByHackathonID(hackathonID)
+}
+
+func (rm *RepositoryManager) AwardFindWinnerByID(id interface{}) (*models.AwardWinner, error) {
+	return rm.Award().FindWinnerByID(id)
+}
+
+// ScheduleItem operations
+func (rm *RepositoryManager) ScheduleItemFindByID(id interface{}) (*models.ScheduleItem, error) {
+	return rm.ScheduleItem().FindByID(id)
+}
+
+func (rm *RepositoryManager) ScheduleItemFindByHackathonID(hackathonID interface{}) (*models.ScheduleItems, error) {
+	return rm.ScheduleItem().FindByHackathonID(hackathonID)
+}
+
+// TeamRequest operations
+func (rm *RepositoryManager) TeamRequestFindJoinRequestByID(id interface{}) (*models.JoinRequest, error) {
+	return rm.TeamRequest().FindJoinRequestByID(id)
+}
+
+func (rm *RepositoryManager) TeamRequestFindPendingJoinRequestsByProjectID(projectID interface{}) (*models.JoinRequests, error) {
+	return rm.TeamRequest().FindPendingJoinRequestsByProjectID(projectID)
+}
+
+func (rm *RepositoryManager) TeamRequestHasPendingJoinRequest(projectID, userID interface{}) (bool, error) {
+	return rm.TeamRequest().HasPendingJoinRequest(projectID, userID)
+}
+
+func (rm *RepositoryManager) TeamRequestFindInvitationByID(id interface{}) (*models.ProjectInvitation, error) {
+	return rm.TeamRequest().FindInvitationByID(id)
+}
+
+func (rm *RepositoryManager) TeamRequestFindPendingInvitationsByProjectID(projectID interface{}) (*models.ProjectInvitations, error) {
+	return rm.TeamRequest().FindPendingInvitationsByProjectID(projectID)
+}
+
+func (rm *RepositoryManager) TeamRequestFindPendingInvitationsByEmail(email string) (*models.ProjectInvitations, error) {
+	return rm.TeamRequest().FindPendingInvitationsByEmail(email)
+}
+
+// RecoveryCode operations
+func (rm *RepositoryManager) RecoveryCodeFindUnusedByUserIDAndHash(userID interface{}, codeHash string) (*models.RecoveryCode, error) {
+	return rm.RecoveryCode().FindUnusedByUserIDAndHash(userID, codeHash)
+}
+
+func (rm *RepositoryManager) RecoveryCodeCountUnusedByUserID(userID interface{}) (int, error) {
+	return rm.RecoveryCode().CountUnusedByUserID(userID)
+}
+
+func (rm *RepositoryManager) RecoveryCodeDeleteByUserID(userID interface{}) error {
+	return rm.RecoveryCode().DeleteByUserID(userID)
+}
+
+// PasswordReset operations
+func (rm *RepositoryManager) PasswordResetFindByTokenHash(tokenHash string) (*models.PasswordReset, error) {
+	return rm.PasswordReset().FindByTokenHash(tokenHash)
+}
+
+func (rm *RepositoryManager) PasswordResetCountByEmailSince(email string, since time.Time) (int, error) {
+	return rm.PasswordReset().CountByEmailSince(email, since)
+}
+
+func (rm *RepositoryManager) PasswordResetCountByIPAddressSince(ipAddress string, since time.Time) (int, error) {
+	return rm.PasswordReset().CountByIPAddressSince(ipAddress, since)
+}
+
+func (rm *RepositoryManager) PasswordResetExpireByUserID(userID interface{}) error {
+	return rm.PasswordReset().ExpireByUserID(userID)
+}
+
+// UserSession operations
+func (rm *RepositoryManager) UserSessionFindByID(id interface{}) (*models.UserSession, error) {
+	return rm.UserSession().FindByID(id)
+}
+
+func (rm *RepositoryManager) UserSessionFindByUserID(userID interface{}) (*models.UserSessions, error) {
+	return rm.UserSession().FindByUserID(userID)
+}
+
+func (rm *RepositoryManager) UserSessionCountByUserID(userID interface{}) (int, error) {
+	return rm.UserSession().CountByUserID(userID)
+}
+
+func (rm *RepositoryManager) UserSessionDeleteByUserID(userID interface{}) error {
+	return rm.UserSession().DeleteByUserID(userID)
+}
+
+func (rm *RepositoryManager) UserSessionDeleteExpired(idleBefore, createdBefore time.Time) (int, error) {
+	return rm.UserSession().DeleteExpired(idleBefore, createdBefore)
+}
+
+// LoginAttempt operations
+func (rm *RepositoryManager) LoginAttemptFindByEmailSince(email string, since time.Time) (*models.LoginAttempts, error) {
+	return rm.LoginAttempt().FindByEmailSince(email, since)
+}
+
+func (rm *RepositoryManager) LoginAttemptFindByIPAddressSince(ipAddress string, since time.Time) (*models.LoginAttempts, error) {
+	return rm.LoginAttempt().FindByIPAddressSince(ipAddress, since)
+}
+
+func (rm *RepositoryManager) LoginAttemptFindLocked(since time.Time, threshold int) (*models.LockedAccounts, error) {
+	return rm.LoginAttempt().FindLocked(since, threshold)
+}
+
+func (rm *RepositoryManager) LoginAttemptDeleteByEmail(email string) error {
+	return rm.LoginAttempt().DeleteByEmail(email)
+}
+
+// SCIMToken operations
+func (rm *RepositoryManager) SCIMTokenFindAll() (*models.SCIMTokens, error) {
+	return rm.SCIMToken().FindAll()
+}
+
+func (rm *RepositoryManager) SCIMTokenFindByTokenHash(tokenHash string) (*models.SCIMToken, error) {
+	return rm.SCIMToken().FindByTokenHash(tokenHash)
+}
+
+func (rm *RepositoryManager) SCIMTokenTouch(id interface{}, at time.Time) error {
+	return rm.SCIMToken().Touch(id, at)
+}
+
+// Invitation operations
+func (rm *RepositoryManager) InvitationFindByID(id interface{}) (*models.Invitation, error) {
+	return rm.Invitation().FindByID(id)
+}
+
+func (rm *RepositoryManager) InvitationFindAll() (*models.Invitations, error) {
+	return rm.Invitation().FindAll()
+}
+
+func (rm *RepositoryManager) InvitationRedeem(id interface{}, now time.Time) (bool, error) {
+	return rm.Invitation().Redeem(id, now)
+}
+
+// APIToken operations
+func (rm *RepositoryManager) APITokenFindByUserID(userID interface{}) (*models.APITokens, error) {
+	return rm.APIToken().FindByUserID(userID)
+}
+
+func (rm *RepositoryManager) APITokenFindByTokenHash(tokenHash string) (*models.APIToken, error) {
+	return rm.APIToken().FindByTokenHash(tokenHash)
+}
+
+func (rm *RepositoryManager) APITokenTouch(id interface{}, at time.Time) error {
+	return rm.APIToken().Touch(id, at)
+}
+
+func (rm *RepositoryManager) APITokenFindByID(id interface{}) (*models.APIToken, error) {
+	return rm.APIToken().FindByID(id)
+}
+
+func (rm *RepositoryManager) APITokenFindAll() (*models.APITokens, error) {
+	return rm.APIToken().FindAll()
+}
+
+// AuditLog operations
+func (rm *RepositoryManager) AuditLogFindBetween(since, until time.Time, action string) (*models.AuditLogs, error) {
+	return rm.AuditLog().FindBetween(since, until, action)
+}
+
+func (rm *RepositoryManager) AuditLogCreate(auditLog *models.AuditLog) error {
+	return rm.AuditLog().Create(auditLog)
+}
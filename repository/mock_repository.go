@@ -11,8 +11,10 @@ package repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	models "github.com/arxdsilva/hackathon/models"
+	validate "github.com/gobuffalo/validate/v3"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +42,198 @@ func (m *MockRepositoryInterface) EXPECT() *MockRepositoryInterfaceMockRecorder
 	return m.recorder
 }
 
+// APITokenFindAll mocks base method.
+func (m *MockRepositoryInterface) APITokenFindAll() (*models.APITokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APITokenFindAll")
+	ret0, _ := ret[0].(*models.APITokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APITokenFindAll indicates an expected call of APITokenFindAll.
+func (mr *MockRepositoryInterfaceMockRecorder) APITokenFindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenFindAll", reflect.TypeOf((*MockRepositoryInterface)(nil).APITokenFindAll))
+}
+
+// APITokenFindByID mocks base method.
+func (m *MockRepositoryInterface) APITokenFindByID(id any) (*models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APITokenFindByID", id)
+	ret0, _ := ret[0].(*models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APITokenFindByID indicates an expected call of APITokenFindByID.
+func (mr *MockRepositoryInterfaceMockRecorder) APITokenFindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).APITokenFindByID), id)
+}
+
+// APITokenFindByTokenHash mocks base method.
+func (m *MockRepositoryInterface) APITokenFindByTokenHash(tokenHash string) (*models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APITokenFindByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APITokenFindByTokenHash indicates an expected call of APITokenFindByTokenHash.
+func (mr *MockRepositoryInterfaceMockRecorder) APITokenFindByTokenHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenFindByTokenHash", reflect.TypeOf((*MockRepositoryInterface)(nil).APITokenFindByTokenHash), tokenHash)
+}
+
+// APITokenFindByUserID mocks base method.
+func (m *MockRepositoryInterface) APITokenFindByUserID(userID any) (*models.APITokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APITokenFindByUserID", userID)
+	ret0, _ := ret[0].(*models.APITokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APITokenFindByUserID indicates an expected call of APITokenFindByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) APITokenFindByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenFindByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).APITokenFindByUserID), userID)
+}
+
+// APITokenTouch mocks base method.
+func (m *MockRepositoryInterface) APITokenTouch(id any, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APITokenTouch", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// APITokenTouch indicates an expected call of APITokenTouch.
+func (mr *MockRepositoryInterfaceMockRecorder) APITokenTouch(id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APITokenTouch", reflect.TypeOf((*MockRepositoryInterface)(nil).APITokenTouch), id, at)
+}
+
+// AuditLogCreate mocks base method.
+func (m *MockRepositoryInterface) AuditLogCreate(auditLog *models.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLogCreate", auditLog)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuditLogCreate indicates an expected call of AuditLogCreate.
+func (mr *MockRepositoryInterfaceMockRecorder) AuditLogCreate(auditLog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLogCreate", reflect.TypeOf((*MockRepositoryInterface)(nil).AuditLogCreate), auditLog)
+}
+
+// AuditLogFindBetween mocks base method.
+func (m *MockRepositoryInterface) AuditLogFindBetween(since, until time.Time, action string) (*models.AuditLogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLogFindBetween", since, until, action)
+	ret0, _ := ret[0].(*models.AuditLogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuditLogFindBetween indicates an expected call of AuditLogFindBetween.
+func (mr *MockRepositoryInterfaceMockRecorder) AuditLogFindBetween(since, until, action any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLogFindBetween", reflect.TypeOf((*MockRepositoryInterface)(nil).AuditLogFindBetween), since, until, action)
+}
+
+// AwardFindByHackathonID mocks base method.
+func (m *MockRepositoryInterface) AwardFindByHackathonID(hackathonID any) (*models.Awards, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardFindByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Awards)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardFindByHackathonID indicates an expected call of AwardFindByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) AwardFindByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardFindByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).AwardFindByHackathonID), hackathonID)
+}
+
+// AwardFindByID mocks base method.
+func (m *MockRepositoryInterface) AwardFindByID(id any) (*models.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardFindByID", id)
+	ret0, _ := ret[0].(*models.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardFindByID indicates an expected call of AwardFindByID.
+func (mr *MockRepositoryInterfaceMockRecorder) AwardFindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).AwardFindByID), id)
+}
+
+// AwardFindWinnerByID mocks base method.
+func (m *MockRepositoryInterface) AwardFindWinnerByID(id any) (*models.AwardWinner, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardFindWinnerByID", id)
+	ret0, _ := ret[0].(*models.AwardWinner)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardFindWinnerByID indicates an expected call of AwardFindWinnerByID.
+func (mr *MockRepositoryInterfaceMockRecorder) AwardFindWinnerByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardFindWinnerByID", reflect.TypeOf((*MockRepositoryInterface)(nil).AwardFindWinnerByID), id)
+}
+
+// CompanyAllowedDomainCheckDomain mocks base method.
+func (m *MockRepositoryInterface) CompanyAllowedDomainCheckDomain(domain string, openRegistration bool) (*models.DomainCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompanyAllowedDomainCheckDomain", domain, openRegistration)
+	ret0, _ := ret[0].(*models.DomainCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompanyAllowedDomainCheckDomain indicates an expected call of CompanyAllowedDomainCheckDomain.
+func (mr *MockRepositoryInterfaceMockRecorder) CompanyAllowedDomainCheckDomain(domain, openRegistration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAllowedDomainCheckDomain", reflect.TypeOf((*MockRepositoryInterface)(nil).CompanyAllowedDomainCheckDomain), domain, openRegistration)
+}
+
+// CompanyAllowedDomainCreate mocks base method.
+func (m *MockRepositoryInterface) CompanyAllowedDomainCreate(domain *models.CompanyAllowedDomain) (*validate.Errors, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompanyAllowedDomainCreate", domain)
+	ret0, _ := ret[0].(*validate.Errors)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompanyAllowedDomainCreate indicates an expected call of CompanyAllowedDomainCreate.
+func (mr *MockRepositoryInterfaceMockRecorder) CompanyAllowedDomainCreate(domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAllowedDomainCreate", reflect.TypeOf((*MockRepositoryInterface)(nil).CompanyAllowedDomainCreate), domain)
+}
+
+// CompanyAllowedDomainDelete mocks base method.
+func (m *MockRepositoryInterface) CompanyAllowedDomainDelete(id any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompanyAllowedDomainDelete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompanyAllowedDomainDelete indicates an expected call of CompanyAllowedDomainDelete.
+func (mr *MockRepositoryInterfaceMockRecorder) CompanyAllowedDomainDelete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAllowedDomainDelete", reflect.TypeOf((*MockRepositoryInterface)(nil).CompanyAllowedDomainDelete), id)
+}
+
 // CompanyAllowedDomainFindAll mocks base method.
 func (m *MockRepositoryInterface) CompanyAllowedDomainFindAll() (*models.CompanyAllowedDomains, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +264,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) CompanyAllowedDomainFindAllActive
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAllowedDomainFindAllActive", reflect.TypeOf((*MockRepositoryInterface)(nil).CompanyAllowedDomainFindAllActive))
 }
 
+// CompanyAllowedDomainFindByDomain mocks base method.
+func (m *MockRepositoryInterface) CompanyAllowedDomainFindByDomain(domain string) (*models.CompanyAllowedDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompanyAllowedDomainFindByDomain", domain)
+	ret0, _ := ret[0].(*models.CompanyAllowedDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompanyAllowedDomainFindByDomain indicates an expected call of CompanyAllowedDomainFindByDomain.
+func (mr *MockRepositoryInterfaceMockRecorder) CompanyAllowedDomainFindByDomain(domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAllowedDomainFindByDomain", reflect.TypeOf((*MockRepositoryInterface)(nil).CompanyAllowedDomainFindByDomain), domain)
+}
+
 // CompanyAllowedDomainIsDomainAllowed mocks base method.
 func (m *MockRepositoryInterface) CompanyAllowedDomainIsDomainAllowed(domain string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -85,6 +294,20 @@ func (mr *MockRepositoryInterfaceMockRecorder) CompanyAllowedDomainIsDomainAllow
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAllowedDomainIsDomainAllowed", reflect.TypeOf((*MockRepositoryInterface)(nil).CompanyAllowedDomainIsDomainAllowed), domain)
 }
 
+// CompanyAllowedDomainSetActive mocks base method.
+func (m *MockRepositoryInterface) CompanyAllowedDomainSetActive(id any, active bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompanyAllowedDomainSetActive", id, active)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompanyAllowedDomainSetActive indicates an expected call of CompanyAllowedDomainSetActive.
+func (mr *MockRepositoryInterfaceMockRecorder) CompanyAllowedDomainSetActive(id, active any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAllowedDomainSetActive", reflect.TypeOf((*MockRepositoryInterface)(nil).CompanyAllowedDomainSetActive), id, active)
+}
+
 // FileFindAll mocks base method.
 func (m *MockRepositoryInterface) FileFindAll() (*models.Files, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +368,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) FileFindByID(id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).FileFindByID), id)
 }
 
+// FileFindVisiblePage mocks base method.
+func (m *MockRepositoryInterface) FileFindVisiblePage(hackathonID, projectID string, viewerID any, includeAll bool, after *PageCursor, limit int) (*models.Files, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileFindVisiblePage", hackathonID, projectID, viewerID, includeAll, after, limit)
+	ret0, _ := ret[0].(*models.Files)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FileFindVisiblePage indicates an expected call of FileFindVisiblePage.
+func (mr *MockRepositoryInterfaceMockRecorder) FileFindVisiblePage(hackathonID, projectID, viewerID, includeAll, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileFindVisiblePage", reflect.TypeOf((*MockRepositoryInterface)(nil).FileFindVisiblePage), hackathonID, projectID, viewerID, includeAll, after, limit)
+}
+
 // HackathonCount mocks base method.
 func (m *MockRepositoryInterface) HackathonCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +413,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) HackathonFindByID(id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonFindByID), id)
 }
 
+// HackathonFindByIDsWithSchedule mocks base method.
+func (m *MockRepositoryInterface) HackathonFindByIDsWithSchedule(ids []any) (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HackathonFindByIDsWithSchedule", ids)
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HackathonFindByIDsWithSchedule indicates an expected call of HackathonFindByIDsWithSchedule.
+func (mr *MockRepositoryInterfaceMockRecorder) HackathonFindByIDsWithSchedule(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonFindByIDsWithSchedule", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonFindByIDsWithSchedule), ids)
+}
+
 // HackathonFindByOwnerID mocks base method.
 func (m *MockRepositoryInterface) HackathonFindByOwnerID(ownerID any) (*models.Hackathons, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +443,36 @@ func (mr *MockRepositoryInterfaceMockRecorder) HackathonFindByOwnerID(ownerID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonFindByOwnerID", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonFindByOwnerID), ownerID)
 }
 
+// HackathonFindPage mocks base method.
+func (m *MockRepositoryInterface) HackathonFindPage(viewerID any, includeHidden bool, after *PageCursor, limit int) (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HackathonFindPage", viewerID, includeHidden, after, limit)
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HackathonFindPage indicates an expected call of HackathonFindPage.
+func (mr *MockRepositoryInterfaceMockRecorder) HackathonFindPage(viewerID, includeHidden, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonFindPage", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonFindPage), viewerID, includeHidden, after, limit)
+}
+
+// HackathonFindUnfinished mocks base method.
+func (m *MockRepositoryInterface) HackathonFindUnfinished() (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HackathonFindUnfinished")
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HackathonFindUnfinished indicates an expected call of HackathonFindUnfinished.
+func (mr *MockRepositoryInterfaceMockRecorder) HackathonFindUnfinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonFindUnfinished", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonFindUnfinished))
+}
+
 // HackathonGetActiveHackathonIDs mocks base method.
 func (m *MockRepositoryInterface) HackathonGetActiveHackathonIDs() ([]int, error) {
 	m.ctrl.T.Helper()
@@ -235,1001 +518,3350 @@ func (mr *MockRepositoryInterfaceMockRecorder) HackathonGetRecent(limit any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonGetRecent", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonGetRecent), limit)
 }
 
-// ProjectCount mocks base method.
-func (m *MockRepositoryInterface) ProjectCount() (int, error) {
+// HackathonLockDueForStatusTransition mocks base method.
+func (m *MockRepositoryInterface) HackathonLockDueForStatusTransition(now time.Time) (*models.Hackathons, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectCount")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "HackathonLockDueForStatusTransition", now)
+	ret0, _ := ret[0].(*models.Hackathons)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectCount indicates an expected call of ProjectCount.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectCount() *gomock.Call {
+// HackathonLockDueForStatusTransition indicates an expected call of HackathonLockDueForStatusTransition.
+func (mr *MockRepositoryInterfaceMockRecorder) HackathonLockDueForStatusTransition(now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCount", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonLockDueForStatusTransition", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonLockDueForStatusTransition), now)
 }
 
-// ProjectCountActive mocks base method.
-func (m *MockRepositoryInterface) ProjectCountActive() (int, error) {
+// HackathonSetStatus mocks base method.
+func (m *MockRepositoryInterface) HackathonSetStatus(id any, status string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectCountActive")
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "HackathonSetStatus", id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ProjectCountActive indicates an expected call of ProjectCountActive.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectCountActive() *gomock.Call {
+// HackathonSetStatus indicates an expected call of HackathonSetStatus.
+func (mr *MockRepositoryInterfaceMockRecorder) HackathonSetStatus(id, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCountActive", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCountActive))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HackathonSetStatus", reflect.TypeOf((*MockRepositoryInterface)(nil).HackathonSetStatus), id, status)
 }
 
-// ProjectCountMembershipsByProjectID mocks base method.
-func (m *MockRepositoryInterface) ProjectCountMembershipsByProjectID(projectID any) (int, error) {
+// InvitationFindAll mocks base method.
+func (m *MockRepositoryInterface) InvitationFindAll() (*models.Invitations, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectCountMembershipsByProjectID", projectID)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "InvitationFindAll")
+	ret0, _ := ret[0].(*models.Invitations)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectCountMembershipsByProjectID indicates an expected call of ProjectCountMembershipsByProjectID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectCountMembershipsByProjectID(projectID any) *gomock.Call {
+// InvitationFindAll indicates an expected call of InvitationFindAll.
+func (mr *MockRepositoryInterfaceMockRecorder) InvitationFindAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCountMembershipsByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCountMembershipsByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvitationFindAll", reflect.TypeOf((*MockRepositoryInterface)(nil).InvitationFindAll))
 }
 
-// ProjectCountPresenting mocks base method.
-func (m *MockRepositoryInterface) ProjectCountPresenting() (int, error) {
+// InvitationFindByID mocks base method.
+func (m *MockRepositoryInterface) InvitationFindByID(id any) (*models.Invitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectCountPresenting")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "InvitationFindByID", id)
+	ret0, _ := ret[0].(*models.Invitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectCountPresenting indicates an expected call of ProjectCountPresenting.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectCountPresenting() *gomock.Call {
+// InvitationFindByID indicates an expected call of InvitationFindByID.
+func (mr *MockRepositoryInterfaceMockRecorder) InvitationFindByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCountPresenting", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCountPresenting))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvitationFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).InvitationFindByID), id)
 }
 
-// ProjectFindByHackathonID mocks base method.
-func (m *MockRepositoryInterface) ProjectFindByHackathonID(hackathonID any) (*models.Projects, error) {
+// InvitationRedeem mocks base method.
+func (m *MockRepositoryInterface) InvitationRedeem(id any, now time.Time) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectFindByHackathonID", hackathonID)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "InvitationRedeem", id, now)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectFindByHackathonID indicates an expected call of ProjectFindByHackathonID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByHackathonID(hackathonID any) *gomock.Call {
+// InvitationRedeem indicates an expected call of InvitationRedeem.
+func (mr *MockRepositoryInterfaceMockRecorder) InvitationRedeem(id, now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByHackathonID), hackathonID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvitationRedeem", reflect.TypeOf((*MockRepositoryInterface)(nil).InvitationRedeem), id, now)
 }
 
-// ProjectFindByID mocks base method.
-func (m *MockRepositoryInterface) ProjectFindByID(id any) (*models.Project, error) {
+// JudgingFindAssignmentsByHackathonID mocks base method.
+func (m *MockRepositoryInterface) JudgingFindAssignmentsByHackathonID(hackathonID any) (*models.JudgeAssignments, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectFindByID", id)
-	ret0, _ := ret[0].(*models.Project)
+	ret := m.ctrl.Call(m, "JudgingFindAssignmentsByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.JudgeAssignments)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectFindByID indicates an expected call of ProjectFindByID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByID(id any) *gomock.Call {
+// JudgingFindAssignmentsByHackathonID indicates an expected call of JudgingFindAssignmentsByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) JudgingFindAssignmentsByHackathonID(hackathonID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JudgingFindAssignmentsByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).JudgingFindAssignmentsByHackathonID), hackathonID)
 }
 
-// ProjectFindByUserID mocks base method.
-func (m *MockRepositoryInterface) ProjectFindByUserID(userID any) (*models.Projects, error) {
+// JudgingFindAssignmentsByHackathonIDAndJudgeID mocks base method.
+func (m *MockRepositoryInterface) JudgingFindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID any) (*models.JudgeAssignments, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectFindByUserID", userID)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "JudgingFindAssignmentsByHackathonIDAndJudgeID", hackathonID, judgeID)
+	ret0, _ := ret[0].(*models.JudgeAssignments)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectFindByUserID indicates an expected call of ProjectFindByUserID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByUserID(userID any) *gomock.Call {
+// JudgingFindAssignmentsByHackathonIDAndJudgeID indicates an expected call of JudgingFindAssignmentsByHackathonIDAndJudgeID.
+func (mr *MockRepositoryInterfaceMockRecorder) JudgingFindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JudgingFindAssignmentsByHackathonIDAndJudgeID", reflect.TypeOf((*MockRepositoryInterface)(nil).JudgingFindAssignmentsByHackathonIDAndJudgeID), hackathonID, judgeID)
 }
 
-// ProjectFindByUserIDWithHackathon mocks base method.
-func (m *MockRepositoryInterface) ProjectFindByUserIDWithHackathon(userID any) (*models.Projects, error) {
+// JudgingFindCriteriaByHackathonID mocks base method.
+func (m *MockRepositoryInterface) JudgingFindCriteriaByHackathonID(hackathonID any) (*models.JudgingCriteria, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectFindByUserIDWithHackathon", userID)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "JudgingFindCriteriaByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.JudgingCriteria)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectFindByUserIDWithHackathon indicates an expected call of ProjectFindByUserIDWithHackathon.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByUserIDWithHackathon(userID any) *gomock.Call {
+// JudgingFindCriteriaByHackathonID indicates an expected call of JudgingFindCriteriaByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) JudgingFindCriteriaByHackathonID(hackathonID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByUserIDWithHackathon", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByUserIDWithHackathon), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JudgingFindCriteriaByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).JudgingFindCriteriaByHackathonID), hackathonID)
 }
 
-// ProjectFindPresentingByHackathonID mocks base method.
-func (m *MockRepositoryInterface) ProjectFindPresentingByHackathonID(hackathonID any) (*models.Projects, error) {
+// JudgingFindScoresByHackathonID mocks base method.
+func (m *MockRepositoryInterface) JudgingFindScoresByHackathonID(hackathonID any) (*models.JudgeScores, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectFindPresentingByHackathonID", hackathonID)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "JudgingFindScoresByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.JudgeScores)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectFindPresentingByHackathonID indicates an expected call of ProjectFindPresentingByHackathonID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindPresentingByHackathonID(hackathonID any) *gomock.Call {
+// JudgingFindScoresByHackathonID indicates an expected call of JudgingFindScoresByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) JudgingFindScoresByHackathonID(hackathonID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindPresentingByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindPresentingByHackathonID), hackathonID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JudgingFindScoresByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).JudgingFindScoresByHackathonID), hackathonID)
 }
 
-// ProjectFindPresentingFromActiveHackathons mocks base method.
-func (m *MockRepositoryInterface) ProjectFindPresentingFromActiveHackathons() (*models.Projects, error) {
+// JudgingFindScoresByProjectIDAndJudgeID mocks base method.
+func (m *MockRepositoryInterface) JudgingFindScoresByProjectIDAndJudgeID(projectID, judgeID any) (*models.JudgeScores, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectFindPresentingFromActiveHackathons")
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "JudgingFindScoresByProjectIDAndJudgeID", projectID, judgeID)
+	ret0, _ := ret[0].(*models.JudgeScores)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectFindPresentingFromActiveHackathons indicates an expected call of ProjectFindPresentingFromActiveHackathons.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindPresentingFromActiveHackathons() *gomock.Call {
+// JudgingFindScoresByProjectIDAndJudgeID indicates an expected call of JudgingFindScoresByProjectIDAndJudgeID.
+func (mr *MockRepositoryInterfaceMockRecorder) JudgingFindScoresByProjectIDAndJudgeID(projectID, judgeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindPresentingFromActiveHackathons", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindPresentingFromActiveHackathons))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JudgingFindScoresByProjectIDAndJudgeID", reflect.TypeOf((*MockRepositoryInterface)(nil).JudgingFindScoresByProjectIDAndJudgeID), projectID, judgeID)
 }
 
-// ProjectGetFilesByProjectID mocks base method.
-func (m *MockRepositoryInterface) ProjectGetFilesByProjectID(projectID any) (*models.Files, error) {
+// JudgingIsJudgeAssigned mocks base method.
+func (m *MockRepositoryInterface) JudgingIsJudgeAssigned(projectID, judgeID any) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectGetFilesByProjectID", projectID)
-	ret0, _ := ret[0].(*models.Files)
+	ret := m.ctrl.Call(m, "JudgingIsJudgeAssigned", projectID, judgeID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectGetFilesByProjectID indicates an expected call of ProjectGetFilesByProjectID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectGetFilesByProjectID(projectID any) *gomock.Call {
+// JudgingIsJudgeAssigned indicates an expected call of JudgingIsJudgeAssigned.
+func (mr *MockRepositoryInterfaceMockRecorder) JudgingIsJudgeAssigned(projectID, judgeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGetFilesByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectGetFilesByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JudgingIsJudgeAssigned", reflect.TypeOf((*MockRepositoryInterface)(nil).JudgingIsJudgeAssigned), projectID, judgeID)
 }
 
-// ProjectGetMembershipsByProjectID mocks base method.
-func (m *MockRepositoryInterface) ProjectGetMembershipsByProjectID(projectID any) (*models.ProjectMemberships, error) {
+// LoginAttemptDeleteByEmail mocks base method.
+func (m *MockRepositoryInterface) LoginAttemptDeleteByEmail(email string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectGetMembershipsByProjectID", projectID)
-	ret0, _ := ret[0].(*models.ProjectMemberships)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "LoginAttemptDeleteByEmail", email)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ProjectGetMembershipsByProjectID indicates an expected call of ProjectGetMembershipsByProjectID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectGetMembershipsByProjectID(projectID any) *gomock.Call {
+// LoginAttemptDeleteByEmail indicates an expected call of LoginAttemptDeleteByEmail.
+func (mr *MockRepositoryInterfaceMockRecorder) LoginAttemptDeleteByEmail(email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGetMembershipsByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectGetMembershipsByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginAttemptDeleteByEmail", reflect.TypeOf((*MockRepositoryInterface)(nil).LoginAttemptDeleteByEmail), email)
 }
 
-// ProjectGetRecent mocks base method.
-func (m *MockRepositoryInterface) ProjectGetRecent(limit int) (*models.Projects, error) {
+// LoginAttemptFindByEmailSince mocks base method.
+func (m *MockRepositoryInterface) LoginAttemptFindByEmailSince(email string, since time.Time) (*models.LoginAttempts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectGetRecent", limit)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "LoginAttemptFindByEmailSince", email, since)
+	ret0, _ := ret[0].(*models.LoginAttempts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectGetRecent indicates an expected call of ProjectGetRecent.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectGetRecent(limit any) *gomock.Call {
+// LoginAttemptFindByEmailSince indicates an expected call of LoginAttemptFindByEmailSince.
+func (mr *MockRepositoryInterfaceMockRecorder) LoginAttemptFindByEmailSince(email, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGetRecent", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectGetRecent), limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginAttemptFindByEmailSince", reflect.TypeOf((*MockRepositoryInterface)(nil).LoginAttemptFindByEmailSince), email, since)
 }
 
-// ProjectIsUserMemberOfProject mocks base method.
-func (m *MockRepositoryInterface) ProjectIsUserMemberOfProject(projectID, userID any) (bool, error) {
+// LoginAttemptFindByIPAddressSince mocks base method.
+func (m *MockRepositoryInterface) LoginAttemptFindByIPAddressSince(ipAddress string, since time.Time) (*models.LoginAttempts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectIsUserMemberOfProject", projectID, userID)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "LoginAttemptFindByIPAddressSince", ipAddress, since)
+	ret0, _ := ret[0].(*models.LoginAttempts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectIsUserMemberOfProject indicates an expected call of ProjectIsUserMemberOfProject.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectIsUserMemberOfProject(projectID, userID any) *gomock.Call {
+// LoginAttemptFindByIPAddressSince indicates an expected call of LoginAttemptFindByIPAddressSince.
+func (mr *MockRepositoryInterfaceMockRecorder) LoginAttemptFindByIPAddressSince(ipAddress, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIsUserMemberOfProject", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectIsUserMemberOfProject), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginAttemptFindByIPAddressSince", reflect.TypeOf((*MockRepositoryInterface)(nil).LoginAttemptFindByIPAddressSince), ipAddress, since)
 }
 
-// ProjectMembershipCountByProjectID mocks base method.
-func (m *MockRepositoryInterface) ProjectMembershipCountByProjectID(projectID any) (int, error) {
+// LoginAttemptFindLocked mocks base method.
+func (m *MockRepositoryInterface) LoginAttemptFindLocked(since time.Time, threshold int) (*models.LockedAccounts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectMembershipCountByProjectID", projectID)
+	ret := m.ctrl.Call(m, "LoginAttemptFindLocked", since, threshold)
+	ret0, _ := ret[0].(*models.LockedAccounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginAttemptFindLocked indicates an expected call of LoginAttemptFindLocked.
+func (mr *MockRepositoryInterfaceMockRecorder) LoginAttemptFindLocked(since, threshold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginAttemptFindLocked", reflect.TypeOf((*MockRepositoryInterface)(nil).LoginAttemptFindLocked), since, threshold)
+}
+
+// PasswordResetCountByEmailSince mocks base method.
+func (m *MockRepositoryInterface) PasswordResetCountByEmailSince(email string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetCountByEmailSince", email, since)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectMembershipCountByProjectID indicates an expected call of ProjectMembershipCountByProjectID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectMembershipCountByProjectID(projectID any) *gomock.Call {
+// PasswordResetCountByEmailSince indicates an expected call of PasswordResetCountByEmailSince.
+func (mr *MockRepositoryInterfaceMockRecorder) PasswordResetCountByEmailSince(email, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembershipCountByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectMembershipCountByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetCountByEmailSince", reflect.TypeOf((*MockRepositoryInterface)(nil).PasswordResetCountByEmailSince), email, since)
 }
 
-// ProjectMembershipFindByProjectIDAndUserID mocks base method.
-func (m *MockRepositoryInterface) ProjectMembershipFindByProjectIDAndUserID(projectID, userID any) (*models.ProjectMembership, error) {
+// PasswordResetCountByIPAddressSince mocks base method.
+func (m *MockRepositoryInterface) PasswordResetCountByIPAddressSince(ipAddress string, since time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectMembershipFindByProjectIDAndUserID", projectID, userID)
-	ret0, _ := ret[0].(*models.ProjectMembership)
+	ret := m.ctrl.Call(m, "PasswordResetCountByIPAddressSince", ipAddress, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PasswordResetCountByIPAddressSince indicates an expected call of PasswordResetCountByIPAddressSince.
+func (mr *MockRepositoryInterfaceMockRecorder) PasswordResetCountByIPAddressSince(ipAddress, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetCountByIPAddressSince", reflect.TypeOf((*MockRepositoryInterface)(nil).PasswordResetCountByIPAddressSince), ipAddress, since)
+}
+
+// PasswordResetExpireByUserID mocks base method.
+func (m *MockRepositoryInterface) PasswordResetExpireByUserID(userID any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetExpireByUserID", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PasswordResetExpireByUserID indicates an expected call of PasswordResetExpireByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) PasswordResetExpireByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetExpireByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).PasswordResetExpireByUserID), userID)
+}
+
+// PasswordResetFindByTokenHash mocks base method.
+func (m *MockRepositoryInterface) PasswordResetFindByTokenHash(tokenHash string) (*models.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetFindByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PasswordResetFindByTokenHash indicates an expected call of PasswordResetFindByTokenHash.
+func (mr *MockRepositoryInterfaceMockRecorder) PasswordResetFindByTokenHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetFindByTokenHash", reflect.TypeOf((*MockRepositoryInterface)(nil).PasswordResetFindByTokenHash), tokenHash)
+}
+
+// ProjectCount mocks base method.
+func (m *MockRepositoryInterface) ProjectCount() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCount")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCount indicates an expected call of ProjectCount.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCount", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCount))
+}
+
+// ProjectCountActive mocks base method.
+func (m *MockRepositoryInterface) ProjectCountActive() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCountActive")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCountActive indicates an expected call of ProjectCountActive.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectCountActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCountActive", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCountActive))
+}
+
+// ProjectCountMembershipsByProjectID mocks base method.
+func (m *MockRepositoryInterface) ProjectCountMembershipsByProjectID(projectID any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCountMembershipsByProjectID", projectID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCountMembershipsByProjectID indicates an expected call of ProjectCountMembershipsByProjectID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectCountMembershipsByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCountMembershipsByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCountMembershipsByProjectID), projectID)
+}
+
+// ProjectCountPendingReview mocks base method.
+func (m *MockRepositoryInterface) ProjectCountPendingReview() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCountPendingReview")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCountPendingReview indicates an expected call of ProjectCountPendingReview.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectCountPendingReview() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCountPendingReview", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCountPendingReview))
+}
+
+// ProjectCountPresenting mocks base method.
+func (m *MockRepositoryInterface) ProjectCountPresenting() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectCountPresenting")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectCountPresenting indicates an expected call of ProjectCountPresenting.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectCountPresenting() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectCountPresenting", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectCountPresenting))
+}
+
+// ProjectFindApprovedByHackathonID mocks base method.
+func (m *MockRepositoryInterface) ProjectFindApprovedByHackathonID(hackathonID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindApprovedByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindApprovedByHackathonID indicates an expected call of ProjectFindApprovedByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindApprovedByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindApprovedByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindApprovedByHackathonID), hackathonID)
+}
+
+// ProjectFindByHackathonID mocks base method.
+func (m *MockRepositoryInterface) ProjectFindByHackathonID(hackathonID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindByHackathonID indicates an expected call of ProjectFindByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByHackathonID), hackathonID)
+}
+
+// ProjectFindByID mocks base method.
+func (m *MockRepositoryInterface) ProjectFindByID(id any) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindByID", id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindByID indicates an expected call of ProjectFindByID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByID), id)
+}
+
+// ProjectFindByUserID mocks base method.
+func (m *MockRepositoryInterface) ProjectFindByUserID(userID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindByUserID", userID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindByUserID indicates an expected call of ProjectFindByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByUserID), userID)
+}
+
+// ProjectFindByUserIDWithHackathon mocks base method.
+func (m *MockRepositoryInterface) ProjectFindByUserIDWithHackathon(userID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindByUserIDWithHackathon", userID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindByUserIDWithHackathon indicates an expected call of ProjectFindByUserIDWithHackathon.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindByUserIDWithHackathon(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindByUserIDWithHackathon", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindByUserIDWithHackathon), userID)
+}
+
+// ProjectFindPendingReview mocks base method.
+func (m *MockRepositoryInterface) ProjectFindPendingReview() (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindPendingReview")
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindPendingReview indicates an expected call of ProjectFindPendingReview.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindPendingReview() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindPendingReview", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindPendingReview))
+}
+
+// ProjectFindPresentingByHackathonID mocks base method.
+func (m *MockRepositoryInterface) ProjectFindPresentingByHackathonID(hackathonID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindPresentingByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindPresentingByHackathonID indicates an expected call of ProjectFindPresentingByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindPresentingByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindPresentingByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindPresentingByHackathonID), hackathonID)
+}
+
+// ProjectFindPresentingFromActiveHackathons mocks base method.
+func (m *MockRepositoryInterface) ProjectFindPresentingFromActiveHackathons() (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindPresentingFromActiveHackathons")
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindPresentingFromActiveHackathons indicates an expected call of ProjectFindPresentingFromActiveHackathons.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindPresentingFromActiveHackathons() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindPresentingFromActiveHackathons", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindPresentingFromActiveHackathons))
+}
+
+// ProjectFindVisiblePage mocks base method.
+func (m *MockRepositoryInterface) ProjectFindVisiblePage(hackathonID string, viewerID any, includeAll bool, after *PageCursor, limit int) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectFindVisiblePage", hackathonID, viewerID, includeAll, after, limit)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectFindVisiblePage indicates an expected call of ProjectFindVisiblePage.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectFindVisiblePage(hackathonID, viewerID, includeAll, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectFindVisiblePage", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectFindVisiblePage), hackathonID, viewerID, includeAll, after, limit)
+}
+
+// ProjectGetFilesByProjectID mocks base method.
+func (m *MockRepositoryInterface) ProjectGetFilesByProjectID(projectID any) (*models.Files, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectGetFilesByProjectID", projectID)
+	ret0, _ := ret[0].(*models.Files)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectGetFilesByProjectID indicates an expected call of ProjectGetFilesByProjectID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectGetFilesByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGetFilesByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectGetFilesByProjectID), projectID)
+}
+
+// ProjectGetMembershipsByProjectID mocks base method.
+func (m *MockRepositoryInterface) ProjectGetMembershipsByProjectID(projectID any) (*models.ProjectMemberships, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectGetMembershipsByProjectID", projectID)
+	ret0, _ := ret[0].(*models.ProjectMemberships)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectGetMembershipsByProjectID indicates an expected call of ProjectGetMembershipsByProjectID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectGetMembershipsByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGetMembershipsByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectGetMembershipsByProjectID), projectID)
+}
+
+// ProjectGetRecent mocks base method.
+func (m *MockRepositoryInterface) ProjectGetRecent(limit int) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectGetRecent", limit)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectGetRecent indicates an expected call of ProjectGetRecent.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectGetRecent(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectGetRecent", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectGetRecent), limit)
+}
+
+// ProjectIsUserMemberOfProject mocks base method.
+func (m *MockRepositoryInterface) ProjectIsUserMemberOfProject(projectID, userID any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectIsUserMemberOfProject", projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectIsUserMemberOfProject indicates an expected call of ProjectIsUserMemberOfProject.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectIsUserMemberOfProject(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectIsUserMemberOfProject", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectIsUserMemberOfProject), projectID, userID)
+}
+
+// ProjectLockByID mocks base method.
+func (m *MockRepositoryInterface) ProjectLockByID(id any) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectLockByID", id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectLockByID indicates an expected call of ProjectLockByID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectLockByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectLockByID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectLockByID), id)
+}
+
+// ProjectMembershipCountByProjectID mocks base method.
+func (m *MockRepositoryInterface) ProjectMembershipCountByProjectID(projectID any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectMembershipCountByProjectID", projectID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectMembershipCountByProjectID indicates an expected call of ProjectMembershipCountByProjectID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectMembershipCountByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembershipCountByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectMembershipCountByProjectID), projectID)
+}
+
+// ProjectMembershipFindByProjectIDAndUserID mocks base method.
+func (m *MockRepositoryInterface) ProjectMembershipFindByProjectIDAndUserID(projectID, userID any) (*models.ProjectMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectMembershipFindByProjectIDAndUserID", projectID, userID)
+	ret0, _ := ret[0].(*models.ProjectMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectMembershipFindByProjectIDAndUserID indicates an expected call of ProjectMembershipFindByProjectIDAndUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectMembershipFindByProjectIDAndUserID(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembershipFindByProjectIDAndUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectMembershipFindByProjectIDAndUserID), projectID, userID)
+}
+
+// ProjectMembershipFindPageByProjectID mocks base method.
+func (m *MockRepositoryInterface) ProjectMembershipFindPageByProjectID(projectID any, after *PageCursor, limit int) (*models.ProjectMemberships, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectMembershipFindPageByProjectID", projectID, after, limit)
+	ret0, _ := ret[0].(*models.ProjectMemberships)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectMembershipFindPageByProjectID indicates an expected call of ProjectMembershipFindPageByProjectID.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectMembershipFindPageByProjectID(projectID, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembershipFindPageByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectMembershipFindPageByProjectID), projectID, after, limit)
+}
+
+// ProjectMembershipIsUserMember mocks base method.
+func (m *MockRepositoryInterface) ProjectMembershipIsUserMember(projectID, userID any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectMembershipIsUserMember", projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProjectMembershipIsUserMember indicates an expected call of ProjectMembershipIsUserMember.
+func (mr *MockRepositoryInterfaceMockRecorder) ProjectMembershipIsUserMember(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembershipIsUserMember", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectMembershipIsUserMember), projectID, userID)
+}
+
+// RecoveryCodeCountUnusedByUserID mocks base method.
+func (m *MockRepositoryInterface) RecoveryCodeCountUnusedByUserID(userID any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoveryCodeCountUnusedByUserID", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoveryCodeCountUnusedByUserID indicates an expected call of RecoveryCodeCountUnusedByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) RecoveryCodeCountUnusedByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoveryCodeCountUnusedByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).RecoveryCodeCountUnusedByUserID), userID)
+}
+
+// RecoveryCodeDeleteByUserID mocks base method.
+func (m *MockRepositoryInterface) RecoveryCodeDeleteByUserID(userID any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoveryCodeDeleteByUserID", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecoveryCodeDeleteByUserID indicates an expected call of RecoveryCodeDeleteByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) RecoveryCodeDeleteByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoveryCodeDeleteByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).RecoveryCodeDeleteByUserID), userID)
+}
+
+// RecoveryCodeFindUnusedByUserIDAndHash mocks base method.
+func (m *MockRepositoryInterface) RecoveryCodeFindUnusedByUserIDAndHash(userID any, codeHash string) (*models.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoveryCodeFindUnusedByUserIDAndHash", userID, codeHash)
+	ret0, _ := ret[0].(*models.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoveryCodeFindUnusedByUserIDAndHash indicates an expected call of RecoveryCodeFindUnusedByUserIDAndHash.
+func (mr *MockRepositoryInterfaceMockRecorder) RecoveryCodeFindUnusedByUserIDAndHash(userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoveryCodeFindUnusedByUserIDAndHash", reflect.TypeOf((*MockRepositoryInterface)(nil).RecoveryCodeFindUnusedByUserIDAndHash), userID, codeHash)
+}
+
+// SCIMTokenFindAll mocks base method.
+func (m *MockRepositoryInterface) SCIMTokenFindAll() (*models.SCIMTokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SCIMTokenFindAll")
+	ret0, _ := ret[0].(*models.SCIMTokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SCIMTokenFindAll indicates an expected call of SCIMTokenFindAll.
+func (mr *MockRepositoryInterfaceMockRecorder) SCIMTokenFindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SCIMTokenFindAll", reflect.TypeOf((*MockRepositoryInterface)(nil).SCIMTokenFindAll))
+}
+
+// SCIMTokenFindByTokenHash mocks base method.
+func (m *MockRepositoryInterface) SCIMTokenFindByTokenHash(tokenHash string) (*models.SCIMToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SCIMTokenFindByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.SCIMToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SCIMTokenFindByTokenHash indicates an expected call of SCIMTokenFindByTokenHash.
+func (mr *MockRepositoryInterfaceMockRecorder) SCIMTokenFindByTokenHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SCIMTokenFindByTokenHash", reflect.TypeOf((*MockRepositoryInterface)(nil).SCIMTokenFindByTokenHash), tokenHash)
+}
+
+// SCIMTokenTouch mocks base method.
+func (m *MockRepositoryInterface) SCIMTokenTouch(id any, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SCIMTokenTouch", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SCIMTokenTouch indicates an expected call of SCIMTokenTouch.
+func (mr *MockRepositoryInterfaceMockRecorder) SCIMTokenTouch(id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SCIMTokenTouch", reflect.TypeOf((*MockRepositoryInterface)(nil).SCIMTokenTouch), id, at)
+}
+
+// ScheduleItemFindByHackathonID mocks base method.
+func (m *MockRepositoryInterface) ScheduleItemFindByHackathonID(hackathonID any) (*models.ScheduleItems, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleItemFindByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.ScheduleItems)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleItemFindByHackathonID indicates an expected call of ScheduleItemFindByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) ScheduleItemFindByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleItemFindByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).ScheduleItemFindByHackathonID), hackathonID)
+}
+
+// ScheduleItemFindByID mocks base method.
+func (m *MockRepositoryInterface) ScheduleItemFindByID(id any) (*models.ScheduleItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleItemFindByID", id)
+	ret0, _ := ret[0].(*models.ScheduleItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleItemFindByID indicates an expected call of ScheduleItemFindByID.
+func (mr *MockRepositoryInterfaceMockRecorder) ScheduleItemFindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleItemFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).ScheduleItemFindByID), id)
+}
+
+// TeamRequestFindInvitationByID mocks base method.
+func (m *MockRepositoryInterface) TeamRequestFindInvitationByID(id any) (*models.ProjectInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamRequestFindInvitationByID", id)
+	ret0, _ := ret[0].(*models.ProjectInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TeamRequestFindInvitationByID indicates an expected call of TeamRequestFindInvitationByID.
+func (mr *MockRepositoryInterfaceMockRecorder) TeamRequestFindInvitationByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamRequestFindInvitationByID", reflect.TypeOf((*MockRepositoryInterface)(nil).TeamRequestFindInvitationByID), id)
+}
+
+// TeamRequestFindJoinRequestByID mocks base method.
+func (m *MockRepositoryInterface) TeamRequestFindJoinRequestByID(id any) (*models.JoinRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamRequestFindJoinRequestByID", id)
+	ret0, _ := ret[0].(*models.JoinRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TeamRequestFindJoinRequestByID indicates an expected call of TeamRequestFindJoinRequestByID.
+func (mr *MockRepositoryInterfaceMockRecorder) TeamRequestFindJoinRequestByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamRequestFindJoinRequestByID", reflect.TypeOf((*MockRepositoryInterface)(nil).TeamRequestFindJoinRequestByID), id)
+}
+
+// TeamRequestFindPendingInvitationsByEmail mocks base method.
+func (m *MockRepositoryInterface) TeamRequestFindPendingInvitationsByEmail(email string) (*models.ProjectInvitations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamRequestFindPendingInvitationsByEmail", email)
+	ret0, _ := ret[0].(*models.ProjectInvitations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TeamRequestFindPendingInvitationsByEmail indicates an expected call of TeamRequestFindPendingInvitationsByEmail.
+func (mr *MockRepositoryInterfaceMockRecorder) TeamRequestFindPendingInvitationsByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamRequestFindPendingInvitationsByEmail", reflect.TypeOf((*MockRepositoryInterface)(nil).TeamRequestFindPendingInvitationsByEmail), email)
+}
+
+// TeamRequestFindPendingInvitationsByProjectID mocks base method.
+func (m *MockRepositoryInterface) TeamRequestFindPendingInvitationsByProjectID(projectID any) (*models.ProjectInvitations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamRequestFindPendingInvitationsByProjectID", projectID)
+	ret0, _ := ret[0].(*models.ProjectInvitations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TeamRequestFindPendingInvitationsByProjectID indicates an expected call of TeamRequestFindPendingInvitationsByProjectID.
+func (mr *MockRepositoryInterfaceMockRecorder) TeamRequestFindPendingInvitationsByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamRequestFindPendingInvitationsByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).TeamRequestFindPendingInvitationsByProjectID), projectID)
+}
+
+// TeamRequestFindPendingJoinRequestsByProjectID mocks base method.
+func (m *MockRepositoryInterface) TeamRequestFindPendingJoinRequestsByProjectID(projectID any) (*models.JoinRequests, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamRequestFindPendingJoinRequestsByProjectID", projectID)
+	ret0, _ := ret[0].(*models.JoinRequests)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TeamRequestFindPendingJoinRequestsByProjectID indicates an expected call of TeamRequestFindPendingJoinRequestsByProjectID.
+func (mr *MockRepositoryInterfaceMockRecorder) TeamRequestFindPendingJoinRequestsByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamRequestFindPendingJoinRequestsByProjectID", reflect.TypeOf((*MockRepositoryInterface)(nil).TeamRequestFindPendingJoinRequestsByProjectID), projectID)
+}
+
+// TeamRequestHasPendingJoinRequest mocks base method.
+func (m *MockRepositoryInterface) TeamRequestHasPendingJoinRequest(projectID, userID any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TeamRequestHasPendingJoinRequest", projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TeamRequestHasPendingJoinRequest indicates an expected call of TeamRequestHasPendingJoinRequest.
+func (mr *MockRepositoryInterfaceMockRecorder) TeamRequestHasPendingJoinRequest(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TeamRequestHasPendingJoinRequest", reflect.TypeOf((*MockRepositoryInterface)(nil).TeamRequestHasPendingJoinRequest), projectID, userID)
+}
+
+// UserCount mocks base method.
+func (m *MockRepositoryInterface) UserCount() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserCount")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserCount indicates an expected call of UserCount.
+func (mr *MockRepositoryInterfaceMockRecorder) UserCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCount", reflect.TypeOf((*MockRepositoryInterface)(nil).UserCount))
+}
+
+// UserCreate mocks base method.
+func (m *MockRepositoryInterface) UserCreate(user *models.User) (*validate.Errors, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserCreate", user)
+	ret0, _ := ret[0].(*validate.Errors)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserCreate indicates an expected call of UserCreate.
+func (mr *MockRepositoryInterfaceMockRecorder) UserCreate(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCreate", reflect.TypeOf((*MockRepositoryInterface)(nil).UserCreate), user)
+}
+
+// UserFindByCalendarToken mocks base method.
+func (m *MockRepositoryInterface) UserFindByCalendarToken(token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindByCalendarToken", token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindByCalendarToken indicates an expected call of UserFindByCalendarToken.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindByCalendarToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByCalendarToken", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByCalendarToken), token)
+}
+
+// UserFindByEmail mocks base method.
+func (m *MockRepositoryInterface) UserFindByEmail(email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindByEmail", email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindByEmail indicates an expected call of UserFindByEmail.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByEmail", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByEmail), email)
+}
+
+// UserFindByID mocks base method.
+func (m *MockRepositoryInterface) UserFindByID(id any) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindByID", id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindByID indicates an expected call of UserFindByID.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByID), id)
+}
+
+// UserFindByIDs mocks base method.
+func (m *MockRepositoryInterface) UserFindByIDs(ids []any) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindByIDs", ids)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindByIDs indicates an expected call of UserFindByIDs.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByIDs", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByIDs), ids)
+}
+
+// UserFindByLDAPDN mocks base method.
+func (m *MockRepositoryInterface) UserFindByLDAPDN(dn string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindByLDAPDN", dn)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindByLDAPDN indicates an expected call of UserFindByLDAPDN.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindByLDAPDN(dn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByLDAPDN", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByLDAPDN), dn)
+}
+
+// UserFindByOIDCSubject mocks base method.
+func (m *MockRepositoryInterface) UserFindByOIDCSubject(subject string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindByOIDCSubject", subject)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindByOIDCSubject indicates an expected call of UserFindByOIDCSubject.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindByOIDCSubject(subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByOIDCSubject", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByOIDCSubject), subject)
+}
+
+// UserFindByRole mocks base method.
+func (m *MockRepositoryInterface) UserFindByRole(role string) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindByRole", role)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindByRole indicates an expected call of UserFindByRole.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindByRole(role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByRole", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByRole), role)
+}
+
+// UserFindBySCIMExternalID mocks base method.
+func (m *MockRepositoryInterface) UserFindBySCIMExternalID(externalID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindBySCIMExternalID", externalID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindBySCIMExternalID indicates an expected call of UserFindBySCIMExternalID.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindBySCIMExternalID(externalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindBySCIMExternalID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindBySCIMExternalID), externalID)
+}
+
+// UserFindPage mocks base method.
+func (m *MockRepositoryInterface) UserFindPage(offset, limit int) (*models.Users, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindPage", offset, limit)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UserFindPage indicates an expected call of UserFindPage.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindPage(offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindPage", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindPage), offset, limit)
+}
+
+// UserFindServiceAccounts mocks base method.
+func (m *MockRepositoryInterface) UserFindServiceAccounts() (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserFindServiceAccounts")
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserFindServiceAccounts indicates an expected call of UserFindServiceAccounts.
+func (mr *MockRepositoryInterfaceMockRecorder) UserFindServiceAccounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindServiceAccounts", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindServiceAccounts))
+}
+
+// UserGetRecent mocks base method.
+func (m *MockRepositoryInterface) UserGetRecent(limit int) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserGetRecent", limit)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetRecent indicates an expected call of UserGetRecent.
+func (mr *MockRepositoryInterfaceMockRecorder) UserGetRecent(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetRecent", reflect.TypeOf((*MockRepositoryInterface)(nil).UserGetRecent), limit)
+}
+
+// UserLockByID mocks base method.
+func (m *MockRepositoryInterface) UserLockByID(id any) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserLockByID", id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserLockByID indicates an expected call of UserLockByID.
+func (mr *MockRepositoryInterfaceMockRecorder) UserLockByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLockByID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserLockByID), id)
+}
+
+// UserSessionCountByUserID mocks base method.
+func (m *MockRepositoryInterface) UserSessionCountByUserID(userID any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSessionCountByUserID", userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSessionCountByUserID indicates an expected call of UserSessionCountByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) UserSessionCountByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSessionCountByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserSessionCountByUserID), userID)
+}
+
+// UserSessionDeleteByUserID mocks base method.
+func (m *MockRepositoryInterface) UserSessionDeleteByUserID(userID any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSessionDeleteByUserID", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserSessionDeleteByUserID indicates an expected call of UserSessionDeleteByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) UserSessionDeleteByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSessionDeleteByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserSessionDeleteByUserID), userID)
+}
+
+// UserSessionDeleteExpired mocks base method.
+func (m *MockRepositoryInterface) UserSessionDeleteExpired(idleBefore, createdBefore time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSessionDeleteExpired", idleBefore, createdBefore)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSessionDeleteExpired indicates an expected call of UserSessionDeleteExpired.
+func (mr *MockRepositoryInterfaceMockRecorder) UserSessionDeleteExpired(idleBefore, createdBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSessionDeleteExpired", reflect.TypeOf((*MockRepositoryInterface)(nil).UserSessionDeleteExpired), idleBefore, createdBefore)
+}
+
+// UserSessionFindByID mocks base method.
+func (m *MockRepositoryInterface) UserSessionFindByID(id any) (*models.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSessionFindByID", id)
+	ret0, _ := ret[0].(*models.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSessionFindByID indicates an expected call of UserSessionFindByID.
+func (mr *MockRepositoryInterfaceMockRecorder) UserSessionFindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSessionFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserSessionFindByID), id)
+}
+
+// UserSessionFindByUserID mocks base method.
+func (m *MockRepositoryInterface) UserSessionFindByUserID(userID any) (*models.UserSessions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSessionFindByUserID", userID)
+	ret0, _ := ret[0].(*models.UserSessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserSessionFindByUserID indicates an expected call of UserSessionFindByUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) UserSessionFindByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSessionFindByUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserSessionFindByUserID), userID)
+}
+
+// UserSetForcePasswordReset mocks base method.
+func (m *MockRepositoryInterface) UserSetForcePasswordReset(id any, force bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSetForcePasswordReset", id, force)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserSetForcePasswordReset indicates an expected call of UserSetForcePasswordReset.
+func (mr *MockRepositoryInterfaceMockRecorder) UserSetForcePasswordReset(id, force any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSetForcePasswordReset", reflect.TypeOf((*MockRepositoryInterface)(nil).UserSetForcePasswordReset), id, force)
+}
+
+// UserSetRole mocks base method.
+func (m *MockRepositoryInterface) UserSetRole(id any, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserSetRole", id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserSetRole indicates an expected call of UserSetRole.
+func (mr *MockRepositoryInterfaceMockRecorder) UserSetRole(id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserSetRole", reflect.TypeOf((*MockRepositoryInterface)(nil).UserSetRole), id, role)
+}
+
+// VoteCountByHackathonIDAndUserID mocks base method.
+func (m *MockRepositoryInterface) VoteCountByHackathonIDAndUserID(hackathonID, userID any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteCountByHackathonIDAndUserID", hackathonID, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoteCountByHackathonIDAndUserID indicates an expected call of VoteCountByHackathonIDAndUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) VoteCountByHackathonIDAndUserID(hackathonID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteCountByHackathonIDAndUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).VoteCountByHackathonIDAndUserID), hackathonID, userID)
+}
+
+// VoteFindByHackathonIDAndUserID mocks base method.
+func (m *MockRepositoryInterface) VoteFindByHackathonIDAndUserID(hackathonID, userID any) (*models.Votes, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteFindByHackathonIDAndUserID", hackathonID, userID)
+	ret0, _ := ret[0].(*models.Votes)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoteFindByHackathonIDAndUserID indicates an expected call of VoteFindByHackathonIDAndUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) VoteFindByHackathonIDAndUserID(hackathonID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteFindByHackathonIDAndUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).VoteFindByHackathonIDAndUserID), hackathonID, userID)
+}
+
+// VoteFindByProjectIDAndUserID mocks base method.
+func (m *MockRepositoryInterface) VoteFindByProjectIDAndUserID(projectID, userID any) (*models.Vote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteFindByProjectIDAndUserID", projectID, userID)
+	ret0, _ := ret[0].(*models.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoteFindByProjectIDAndUserID indicates an expected call of VoteFindByProjectIDAndUserID.
+func (mr *MockRepositoryInterfaceMockRecorder) VoteFindByProjectIDAndUserID(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteFindByProjectIDAndUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).VoteFindByProjectIDAndUserID), projectID, userID)
+}
+
+// VoteTallyByHackathonID mocks base method.
+func (m *MockRepositoryInterface) VoteTallyByHackathonID(hackathonID any) (*models.VoteTallies, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoteTallyByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.VoteTallies)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoteTallyByHackathonID indicates an expected call of VoteTallyByHackathonID.
+func (mr *MockRepositoryInterfaceMockRecorder) VoteTallyByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteTallyByHackathonID", reflect.TypeOf((*MockRepositoryInterface)(nil).VoteTallyByHackathonID), hackathonID)
+}
+
+// MockUserRepositoryInterface is a mock of UserRepositoryInterface interface.
+type MockUserRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserRepositoryInterfaceMockRecorder is the mock recorder for MockUserRepositoryInterface.
+type MockUserRepositoryInterfaceMockRecorder struct {
+	mock *MockUserRepositoryInterface
+}
+
+// NewMockUserRepositoryInterface creates a new mock instance.
+func NewMockUserRepositoryInterface(ctrl *gomock.Controller) *MockUserRepositoryInterface {
+	mock := &MockUserRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepositoryInterface) EXPECT() *MockUserRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockUserRepositoryInterface) Count() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockUserRepositoryInterfaceMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Count))
+}
+
+// Create mocks base method.
+func (m *MockUserRepositoryInterface) Create(user *models.User) (*validate.Errors, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", user)
+	ret0, _ := ret[0].(*validate.Errors)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserRepositoryInterfaceMockRecorder) Create(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Create), user)
+}
+
+// FindByCalendarToken mocks base method.
+func (m *MockUserRepositoryInterface) FindByCalendarToken(token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCalendarToken", token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCalendarToken indicates an expected call of FindByCalendarToken.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByCalendarToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCalendarToken", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByCalendarToken), token)
+}
+
+// FindByEmail mocks base method.
+func (m *MockUserRepositoryInterface) FindByEmail(email string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", email)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByEmail), email)
+}
+
+// FindByID mocks base method.
+func (m *MockUserRepositoryInterface) FindByID(id any) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByID), id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockUserRepositoryInterface) FindByIDs(ids []any) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ids)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByIDs), ids)
+}
+
+// FindByLDAPDN mocks base method.
+func (m *MockUserRepositoryInterface) FindByLDAPDN(dn string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByLDAPDN", dn)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByLDAPDN indicates an expected call of FindByLDAPDN.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByLDAPDN(dn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByLDAPDN", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByLDAPDN), dn)
+}
+
+// FindByOIDCSubject mocks base method.
+func (m *MockUserRepositoryInterface) FindByOIDCSubject(subject string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOIDCSubject", subject)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOIDCSubject indicates an expected call of FindByOIDCSubject.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByOIDCSubject(subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOIDCSubject", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByOIDCSubject), subject)
+}
+
+// FindByRole mocks base method.
+func (m *MockUserRepositoryInterface) FindByRole(role string) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByRole", role)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByRole indicates an expected call of FindByRole.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByRole(role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByRole", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByRole), role)
+}
+
+// FindBySCIMExternalID mocks base method.
+func (m *MockUserRepositoryInterface) FindBySCIMExternalID(externalID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySCIMExternalID", externalID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySCIMExternalID indicates an expected call of FindBySCIMExternalID.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindBySCIMExternalID(externalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySCIMExternalID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindBySCIMExternalID), externalID)
+}
+
+// FindPage mocks base method.
+func (m *MockUserRepositoryInterface) FindPage(offset, limit int) (*models.Users, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", offset, limit)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindPage(offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindPage), offset, limit)
+}
+
+// FindServiceAccounts mocks base method.
+func (m *MockUserRepositoryInterface) FindServiceAccounts() (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindServiceAccounts")
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindServiceAccounts indicates an expected call of FindServiceAccounts.
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindServiceAccounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindServiceAccounts", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindServiceAccounts))
+}
+
+// GetRecent mocks base method.
+func (m *MockUserRepositoryInterface) GetRecent(limit int) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecent", limit)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecent indicates an expected call of GetRecent.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetRecent(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetRecent), limit)
+}
+
+// LockByID mocks base method.
+func (m *MockUserRepositoryInterface) LockByID(id any) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockUserRepositoryInterfaceMockRecorder) LockByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).LockByID), id)
+}
+
+// SetForcePasswordReset mocks base method.
+func (m *MockUserRepositoryInterface) SetForcePasswordReset(id any, force bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetForcePasswordReset", id, force)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetForcePasswordReset indicates an expected call of SetForcePasswordReset.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SetForcePasswordReset(id, force any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetForcePasswordReset", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SetForcePasswordReset), id, force)
+}
+
+// SetRole mocks base method.
+func (m *MockUserRepositoryInterface) SetRole(id any, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SetRole(id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SetRole), id, role)
+}
+
+// MockHackathonRepositoryInterface is a mock of HackathonRepositoryInterface interface.
+type MockHackathonRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockHackathonRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockHackathonRepositoryInterfaceMockRecorder is the mock recorder for MockHackathonRepositoryInterface.
+type MockHackathonRepositoryInterfaceMockRecorder struct {
+	mock *MockHackathonRepositoryInterface
+}
+
+// NewMockHackathonRepositoryInterface creates a new mock instance.
+func NewMockHackathonRepositoryInterface(ctrl *gomock.Controller) *MockHackathonRepositoryInterface {
+	mock := &MockHackathonRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockHackathonRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHackathonRepositoryInterface) EXPECT() *MockHackathonRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockHackathonRepositoryInterface) Count() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).Count))
+}
+
+// FindByID mocks base method.
+func (m *MockHackathonRepositoryInterface) FindByID(id any) (*models.Hackathon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.Hackathon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).FindByID), id)
+}
+
+// FindByIDsWithSchedule mocks base method.
+func (m *MockHackathonRepositoryInterface) FindByIDsWithSchedule(ids []any) (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDsWithSchedule", ids)
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDsWithSchedule indicates an expected call of FindByIDsWithSchedule.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) FindByIDsWithSchedule(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDsWithSchedule", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).FindByIDsWithSchedule), ids)
+}
+
+// FindByOwnerID mocks base method.
+func (m *MockHackathonRepositoryInterface) FindByOwnerID(ownerID any) (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOwnerID", ownerID)
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOwnerID indicates an expected call of FindByOwnerID.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) FindByOwnerID(ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOwnerID", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).FindByOwnerID), ownerID)
+}
+
+// FindPage mocks base method.
+func (m *MockHackathonRepositoryInterface) FindPage(viewerID any, includeHidden bool, after *PageCursor, limit int) (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", viewerID, includeHidden, after, limit)
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) FindPage(viewerID, includeHidden, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).FindPage), viewerID, includeHidden, after, limit)
+}
+
+// FindUnfinished mocks base method.
+func (m *MockHackathonRepositoryInterface) FindUnfinished() (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnfinished")
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnfinished indicates an expected call of FindUnfinished.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) FindUnfinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnfinished", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).FindUnfinished))
+}
+
+// GetActiveHackathonIDs mocks base method.
+func (m *MockHackathonRepositoryInterface) GetActiveHackathonIDs() ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveHackathonIDs")
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveHackathonIDs indicates an expected call of GetActiveHackathonIDs.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) GetActiveHackathonIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveHackathonIDs", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).GetActiveHackathonIDs))
+}
+
+// GetActiveWithSchedule mocks base method.
+func (m *MockHackathonRepositoryInterface) GetActiveWithSchedule() (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveWithSchedule")
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveWithSchedule indicates an expected call of GetActiveWithSchedule.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) GetActiveWithSchedule() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveWithSchedule", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).GetActiveWithSchedule))
+}
+
+// GetRecent mocks base method.
+func (m *MockHackathonRepositoryInterface) GetRecent(limit int) (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecent", limit)
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecent indicates an expected call of GetRecent.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) GetRecent(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).GetRecent), limit)
+}
+
+// LockDueForStatusTransition mocks base method.
+func (m *MockHackathonRepositoryInterface) LockDueForStatusTransition(now time.Time) (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDueForStatusTransition", now)
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockDueForStatusTransition indicates an expected call of LockDueForStatusTransition.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) LockDueForStatusTransition(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDueForStatusTransition", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).LockDueForStatusTransition), now)
+}
+
+// SetStatus mocks base method.
+func (m *MockHackathonRepositoryInterface) SetStatus(id any, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockHackathonRepositoryInterfaceMockRecorder) SetStatus(id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).SetStatus), id, status)
+}
+
+// MockProjectRepositoryInterface is a mock of ProjectRepositoryInterface interface.
+type MockProjectRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockProjectRepositoryInterfaceMockRecorder is the mock recorder for MockProjectRepositoryInterface.
+type MockProjectRepositoryInterfaceMockRecorder struct {
+	mock *MockProjectRepositoryInterface
+}
+
+// NewMockProjectRepositoryInterface creates a new mock instance.
+func NewMockProjectRepositoryInterface(ctrl *gomock.Controller) *MockProjectRepositoryInterface {
+	mock := &MockProjectRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProjectRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectRepositoryInterface) EXPECT() *MockProjectRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockProjectRepositoryInterface) Count() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) Count() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).Count))
+}
+
+// CountActive mocks base method.
+func (m *MockProjectRepositoryInterface) CountActive() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActive")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActive indicates an expected call of CountActive.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) CountActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActive", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).CountActive))
+}
+
+// CountMembershipsByProjectID mocks base method.
+func (m *MockProjectRepositoryInterface) CountMembershipsByProjectID(projectID any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMembershipsByProjectID", projectID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMembershipsByProjectID indicates an expected call of CountMembershipsByProjectID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) CountMembershipsByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMembershipsByProjectID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).CountMembershipsByProjectID), projectID)
+}
+
+// CountPendingReview mocks base method.
+func (m *MockProjectRepositoryInterface) CountPendingReview() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingReview")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingReview indicates an expected call of CountPendingReview.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) CountPendingReview() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingReview", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).CountPendingReview))
+}
+
+// CountPresenting mocks base method.
+func (m *MockProjectRepositoryInterface) CountPresenting() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPresenting")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPresenting indicates an expected call of CountPresenting.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) CountPresenting() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPresenting", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).CountPresenting))
+}
+
+// FindApprovedByHackathonID mocks base method.
+func (m *MockProjectRepositoryInterface) FindApprovedByHackathonID(hackathonID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApprovedByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApprovedByHackathonID indicates an expected call of FindApprovedByHackathonID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindApprovedByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApprovedByHackathonID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindApprovedByHackathonID), hackathonID)
+}
+
+// FindByHackathonID mocks base method.
+func (m *MockProjectRepositoryInterface) FindByHackathonID(hackathonID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHackathonID indicates an expected call of FindByHackathonID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHackathonID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByHackathonID), hackathonID)
+}
+
+// FindByID mocks base method.
+func (m *MockProjectRepositoryInterface) FindByID(id any) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByID), id)
+}
+
+// FindByUserID mocks base method.
+func (m *MockProjectRepositoryInterface) FindByUserID(userID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", userID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByUserID), userID)
+}
+
+// FindByUserIDWithHackathon mocks base method.
+func (m *MockProjectRepositoryInterface) FindByUserIDWithHackathon(userID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserIDWithHackathon", userID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserIDWithHackathon indicates an expected call of FindByUserIDWithHackathon.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByUserIDWithHackathon(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDWithHackathon", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByUserIDWithHackathon), userID)
+}
+
+// FindPendingReview mocks base method.
+func (m *MockProjectRepositoryInterface) FindPendingReview() (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingReview")
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingReview indicates an expected call of FindPendingReview.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindPendingReview() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingReview", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindPendingReview))
+}
+
+// FindPresentingByHackathonID mocks base method.
+func (m *MockProjectRepositoryInterface) FindPresentingByHackathonID(hackathonID any) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPresentingByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPresentingByHackathonID indicates an expected call of FindPresentingByHackathonID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindPresentingByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPresentingByHackathonID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindPresentingByHackathonID), hackathonID)
+}
+
+// FindPresentingFromActiveHackathons mocks base method.
+func (m *MockProjectRepositoryInterface) FindPresentingFromActiveHackathons() (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPresentingFromActiveHackathons")
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPresentingFromActiveHackathons indicates an expected call of FindPresentingFromActiveHackathons.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindPresentingFromActiveHackathons() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPresentingFromActiveHackathons", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindPresentingFromActiveHackathons))
+}
+
+// FindVisiblePage mocks base method.
+func (m *MockProjectRepositoryInterface) FindVisiblePage(hackathonID string, viewerID any, includeAll bool, after *PageCursor, limit int) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVisiblePage", hackathonID, viewerID, includeAll, after, limit)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVisiblePage indicates an expected call of FindVisiblePage.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) FindVisiblePage(hackathonID, viewerID, includeAll, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVisiblePage", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindVisiblePage), hackathonID, viewerID, includeAll, after, limit)
+}
+
+// GetFilesByProjectID mocks base method.
+func (m *MockProjectRepositoryInterface) GetFilesByProjectID(projectID any) (*models.Files, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilesByProjectID", projectID)
+	ret0, _ := ret[0].(*models.Files)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilesByProjectID indicates an expected call of GetFilesByProjectID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) GetFilesByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilesByProjectID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetFilesByProjectID), projectID)
+}
+
+// GetMembershipsByProjectID mocks base method.
+func (m *MockProjectRepositoryInterface) GetMembershipsByProjectID(projectID any) (*models.ProjectMemberships, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipsByProjectID", projectID)
+	ret0, _ := ret[0].(*models.ProjectMemberships)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipsByProjectID indicates an expected call of GetMembershipsByProjectID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) GetMembershipsByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipsByProjectID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetMembershipsByProjectID), projectID)
+}
+
+// GetRecent mocks base method.
+func (m *MockProjectRepositoryInterface) GetRecent(limit int) (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecent", limit)
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecent indicates an expected call of GetRecent.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) GetRecent(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetRecent), limit)
+}
+
+// IsUserMemberOfProject mocks base method.
+func (m *MockProjectRepositoryInterface) IsUserMemberOfProject(projectID, userID any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserMemberOfProject", projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserMemberOfProject indicates an expected call of IsUserMemberOfProject.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) IsUserMemberOfProject(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserMemberOfProject", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).IsUserMemberOfProject), projectID, userID)
+}
+
+// LockByID mocks base method.
+func (m *MockProjectRepositoryInterface) LockByID(id any) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) LockByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).LockByID), id)
+}
+
+// MockProjectMembershipRepositoryInterface is a mock of ProjectMembershipRepositoryInterface interface.
+type MockProjectMembershipRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectMembershipRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockProjectMembershipRepositoryInterfaceMockRecorder is the mock recorder for MockProjectMembershipRepositoryInterface.
+type MockProjectMembershipRepositoryInterfaceMockRecorder struct {
+	mock *MockProjectMembershipRepositoryInterface
+}
+
+// NewMockProjectMembershipRepositoryInterface creates a new mock instance.
+func NewMockProjectMembershipRepositoryInterface(ctrl *gomock.Controller) *MockProjectMembershipRepositoryInterface {
+	mock := &MockProjectMembershipRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockProjectMembershipRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectMembershipRepositoryInterface) EXPECT() *MockProjectMembershipRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountByProjectID mocks base method.
+func (m *MockProjectMembershipRepositoryInterface) CountByProjectID(projectID any) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByProjectID", projectID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByProjectID indicates an expected call of CountByProjectID.
+func (mr *MockProjectMembershipRepositoryInterfaceMockRecorder) CountByProjectID(projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByProjectID", reflect.TypeOf((*MockProjectMembershipRepositoryInterface)(nil).CountByProjectID), projectID)
+}
+
+// FindByProjectIDAndUserID mocks base method.
+func (m *MockProjectMembershipRepositoryInterface) FindByProjectIDAndUserID(projectID, userID any) (*models.ProjectMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProjectIDAndUserID", projectID, userID)
+	ret0, _ := ret[0].(*models.ProjectMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProjectIDAndUserID indicates an expected call of FindByProjectIDAndUserID.
+func (mr *MockProjectMembershipRepositoryInterfaceMockRecorder) FindByProjectIDAndUserID(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProjectIDAndUserID", reflect.TypeOf((*MockProjectMembershipRepositoryInterface)(nil).FindByProjectIDAndUserID), projectID, userID)
+}
+
+// FindPageByProjectID mocks base method.
+func (m *MockProjectMembershipRepositoryInterface) FindPageByProjectID(projectID any, after *PageCursor, limit int) (*models.ProjectMemberships, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByProjectID", projectID, after, limit)
+	ret0, _ := ret[0].(*models.ProjectMemberships)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPageByProjectID indicates an expected call of FindPageByProjectID.
+func (mr *MockProjectMembershipRepositoryInterfaceMockRecorder) FindPageByProjectID(projectID, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByProjectID", reflect.TypeOf((*MockProjectMembershipRepositoryInterface)(nil).FindPageByProjectID), projectID, after, limit)
+}
+
+// IsUserMember mocks base method.
+func (m *MockProjectMembershipRepositoryInterface) IsUserMember(projectID, userID any) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserMember", projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserMember indicates an expected call of IsUserMember.
+func (mr *MockProjectMembershipRepositoryInterfaceMockRecorder) IsUserMember(projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserMember", reflect.TypeOf((*MockProjectMembershipRepositoryInterface)(nil).IsUserMember), projectID, userID)
+}
+
+// MockFileRepositoryInterface is a mock of FileRepositoryInterface interface.
+type MockFileRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockFileRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockFileRepositoryInterfaceMockRecorder is the mock recorder for MockFileRepositoryInterface.
+type MockFileRepositoryInterfaceMockRecorder struct {
+	mock *MockFileRepositoryInterface
+}
+
+// NewMockFileRepositoryInterface creates a new mock instance.
+func NewMockFileRepositoryInterface(ctrl *gomock.Controller) *MockFileRepositoryInterface {
+	mock := &MockFileRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockFileRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFileRepositoryInterface) EXPECT() *MockFileRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockFileRepositoryInterface) FindAll() (*models.Files, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].(*models.Files)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockFileRepositoryInterfaceMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindAll))
+}
+
+// FindAllHackathons mocks base method.
+func (m *MockFileRepositoryInterface) FindAllHackathons() (*models.Hackathons, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllHackathons")
+	ret0, _ := ret[0].(*models.Hackathons)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllHackathons indicates an expected call of FindAllHackathons.
+func (mr *MockFileRepositoryInterfaceMockRecorder) FindAllHackathons() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllHackathons", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindAllHackathons))
+}
+
+// FindAllProjects mocks base method.
+func (m *MockFileRepositoryInterface) FindAllProjects() (*models.Projects, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllProjects")
+	ret0, _ := ret[0].(*models.Projects)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllProjects indicates an expected call of FindAllProjects.
+func (mr *MockFileRepositoryInterfaceMockRecorder) FindAllProjects() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllProjects", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindAllProjects))
+}
+
+// FindByID mocks base method.
+func (m *MockFileRepositoryInterface) FindByID(id any) (*models.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockFileRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindByID), id)
+}
+
+// FindVisiblePage mocks base method.
+func (m *MockFileRepositoryInterface) FindVisiblePage(hackathonID, projectID string, viewerID any, includeAll bool, after *PageCursor, limit int) (*models.Files, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVisiblePage", hackathonID, projectID, viewerID, includeAll, after, limit)
+	ret0, _ := ret[0].(*models.Files)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVisiblePage indicates an expected call of FindVisiblePage.
+func (mr *MockFileRepositoryInterfaceMockRecorder) FindVisiblePage(hackathonID, projectID, viewerID, includeAll, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVisiblePage", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindVisiblePage), hackathonID, projectID, viewerID, includeAll, after, limit)
+}
+
+// MockCompanyAllowedDomainRepositoryInterface is a mock of CompanyAllowedDomainRepositoryInterface interface.
+type MockCompanyAllowedDomainRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCompanyAllowedDomainRepositoryInterfaceMockRecorder is the mock recorder for MockCompanyAllowedDomainRepositoryInterface.
+type MockCompanyAllowedDomainRepositoryInterfaceMockRecorder struct {
+	mock *MockCompanyAllowedDomainRepositoryInterface
+}
+
+// NewMockCompanyAllowedDomainRepositoryInterface creates a new mock instance.
+func NewMockCompanyAllowedDomainRepositoryInterface(ctrl *gomock.Controller) *MockCompanyAllowedDomainRepositoryInterface {
+	mock := &MockCompanyAllowedDomainRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCompanyAllowedDomainRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompanyAllowedDomainRepositoryInterface) EXPECT() *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CheckDomain mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) CheckDomain(domain string, openRegistration bool) (*models.DomainCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDomain", domain, openRegistration)
+	ret0, _ := ret[0].(*models.DomainCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckDomain indicates an expected call of CheckDomain.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) CheckDomain(domain, openRegistration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDomain", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).CheckDomain), domain, openRegistration)
+}
+
+// Create mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) Create(domain *models.CompanyAllowedDomain) (*validate.Errors, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", domain)
+	ret0, _ := ret[0].(*validate.Errors)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) Create(domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).Create), domain)
+}
+
+// Delete mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) Delete(id any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).Delete), id)
+}
+
+// FindAll mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) FindAll() (*models.CompanyAllowedDomains, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].(*models.CompanyAllowedDomains)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).FindAll))
+}
+
+// FindAllActive mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) FindAllActive() (*models.CompanyAllowedDomains, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllActive")
+	ret0, _ := ret[0].(*models.CompanyAllowedDomains)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllActive indicates an expected call of FindAllActive.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) FindAllActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllActive", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).FindAllActive))
+}
+
+// FindByDomain mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) FindByDomain(domain string) (*models.CompanyAllowedDomain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByDomain", domain)
+	ret0, _ := ret[0].(*models.CompanyAllowedDomain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByDomain indicates an expected call of FindByDomain.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) FindByDomain(domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByDomain", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).FindByDomain), domain)
+}
+
+// IsDomainAllowed mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) IsDomainAllowed(domain string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDomainAllowed", domain)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDomainAllowed indicates an expected call of IsDomainAllowed.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) IsDomainAllowed(domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDomainAllowed", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).IsDomainAllowed), domain)
+}
+
+// SetActive mocks base method.
+func (m *MockCompanyAllowedDomainRepositoryInterface) SetActive(id any, active bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActive", id, active)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetActive indicates an expected call of SetActive.
+func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) SetActive(id, active any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActive", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).SetActive), id, active)
+}
+
+// MockJudgingRepositoryInterface is a mock of JudgingRepositoryInterface interface.
+type MockJudgingRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockJudgingRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockJudgingRepositoryInterfaceMockRecorder is the mock recorder for MockJudgingRepositoryInterface.
+type MockJudgingRepositoryInterfaceMockRecorder struct {
+	mock *MockJudgingRepositoryInterface
+}
+
+// NewMockJudgingRepositoryInterface creates a new mock instance.
+func NewMockJudgingRepositoryInterface(ctrl *gomock.Controller) *MockJudgingRepositoryInterface {
+	mock := &MockJudgingRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockJudgingRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJudgingRepositoryInterface) EXPECT() *MockJudgingRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindAssignmentsByHackathonID mocks base method.
+func (m *MockJudgingRepositoryInterface) FindAssignmentsByHackathonID(hackathonID any) (*models.JudgeAssignments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAssignmentsByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.JudgeAssignments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAssignmentsByHackathonID indicates an expected call of FindAssignmentsByHackathonID.
+func (mr *MockJudgingRepositoryInterfaceMockRecorder) FindAssignmentsByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAssignmentsByHackathonID", reflect.TypeOf((*MockJudgingRepositoryInterface)(nil).FindAssignmentsByHackathonID), hackathonID)
+}
+
+// FindAssignmentsByHackathonIDAndJudgeID mocks base method.
+func (m *MockJudgingRepositoryInterface) FindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID any) (*models.JudgeAssignments, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAssignmentsByHackathonIDAndJudgeID", hackathonID, judgeID)
+	ret0, _ := ret[0].(*models.JudgeAssignments)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAssignmentsByHackathonIDAndJudgeID indicates an expected call of FindAssignmentsByHackathonIDAndJudgeID.
+func (mr *MockJudgingRepositoryInterfaceMockRecorder) FindAssignmentsByHackathonIDAndJudgeID(hackathonID, judgeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAssignmentsByHackathonIDAndJudgeID", reflect.TypeOf((*MockJudgingRepositoryInterface)(nil).FindAssignmentsByHackathonIDAndJudgeID), hackathonID, judgeID)
+}
+
+// FindCriteriaByHackathonID mocks base method.
+func (m *MockJudgingRepositoryInterface) FindCriteriaByHackathonID(hackathonID any) (*models.JudgingCriteria, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCriteriaByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.JudgingCriteria)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCriteriaByHackathonID indicates an expected call of FindCriteriaByHackathonID.
+func (mr *MockJudgingRepositoryInterfaceMockRecorder) FindCriteriaByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCriteriaByHackathonID", reflect.TypeOf((*MockJudgingRepositoryInterface)(nil).FindCriteriaByHackathonID), hackathonID)
+}
+
+// FindScoresByHackathonID mocks base method.
+func (m *MockJudgingRepositoryInterface) FindScoresByHackathonID(hackathonID any) (*models.JudgeScores, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindScoresByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.JudgeScores)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindScoresByHackathonID indicates an expected call of FindScoresByHackathonID.
+func (mr *MockJudgingRepositoryInterfaceMockRecorder) FindScoresByHackathonID(hackathonID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindScoresByHackathonID", reflect.TypeOf((*MockJudgingRepositoryInterface)(nil).FindScoresByHackathonID), hackathonID)
+}
+
+// FindScoresByProjectIDAndJudgeID mocks base method.
+func (m *MockJudgingRepositoryInterface) FindScoresByProjectIDAndJudgeID(projectID, judgeID any) (*models.JudgeScores, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindScoresByProjectIDAndJudgeID", projectID, judgeID)
+	ret0, _ := ret[0].(*models.JudgeScores)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectMembershipFindByProjectIDAndUserID indicates an expected call of ProjectMembershipFindByProjectIDAndUserID.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectMembershipFindByProjectIDAndUserID(projectID, userID any) *gomock.Call {
+// FindScoresByProjectIDAndJudgeID indicates an expected call of FindScoresByProjectIDAndJudgeID.
+func (mr *MockJudgingRepositoryInterfaceMockRecorder) FindScoresByProjectIDAndJudgeID(projectID, judgeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembershipFindByProjectIDAndUserID", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectMembershipFindByProjectIDAndUserID), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindScoresByProjectIDAndJudgeID", reflect.TypeOf((*MockJudgingRepositoryInterface)(nil).FindScoresByProjectIDAndJudgeID), projectID, judgeID)
 }
 
-// ProjectMembershipIsUserMember mocks base method.
-func (m *MockRepositoryInterface) ProjectMembershipIsUserMember(projectID, userID any) (bool, error) {
+// IsJudgeAssigned mocks base method.
+func (m *MockJudgingRepositoryInterface) IsJudgeAssigned(projectID, judgeID any) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProjectMembershipIsUserMember", projectID, userID)
+	ret := m.ctrl.Call(m, "IsJudgeAssigned", projectID, judgeID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ProjectMembershipIsUserMember indicates an expected call of ProjectMembershipIsUserMember.
-func (mr *MockRepositoryInterfaceMockRecorder) ProjectMembershipIsUserMember(projectID, userID any) *gomock.Call {
+// IsJudgeAssigned indicates an expected call of IsJudgeAssigned.
+func (mr *MockJudgingRepositoryInterfaceMockRecorder) IsJudgeAssigned(projectID, judgeID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectMembershipIsUserMember", reflect.TypeOf((*MockRepositoryInterface)(nil).ProjectMembershipIsUserMember), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsJudgeAssigned", reflect.TypeOf((*MockJudgingRepositoryInterface)(nil).IsJudgeAssigned), projectID, judgeID)
 }
 
-// UserCount mocks base method.
-func (m *MockRepositoryInterface) UserCount() (int, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserCount")
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockVoteRepositoryInterface is a mock of VoteRepositoryInterface interface.
+type MockVoteRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoteRepositoryInterfaceMockRecorder
+	isgomock struct{}
 }
 
-// UserCount indicates an expected call of UserCount.
-func (mr *MockRepositoryInterfaceMockRecorder) UserCount() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCount", reflect.TypeOf((*MockRepositoryInterface)(nil).UserCount))
+// MockVoteRepositoryInterfaceMockRecorder is the mock recorder for MockVoteRepositoryInterface.
+type MockVoteRepositoryInterfaceMockRecorder struct {
+	mock *MockVoteRepositoryInterface
 }
 
-// UserFindByEmail mocks base method.
-func (m *MockRepositoryInterface) UserFindByEmail(email string) (*models.User, error) {
+// NewMockVoteRepositoryInterface creates a new mock instance.
+func NewMockVoteRepositoryInterface(ctrl *gomock.Controller) *MockVoteRepositoryInterface {
+	mock := &MockVoteRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockVoteRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoteRepositoryInterface) EXPECT() *MockVoteRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountByHackathonIDAndUserID mocks base method.
+func (m *MockVoteRepositoryInterface) CountByHackathonIDAndUserID(hackathonID, userID any) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserFindByEmail", email)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "CountByHackathonIDAndUserID", hackathonID, userID)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserFindByEmail indicates an expected call of UserFindByEmail.
-func (mr *MockRepositoryInterfaceMockRecorder) UserFindByEmail(email any) *gomock.Call {
+// CountByHackathonIDAndUserID indicates an expected call of CountByHackathonIDAndUserID.
+func (mr *MockVoteRepositoryInterfaceMockRecorder) CountByHackathonIDAndUserID(hackathonID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByEmail", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByHackathonIDAndUserID", reflect.TypeOf((*MockVoteRepositoryInterface)(nil).CountByHackathonIDAndUserID), hackathonID, userID)
 }
 
-// UserFindByID mocks base method.
-func (m *MockRepositoryInterface) UserFindByID(id any) (*models.User, error) {
+// FindByHackathonIDAndUserID mocks base method.
+func (m *MockVoteRepositoryInterface) FindByHackathonIDAndUserID(hackathonID, userID any) (*models.Votes, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserFindByID", id)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "FindByHackathonIDAndUserID", hackathonID, userID)
+	ret0, _ := ret[0].(*models.Votes)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserFindByID indicates an expected call of UserFindByID.
-func (mr *MockRepositoryInterfaceMockRecorder) UserFindByID(id any) *gomock.Call {
+// FindByHackathonIDAndUserID indicates an expected call of FindByHackathonIDAndUserID.
+func (mr *MockVoteRepositoryInterfaceMockRecorder) FindByHackathonIDAndUserID(hackathonID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByID", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHackathonIDAndUserID", reflect.TypeOf((*MockVoteRepositoryInterface)(nil).FindByHackathonIDAndUserID), hackathonID, userID)
 }
 
-// UserFindByIDs mocks base method.
-func (m *MockRepositoryInterface) UserFindByIDs(ids []any) (*models.Users, error) {
+// FindByProjectIDAndUserID mocks base method.
+func (m *MockVoteRepositoryInterface) FindByProjectIDAndUserID(projectID, userID any) (*models.Vote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserFindByIDs", ids)
-	ret0, _ := ret[0].(*models.Users)
+	ret := m.ctrl.Call(m, "FindByProjectIDAndUserID", projectID, userID)
+	ret0, _ := ret[0].(*models.Vote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserFindByIDs indicates an expected call of UserFindByIDs.
-func (mr *MockRepositoryInterfaceMockRecorder) UserFindByIDs(ids any) *gomock.Call {
+// FindByProjectIDAndUserID indicates an expected call of FindByProjectIDAndUserID.
+func (mr *MockVoteRepositoryInterfaceMockRecorder) FindByProjectIDAndUserID(projectID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserFindByIDs", reflect.TypeOf((*MockRepositoryInterface)(nil).UserFindByIDs), ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProjectIDAndUserID", reflect.TypeOf((*MockVoteRepositoryInterface)(nil).FindByProjectIDAndUserID), projectID, userID)
 }
 
-// UserGetRecent mocks base method.
-func (m *MockRepositoryInterface) UserGetRecent(limit int) (*models.Users, error) {
+// TallyByHackathonID mocks base method.
+func (m *MockVoteRepositoryInterface) TallyByHackathonID(hackathonID any) (*models.VoteTallies, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserGetRecent", limit)
-	ret0, _ := ret[0].(*models.Users)
+	ret := m.ctrl.Call(m, "TallyByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.VoteTallies)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UserGetRecent indicates an expected call of UserGetRecent.
-func (mr *MockRepositoryInterfaceMockRecorder) UserGetRecent(limit any) *gomock.Call {
+// TallyByHackathonID indicates an expected call of TallyByHackathonID.
+func (mr *MockVoteRepositoryInterfaceMockRecorder) TallyByHackathonID(hackathonID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetRecent", reflect.TypeOf((*MockRepositoryInterface)(nil).UserGetRecent), limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TallyByHackathonID", reflect.TypeOf((*MockVoteRepositoryInterface)(nil).TallyByHackathonID), hackathonID)
 }
 
-// MockUserRepositoryInterface is a mock of UserRepositoryInterface interface.
-type MockUserRepositoryInterface struct {
+// MockAwardRepositoryInterface is a mock of AwardRepositoryInterface interface.
+type MockAwardRepositoryInterface struct {
 	ctrl     *gomock.Controller
-	recorder *MockUserRepositoryInterfaceMockRecorder
+	recorder *MockAwardRepositoryInterfaceMockRecorder
 	isgomock struct{}
 }
 
-// MockUserRepositoryInterfaceMockRecorder is the mock recorder for MockUserRepositoryInterface.
-type MockUserRepositoryInterfaceMockRecorder struct {
-	mock *MockUserRepositoryInterface
+// MockAwardRepositoryInterfaceMockRecorder is the mock recorder for MockAwardRepositoryInterface.
+type MockAwardRepositoryInterfaceMockRecorder struct {
+	mock *MockAwardRepositoryInterface
 }
 
-// NewMockUserRepositoryInterface creates a new mock instance.
-func NewMockUserRepositoryInterface(ctrl *gomock.Controller) *MockUserRepositoryInterface {
-	mock := &MockUserRepositoryInterface{ctrl: ctrl}
-	mock.recorder = &MockUserRepositoryInterfaceMockRecorder{mock}
+// NewMockAwardRepositoryInterface creates a new mock instance.
+func NewMockAwardRepositoryInterface(ctrl *gomock.Controller) *MockAwardRepositoryInterface {
+	mock := &MockAwardRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAwardRepositoryInterfaceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUserRepositoryInterface) EXPECT() *MockUserRepositoryInterfaceMockRecorder {
+func (m *MockAwardRepositoryInterface) EXPECT() *MockAwardRepositoryInterfaceMockRecorder {
 	return m.recorder
 }
 
-// Count mocks base method.
-func (m *MockUserRepositoryInterface) Count() (int, error) {
+// FindByHackathonID mocks base method.
+func (m *MockAwardRepositoryInterface) FindByHackathonID(hackathonID any) (*models.Awards, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "FindByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.Awards)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Count indicates an expected call of Count.
-func (mr *MockUserRepositoryInterfaceMockRecorder) Count() *gomock.Call {
+// FindByHackathonID indicates an expected call of FindByHackathonID.
+func (mr *MockAwardRepositoryInterfaceMockRecorder) FindByHackathonID(hackathonID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Count))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHackathonID", reflect.TypeOf((*MockAwardRepositoryInterface)(nil).FindByHackathonID), hackathonID)
 }
 
-// FindByEmail mocks base method.
-func (m *MockUserRepositoryInterface) FindByEmail(email string) (*models.User, error) {
+// FindByID mocks base method.
+func (m *MockAwardRepositoryInterface) FindByID(id any) (*models.Award, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByEmail", email)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.Award)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByEmail indicates an expected call of FindByEmail.
-func (mr *MockUserRepositoryInterfaceMockRecorder) FindByEmail(email any) *gomock.Call {
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAwardRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAwardRepositoryInterface)(nil).FindByID), id)
 }
 
-// FindByID mocks base method.
-func (m *MockUserRepositoryInterface) FindByID(id any) (*models.User, error) {
+// FindWinnerByID mocks base method.
+func (m *MockAwardRepositoryInterface) FindWinnerByID(id any) (*models.AwardWinner, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", id)
-	ret0, _ := ret[0].(*models.User)
+	ret := m.ctrl.Call(m, "FindWinnerByID", id)
+	ret0, _ := ret[0].(*models.AwardWinner)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByID indicates an expected call of FindByID.
-func (mr *MockUserRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+// FindWinnerByID indicates an expected call of FindWinnerByID.
+func (mr *MockAwardRepositoryInterfaceMockRecorder) FindWinnerByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWinnerByID", reflect.TypeOf((*MockAwardRepositoryInterface)(nil).FindWinnerByID), id)
 }
 
-// FindByIDs mocks base method.
-func (m *MockUserRepositoryInterface) FindByIDs(ids []any) (*models.Users, error) {
+// MockScheduleItemRepositoryInterface is a mock of ScheduleItemRepositoryInterface interface.
+type MockScheduleItemRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleItemRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduleItemRepositoryInterfaceMockRecorder is the mock recorder for MockScheduleItemRepositoryInterface.
+type MockScheduleItemRepositoryInterfaceMockRecorder struct {
+	mock *MockScheduleItemRepositoryInterface
+}
+
+// NewMockScheduleItemRepositoryInterface creates a new mock instance.
+func NewMockScheduleItemRepositoryInterface(ctrl *gomock.Controller) *MockScheduleItemRepositoryInterface {
+	mock := &MockScheduleItemRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockScheduleItemRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleItemRepositoryInterface) EXPECT() *MockScheduleItemRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindByHackathonID mocks base method.
+func (m *MockScheduleItemRepositoryInterface) FindByHackathonID(hackathonID any) (*models.ScheduleItems, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByIDs", ids)
-	ret0, _ := ret[0].(*models.Users)
+	ret := m.ctrl.Call(m, "FindByHackathonID", hackathonID)
+	ret0, _ := ret[0].(*models.ScheduleItems)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByIDs indicates an expected call of FindByIDs.
-func (mr *MockUserRepositoryInterfaceMockRecorder) FindByIDs(ids any) *gomock.Call {
+// FindByHackathonID indicates an expected call of FindByHackathonID.
+func (mr *MockScheduleItemRepositoryInterfaceMockRecorder) FindByHackathonID(hackathonID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByIDs), ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHackathonID", reflect.TypeOf((*MockScheduleItemRepositoryInterface)(nil).FindByHackathonID), hackathonID)
 }
 
-// GetRecent mocks base method.
-func (m *MockUserRepositoryInterface) GetRecent(limit int) (*models.Users, error) {
+// FindByID mocks base method.
+func (m *MockScheduleItemRepositoryInterface) FindByID(id any) (*models.ScheduleItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecent", limit)
-	ret0, _ := ret[0].(*models.Users)
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.ScheduleItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecent indicates an expected call of GetRecent.
-func (mr *MockUserRepositoryInterfaceMockRecorder) GetRecent(limit any) *gomock.Call {
+// FindByID indicates an expected call of FindByID.
+func (mr *MockScheduleItemRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetRecent), limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockScheduleItemRepositoryInterface)(nil).FindByID), id)
 }
 
-// MockHackathonRepositoryInterface is a mock of HackathonRepositoryInterface interface.
-type MockHackathonRepositoryInterface struct {
+// MockTeamRequestRepositoryInterface is a mock of TeamRequestRepositoryInterface interface.
+type MockTeamRequestRepositoryInterface struct {
 	ctrl     *gomock.Controller
-	recorder *MockHackathonRepositoryInterfaceMockRecorder
+	recorder *MockTeamRequestRepositoryInterfaceMockRecorder
 	isgomock struct{}
 }
 
-// MockHackathonRepositoryInterfaceMockRecorder is the mock recorder for MockHackathonRepositoryInterface.
-type MockHackathonRepositoryInterfaceMockRecorder struct {
-	mock *MockHackathonRepositoryInterface
+// MockTeamRequestRepositoryInterfaceMockRecorder is the mock recorder for MockTeamRequestRepositoryInterface.
+type MockTeamRequestRepositoryInterfaceMockRecorder struct {
+	mock *MockTeamRequestRepositoryInterface
 }
 
-// NewMockHackathonRepositoryInterface creates a new mock instance.
-func NewMockHackathonRepositoryInterface(ctrl *gomock.Controller) *MockHackathonRepositoryInterface {
-	mock := &MockHackathonRepositoryInterface{ctrl: ctrl}
-	mock.recorder = &MockHackathonRepositoryInterfaceMockRecorder{mock}
+// NewMockTeamRequestRepositoryInterface creates a new mock instance.
+func NewMockTeamRequestRepositoryInterface(ctrl *gomock.Controller) *MockTeamRequestRepositoryInterface {
+	mock := &MockTeamRequestRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTeamRequestRepositoryInterfaceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockHackathonRepositoryInterface) EXPECT() *MockHackathonRepositoryInterfaceMockRecorder {
+func (m *MockTeamRequestRepositoryInterface) EXPECT() *MockTeamRequestRepositoryInterfaceMockRecorder {
 	return m.recorder
 }
 
-// Count mocks base method.
-func (m *MockHackathonRepositoryInterface) Count() (int, error) {
+// FindInvitationByID mocks base method.
+func (m *MockTeamRequestRepositoryInterface) FindInvitationByID(id any) (*models.ProjectInvitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count")
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "FindInvitationByID", id)
+	ret0, _ := ret[0].(*models.ProjectInvitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Count indicates an expected call of Count.
-func (mr *MockHackathonRepositoryInterfaceMockRecorder) Count() *gomock.Call {
+// FindInvitationByID indicates an expected call of FindInvitationByID.
+func (mr *MockTeamRequestRepositoryInterfaceMockRecorder) FindInvitationByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).Count))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitationByID", reflect.TypeOf((*MockTeamRequestRepositoryInterface)(nil).FindInvitationByID), id)
 }
 
-// FindByID mocks base method.
-func (m *MockHackathonRepositoryInterface) FindByID(id any) (*models.Hackathon, error) {
+// FindJoinRequestByID mocks base method.
+func (m *MockTeamRequestRepositoryInterface) FindJoinRequestByID(id any) (*models.JoinRequest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", id)
-	ret0, _ := ret[0].(*models.Hackathon)
+	ret := m.ctrl.Call(m, "FindJoinRequestByID", id)
+	ret0, _ := ret[0].(*models.JoinRequest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByID indicates an expected call of FindByID.
-func (mr *MockHackathonRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+// FindJoinRequestByID indicates an expected call of FindJoinRequestByID.
+func (mr *MockTeamRequestRepositoryInterfaceMockRecorder) FindJoinRequestByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindJoinRequestByID", reflect.TypeOf((*MockTeamRequestRepositoryInterface)(nil).FindJoinRequestByID), id)
 }
 
-// FindByOwnerID mocks base method.
-func (m *MockHackathonRepositoryInterface) FindByOwnerID(ownerID any) (*models.Hackathons, error) {
+// FindPendingInvitationsByEmail mocks base method.
+func (m *MockTeamRequestRepositoryInterface) FindPendingInvitationsByEmail(email string) (*models.ProjectInvitations, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByOwnerID", ownerID)
-	ret0, _ := ret[0].(*models.Hackathons)
+	ret := m.ctrl.Call(m, "FindPendingInvitationsByEmail", email)
+	ret0, _ := ret[0].(*models.ProjectInvitations)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByOwnerID indicates an expected call of FindByOwnerID.
-func (mr *MockHackathonRepositoryInterfaceMockRecorder) FindByOwnerID(ownerID any) *gomock.Call {
+// FindPendingInvitationsByEmail indicates an expected call of FindPendingInvitationsByEmail.
+func (mr *MockTeamRequestRepositoryInterfaceMockRecorder) FindPendingInvitationsByEmail(email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOwnerID", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).FindByOwnerID), ownerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingInvitationsByEmail", reflect.TypeOf((*MockTeamRequestRepositoryInterface)(nil).FindPendingInvitationsByEmail), email)
 }
 
-// GetActiveHackathonIDs mocks base method.
-func (m *MockHackathonRepositoryInterface) GetActiveHackathonIDs() ([]int, error) {
+// FindPendingInvitationsByProjectID mocks base method.
+func (m *MockTeamRequestRepositoryInterface) FindPendingInvitationsByProjectID(projectID any) (*models.ProjectInvitations, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveHackathonIDs")
-	ret0, _ := ret[0].([]int)
+	ret := m.ctrl.Call(m, "FindPendingInvitationsByProjectID", projectID)
+	ret0, _ := ret[0].(*models.ProjectInvitations)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActiveHackathonIDs indicates an expected call of GetActiveHackathonIDs.
-func (mr *MockHackathonRepositoryInterfaceMockRecorder) GetActiveHackathonIDs() *gomock.Call {
+// FindPendingInvitationsByProjectID indicates an expected call of FindPendingInvitationsByProjectID.
+func (mr *MockTeamRequestRepositoryInterfaceMockRecorder) FindPendingInvitationsByProjectID(projectID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveHackathonIDs", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).GetActiveHackathonIDs))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingInvitationsByProjectID", reflect.TypeOf((*MockTeamRequestRepositoryInterface)(nil).FindPendingInvitationsByProjectID), projectID)
 }
 
-// GetActiveWithSchedule mocks base method.
-func (m *MockHackathonRepositoryInterface) GetActiveWithSchedule() (*models.Hackathons, error) {
+// FindPendingJoinRequestsByProjectID mocks base method.
+func (m *MockTeamRequestRepositoryInterface) FindPendingJoinRequestsByProjectID(projectID any) (*models.JoinRequests, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveWithSchedule")
-	ret0, _ := ret[0].(*models.Hackathons)
+	ret := m.ctrl.Call(m, "FindPendingJoinRequestsByProjectID", projectID)
+	ret0, _ := ret[0].(*models.JoinRequests)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActiveWithSchedule indicates an expected call of GetActiveWithSchedule.
-func (mr *MockHackathonRepositoryInterfaceMockRecorder) GetActiveWithSchedule() *gomock.Call {
+// FindPendingJoinRequestsByProjectID indicates an expected call of FindPendingJoinRequestsByProjectID.
+func (mr *MockTeamRequestRepositoryInterfaceMockRecorder) FindPendingJoinRequestsByProjectID(projectID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveWithSchedule", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).GetActiveWithSchedule))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingJoinRequestsByProjectID", reflect.TypeOf((*MockTeamRequestRepositoryInterface)(nil).FindPendingJoinRequestsByProjectID), projectID)
 }
 
-// GetRecent mocks base method.
-func (m *MockHackathonRepositoryInterface) GetRecent(limit int) (*models.Hackathons, error) {
+// HasPendingJoinRequest mocks base method.
+func (m *MockTeamRequestRepositoryInterface) HasPendingJoinRequest(projectID, userID any) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecent", limit)
-	ret0, _ := ret[0].(*models.Hackathons)
+	ret := m.ctrl.Call(m, "HasPendingJoinRequest", projectID, userID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecent indicates an expected call of GetRecent.
-func (mr *MockHackathonRepositoryInterfaceMockRecorder) GetRecent(limit any) *gomock.Call {
+// HasPendingJoinRequest indicates an expected call of HasPendingJoinRequest.
+func (mr *MockTeamRequestRepositoryInterfaceMockRecorder) HasPendingJoinRequest(projectID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockHackathonRepositoryInterface)(nil).GetRecent), limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingJoinRequest", reflect.TypeOf((*MockTeamRequestRepositoryInterface)(nil).HasPendingJoinRequest), projectID, userID)
 }
 
-// MockProjectRepositoryInterface is a mock of ProjectRepositoryInterface interface.
-type MockProjectRepositoryInterface struct {
+// MockRecoveryCodeRepositoryInterface is a mock of RecoveryCodeRepositoryInterface interface.
+type MockRecoveryCodeRepositoryInterface struct {
 	ctrl     *gomock.Controller
-	recorder *MockProjectRepositoryInterfaceMockRecorder
+	recorder *MockRecoveryCodeRepositoryInterfaceMockRecorder
 	isgomock struct{}
 }
 
-// MockProjectRepositoryInterfaceMockRecorder is the mock recorder for MockProjectRepositoryInterface.
-type MockProjectRepositoryInterfaceMockRecorder struct {
-	mock *MockProjectRepositoryInterface
+// MockRecoveryCodeRepositoryInterfaceMockRecorder is the mock recorder for MockRecoveryCodeRepositoryInterface.
+type MockRecoveryCodeRepositoryInterfaceMockRecorder struct {
+	mock *MockRecoveryCodeRepositoryInterface
 }
 
-// NewMockProjectRepositoryInterface creates a new mock instance.
-func NewMockProjectRepositoryInterface(ctrl *gomock.Controller) *MockProjectRepositoryInterface {
-	mock := &MockProjectRepositoryInterface{ctrl: ctrl}
-	mock.recorder = &MockProjectRepositoryInterfaceMockRecorder{mock}
+// NewMockRecoveryCodeRepositoryInterface creates a new mock instance.
+func NewMockRecoveryCodeRepositoryInterface(ctrl *gomock.Controller) *MockRecoveryCodeRepositoryInterface {
+	mock := &MockRecoveryCodeRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRecoveryCodeRepositoryInterfaceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockProjectRepositoryInterface) EXPECT() *MockProjectRepositoryInterfaceMockRecorder {
+func (m *MockRecoveryCodeRepositoryInterface) EXPECT() *MockRecoveryCodeRepositoryInterfaceMockRecorder {
 	return m.recorder
 }
 
-// Count mocks base method.
-func (m *MockProjectRepositoryInterface) Count() (int, error) {
+// CountUnusedByUserID mocks base method.
+func (m *MockRecoveryCodeRepositoryInterface) CountUnusedByUserID(userID any) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count")
+	ret := m.ctrl.Call(m, "CountUnusedByUserID", userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Count indicates an expected call of Count.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) Count() *gomock.Call {
+// CountUnusedByUserID indicates an expected call of CountUnusedByUserID.
+func (mr *MockRecoveryCodeRepositoryInterfaceMockRecorder) CountUnusedByUserID(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).Count))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnusedByUserID", reflect.TypeOf((*MockRecoveryCodeRepositoryInterface)(nil).CountUnusedByUserID), userID)
 }
 
-// CountActive mocks base method.
-func (m *MockProjectRepositoryInterface) CountActive() (int, error) {
+// DeleteByUserID mocks base method.
+func (m *MockRecoveryCodeRepositoryInterface) DeleteByUserID(userID any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountActive")
+	ret := m.ctrl.Call(m, "DeleteByUserID", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockRecoveryCodeRepositoryInterfaceMockRecorder) DeleteByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockRecoveryCodeRepositoryInterface)(nil).DeleteByUserID), userID)
+}
+
+// FindUnusedByUserIDAndHash mocks base method.
+func (m *MockRecoveryCodeRepositoryInterface) FindUnusedByUserIDAndHash(userID any, codeHash string) (*models.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnusedByUserIDAndHash", userID, codeHash)
+	ret0, _ := ret[0].(*models.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnusedByUserIDAndHash indicates an expected call of FindUnusedByUserIDAndHash.
+func (mr *MockRecoveryCodeRepositoryInterfaceMockRecorder) FindUnusedByUserIDAndHash(userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnusedByUserIDAndHash", reflect.TypeOf((*MockRecoveryCodeRepositoryInterface)(nil).FindUnusedByUserIDAndHash), userID, codeHash)
+}
+
+// MockPasswordResetRepositoryInterface is a mock of PasswordResetRepositoryInterface interface.
+type MockPasswordResetRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetRepositoryInterfaceMockRecorder is the mock recorder for MockPasswordResetRepositoryInterface.
+type MockPasswordResetRepositoryInterfaceMockRecorder struct {
+	mock *MockPasswordResetRepositoryInterface
+}
+
+// NewMockPasswordResetRepositoryInterface creates a new mock instance.
+func NewMockPasswordResetRepositoryInterface(ctrl *gomock.Controller) *MockPasswordResetRepositoryInterface {
+	mock := &MockPasswordResetRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetRepositoryInterface) EXPECT() *MockPasswordResetRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountByEmailSince mocks base method.
+func (m *MockPasswordResetRepositoryInterface) CountByEmailSince(email string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByEmailSince", email, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByEmailSince indicates an expected call of CountByEmailSince.
+func (mr *MockPasswordResetRepositoryInterfaceMockRecorder) CountByEmailSince(email, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByEmailSince", reflect.TypeOf((*MockPasswordResetRepositoryInterface)(nil).CountByEmailSince), email, since)
+}
+
+// CountByIPAddressSince mocks base method.
+func (m *MockPasswordResetRepositoryInterface) CountByIPAddressSince(ipAddress string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByIPAddressSince", ipAddress, since)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountActive indicates an expected call of CountActive.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) CountActive() *gomock.Call {
+// CountByIPAddressSince indicates an expected call of CountByIPAddressSince.
+func (mr *MockPasswordResetRepositoryInterfaceMockRecorder) CountByIPAddressSince(ipAddress, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByIPAddressSince", reflect.TypeOf((*MockPasswordResetRepositoryInterface)(nil).CountByIPAddressSince), ipAddress, since)
+}
+
+// ExpireByUserID mocks base method.
+func (m *MockPasswordResetRepositoryInterface) ExpireByUserID(userID any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireByUserID", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpireByUserID indicates an expected call of ExpireByUserID.
+func (mr *MockPasswordResetRepositoryInterfaceMockRecorder) ExpireByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireByUserID", reflect.TypeOf((*MockPasswordResetRepositoryInterface)(nil).ExpireByUserID), userID)
+}
+
+// FindByTokenHash mocks base method.
+func (m *MockPasswordResetRepositoryInterface) FindByTokenHash(tokenHash string) (*models.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockPasswordResetRepositoryInterfaceMockRecorder) FindByTokenHash(tokenHash any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActive", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).CountActive))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockPasswordResetRepositoryInterface)(nil).FindByTokenHash), tokenHash)
 }
 
-// CountMembershipsByProjectID mocks base method.
-func (m *MockProjectRepositoryInterface) CountMembershipsByProjectID(projectID any) (int, error) {
+// MockUserSessionRepositoryInterface is a mock of UserSessionRepositoryInterface interface.
+type MockUserSessionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSessionRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserSessionRepositoryInterfaceMockRecorder is the mock recorder for MockUserSessionRepositoryInterface.
+type MockUserSessionRepositoryInterfaceMockRecorder struct {
+	mock *MockUserSessionRepositoryInterface
+}
+
+// NewMockUserSessionRepositoryInterface creates a new mock instance.
+func NewMockUserSessionRepositoryInterface(ctrl *gomock.Controller) *MockUserSessionRepositoryInterface {
+	mock := &MockUserSessionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserSessionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSessionRepositoryInterface) EXPECT() *MockUserSessionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountByUserID mocks base method.
+func (m *MockUserSessionRepositoryInterface) CountByUserID(userID any) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountMembershipsByProjectID", projectID)
+	ret := m.ctrl.Call(m, "CountByUserID", userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountMembershipsByProjectID indicates an expected call of CountMembershipsByProjectID.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) CountMembershipsByProjectID(projectID any) *gomock.Call {
+// CountByUserID indicates an expected call of CountByUserID.
+func (mr *MockUserSessionRepositoryInterfaceMockRecorder) CountByUserID(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMembershipsByProjectID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).CountMembershipsByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserID", reflect.TypeOf((*MockUserSessionRepositoryInterface)(nil).CountByUserID), userID)
 }
 
-// CountPresenting mocks base method.
-func (m *MockProjectRepositoryInterface) CountPresenting() (int, error) {
+// DeleteByUserID mocks base method.
+func (m *MockUserSessionRepositoryInterface) DeleteByUserID(userID any) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountPresenting")
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteByUserID", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CountPresenting indicates an expected call of CountPresenting.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) CountPresenting() *gomock.Call {
+// DeleteByUserID indicates an expected call of DeleteByUserID.
+func (mr *MockUserSessionRepositoryInterfaceMockRecorder) DeleteByUserID(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPresenting", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).CountPresenting))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserID", reflect.TypeOf((*MockUserSessionRepositoryInterface)(nil).DeleteByUserID), userID)
 }
 
-// FindByHackathonID mocks base method.
-func (m *MockProjectRepositoryInterface) FindByHackathonID(hackathonID any) (*models.Projects, error) {
+// DeleteExpired mocks base method.
+func (m *MockUserSessionRepositoryInterface) DeleteExpired(idleBefore, createdBefore time.Time) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByHackathonID", hackathonID)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "DeleteExpired", idleBefore, createdBefore)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByHackathonID indicates an expected call of FindByHackathonID.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByHackathonID(hackathonID any) *gomock.Call {
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockUserSessionRepositoryInterfaceMockRecorder) DeleteExpired(idleBefore, createdBefore any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHackathonID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByHackathonID), hackathonID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockUserSessionRepositoryInterface)(nil).DeleteExpired), idleBefore, createdBefore)
 }
 
 // FindByID mocks base method.
-func (m *MockProjectRepositoryInterface) FindByID(id any) (*models.Project, error) {
+func (m *MockUserSessionRepositoryInterface) FindByID(id any) (*models.UserSession, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByID", id)
-	ret0, _ := ret[0].(*models.Project)
+	ret0, _ := ret[0].(*models.UserSession)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+func (mr *MockUserSessionRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserSessionRepositoryInterface)(nil).FindByID), id)
 }
 
 // FindByUserID mocks base method.
-func (m *MockProjectRepositoryInterface) FindByUserID(userID any) (*models.Projects, error) {
+func (m *MockUserSessionRepositoryInterface) FindByUserID(userID any) (*models.UserSessions, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByUserID", userID)
-	ret0, _ := ret[0].(*models.Projects)
+	ret0, _ := ret[0].(*models.UserSessions)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByUserID indicates an expected call of FindByUserID.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByUserID(userID any) *gomock.Call {
+func (mr *MockUserSessionRepositoryInterfaceMockRecorder) FindByUserID(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByUserID), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockUserSessionRepositoryInterface)(nil).FindByUserID), userID)
 }
 
-// FindByUserIDWithHackathon mocks base method.
-func (m *MockProjectRepositoryInterface) FindByUserIDWithHackathon(userID any) (*models.Projects, error) {
+// MockLoginAttemptRepositoryInterface is a mock of LoginAttemptRepositoryInterface interface.
+type MockLoginAttemptRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginAttemptRepositoryInterfaceMockRecorder is the mock recorder for MockLoginAttemptRepositoryInterface.
+type MockLoginAttemptRepositoryInterfaceMockRecorder struct {
+	mock *MockLoginAttemptRepositoryInterface
+}
+
+// NewMockLoginAttemptRepositoryInterface creates a new mock instance.
+func NewMockLoginAttemptRepositoryInterface(ctrl *gomock.Controller) *MockLoginAttemptRepositoryInterface {
+	mock := &MockLoginAttemptRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptRepositoryInterface) EXPECT() *MockLoginAttemptRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteByEmail mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) DeleteByEmail(email string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUserIDWithHackathon", userID)
-	ret0, _ := ret[0].(*models.Projects)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteByEmail", email)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FindByUserIDWithHackathon indicates an expected call of FindByUserIDWithHackathon.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) FindByUserIDWithHackathon(userID any) *gomock.Call {
+// DeleteByEmail indicates an expected call of DeleteByEmail.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) DeleteByEmail(email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDWithHackathon", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindByUserIDWithHackathon), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByEmail", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).DeleteByEmail), email)
 }
 
-// FindPresentingByHackathonID mocks base method.
-func (m *MockProjectRepositoryInterface) FindPresentingByHackathonID(hackathonID any) (*models.Projects, error) {
+// FindByEmailSince mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) FindByEmailSince(email string, since time.Time) (*models.LoginAttempts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindPresentingByHackathonID", hackathonID)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "FindByEmailSince", email, since)
+	ret0, _ := ret[0].(*models.LoginAttempts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindPresentingByHackathonID indicates an expected call of FindPresentingByHackathonID.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) FindPresentingByHackathonID(hackathonID any) *gomock.Call {
+// FindByEmailSince indicates an expected call of FindByEmailSince.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) FindByEmailSince(email, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPresentingByHackathonID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindPresentingByHackathonID), hackathonID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmailSince", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).FindByEmailSince), email, since)
 }
 
-// FindPresentingFromActiveHackathons mocks base method.
-func (m *MockProjectRepositoryInterface) FindPresentingFromActiveHackathons() (*models.Projects, error) {
+// FindByIPAddressSince mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) FindByIPAddressSince(ipAddress string, since time.Time) (*models.LoginAttempts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindPresentingFromActiveHackathons")
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "FindByIPAddressSince", ipAddress, since)
+	ret0, _ := ret[0].(*models.LoginAttempts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindPresentingFromActiveHackathons indicates an expected call of FindPresentingFromActiveHackathons.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) FindPresentingFromActiveHackathons() *gomock.Call {
+// FindByIPAddressSince indicates an expected call of FindByIPAddressSince.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) FindByIPAddressSince(ipAddress, since any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPresentingFromActiveHackathons", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).FindPresentingFromActiveHackathons))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIPAddressSince", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).FindByIPAddressSince), ipAddress, since)
 }
 
-// GetFilesByProjectID mocks base method.
-func (m *MockProjectRepositoryInterface) GetFilesByProjectID(projectID any) (*models.Files, error) {
+// FindLocked mocks base method.
+func (m *MockLoginAttemptRepositoryInterface) FindLocked(since time.Time, threshold int) (*models.LockedAccounts, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilesByProjectID", projectID)
-	ret0, _ := ret[0].(*models.Files)
+	ret := m.ctrl.Call(m, "FindLocked", since, threshold)
+	ret0, _ := ret[0].(*models.LockedAccounts)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFilesByProjectID indicates an expected call of GetFilesByProjectID.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) GetFilesByProjectID(projectID any) *gomock.Call {
+// FindLocked indicates an expected call of FindLocked.
+func (mr *MockLoginAttemptRepositoryInterfaceMockRecorder) FindLocked(since, threshold any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilesByProjectID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetFilesByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLocked", reflect.TypeOf((*MockLoginAttemptRepositoryInterface)(nil).FindLocked), since, threshold)
 }
 
-// GetMembershipsByProjectID mocks base method.
-func (m *MockProjectRepositoryInterface) GetMembershipsByProjectID(projectID any) (*models.ProjectMemberships, error) {
+// MockSCIMTokenRepositoryInterface is a mock of SCIMTokenRepositoryInterface interface.
+type MockSCIMTokenRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSCIMTokenRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSCIMTokenRepositoryInterfaceMockRecorder is the mock recorder for MockSCIMTokenRepositoryInterface.
+type MockSCIMTokenRepositoryInterfaceMockRecorder struct {
+	mock *MockSCIMTokenRepositoryInterface
+}
+
+// NewMockSCIMTokenRepositoryInterface creates a new mock instance.
+func NewMockSCIMTokenRepositoryInterface(ctrl *gomock.Controller) *MockSCIMTokenRepositoryInterface {
+	mock := &MockSCIMTokenRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSCIMTokenRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSCIMTokenRepositoryInterface) EXPECT() *MockSCIMTokenRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockSCIMTokenRepositoryInterface) FindAll() (*models.SCIMTokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMembershipsByProjectID", projectID)
-	ret0, _ := ret[0].(*models.ProjectMemberships)
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].(*models.SCIMTokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMembershipsByProjectID indicates an expected call of GetMembershipsByProjectID.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) GetMembershipsByProjectID(projectID any) *gomock.Call {
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSCIMTokenRepositoryInterfaceMockRecorder) FindAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipsByProjectID", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetMembershipsByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSCIMTokenRepositoryInterface)(nil).FindAll))
 }
 
-// GetRecent mocks base method.
-func (m *MockProjectRepositoryInterface) GetRecent(limit int) (*models.Projects, error) {
+// FindByTokenHash mocks base method.
+func (m *MockSCIMTokenRepositoryInterface) FindByTokenHash(tokenHash string) (*models.SCIMToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecent", limit)
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "FindByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.SCIMToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRecent indicates an expected call of GetRecent.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) GetRecent(limit any) *gomock.Call {
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockSCIMTokenRepositoryInterfaceMockRecorder) FindByTokenHash(tokenHash any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetRecent), limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockSCIMTokenRepositoryInterface)(nil).FindByTokenHash), tokenHash)
 }
 
-// IsUserMemberOfProject mocks base method.
-func (m *MockProjectRepositoryInterface) IsUserMemberOfProject(projectID, userID any) (bool, error) {
+// Touch mocks base method.
+func (m *MockSCIMTokenRepositoryInterface) Touch(id any, at time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsUserMemberOfProject", projectID, userID)
-	ret0, _ := ret[0].(bool)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Touch", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// IsUserMemberOfProject indicates an expected call of IsUserMemberOfProject.
-func (mr *MockProjectRepositoryInterfaceMockRecorder) IsUserMemberOfProject(projectID, userID any) *gomock.Call {
+// Touch indicates an expected call of Touch.
+func (mr *MockSCIMTokenRepositoryInterfaceMockRecorder) Touch(id, at any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserMemberOfProject", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).IsUserMemberOfProject), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockSCIMTokenRepositoryInterface)(nil).Touch), id, at)
 }
 
-// MockProjectMembershipRepositoryInterface is a mock of ProjectMembershipRepositoryInterface interface.
-type MockProjectMembershipRepositoryInterface struct {
+// MockInvitationRepositoryInterface is a mock of InvitationRepositoryInterface interface.
+type MockInvitationRepositoryInterface struct {
 	ctrl     *gomock.Controller
-	recorder *MockProjectMembershipRepositoryInterfaceMockRecorder
+	recorder *MockInvitationRepositoryInterfaceMockRecorder
 	isgomock struct{}
 }
 
-// MockProjectMembershipRepositoryInterfaceMockRecorder is the mock recorder for MockProjectMembershipRepositoryInterface.
-type MockProjectMembershipRepositoryInterfaceMockRecorder struct {
-	mock *MockProjectMembershipRepositoryInterface
+// MockInvitationRepositoryInterfaceMockRecorder is the mock recorder for MockInvitationRepositoryInterface.
+type MockInvitationRepositoryInterfaceMockRecorder struct {
+	mock *MockInvitationRepositoryInterface
 }
 
-// NewMockProjectMembershipRepositoryInterface creates a new mock instance.
-func NewMockProjectMembershipRepositoryInterface(ctrl *gomock.Controller) *MockProjectMembershipRepositoryInterface {
-	mock := &MockProjectMembershipRepositoryInterface{ctrl: ctrl}
-	mock.recorder = &MockProjectMembershipRepositoryInterfaceMockRecorder{mock}
+// NewMockInvitationRepositoryInterface creates a new mock instance.
+func NewMockInvitationRepositoryInterface(ctrl *gomock.Controller) *MockInvitationRepositoryInterface {
+	mock := &MockInvitationRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockInvitationRepositoryInterfaceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockProjectMembershipRepositoryInterface) EXPECT() *MockProjectMembershipRepositoryInterfaceMockRecorder {
+func (m *MockInvitationRepositoryInterface) EXPECT() *MockInvitationRepositoryInterfaceMockRecorder {
 	return m.recorder
 }
 
-// CountByProjectID mocks base method.
-func (m *MockProjectMembershipRepositoryInterface) CountByProjectID(projectID any) (int, error) {
+// FindAll mocks base method.
+func (m *MockInvitationRepositoryInterface) FindAll() (*models.Invitations, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountByProjectID", projectID)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].(*models.Invitations)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountByProjectID indicates an expected call of CountByProjectID.
-func (mr *MockProjectMembershipRepositoryInterfaceMockRecorder) CountByProjectID(projectID any) *gomock.Call {
+// FindAll indicates an expected call of FindAll.
+func (mr *MockInvitationRepositoryInterfaceMockRecorder) FindAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByProjectID", reflect.TypeOf((*MockProjectMembershipRepositoryInterface)(nil).CountByProjectID), projectID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockInvitationRepositoryInterface)(nil).FindAll))
 }
 
-// FindByProjectIDAndUserID mocks base method.
-func (m *MockProjectMembershipRepositoryInterface) FindByProjectIDAndUserID(projectID, userID any) (*models.ProjectMembership, error) {
+// FindByID mocks base method.
+func (m *MockInvitationRepositoryInterface) FindByID(id any) (*models.Invitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByProjectIDAndUserID", projectID, userID)
-	ret0, _ := ret[0].(*models.ProjectMembership)
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.Invitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByProjectIDAndUserID indicates an expected call of FindByProjectIDAndUserID.
-func (mr *MockProjectMembershipRepositoryInterfaceMockRecorder) FindByProjectIDAndUserID(projectID, userID any) *gomock.Call {
+// FindByID indicates an expected call of FindByID.
+func (mr *MockInvitationRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProjectIDAndUserID", reflect.TypeOf((*MockProjectMembershipRepositoryInterface)(nil).FindByProjectIDAndUserID), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockInvitationRepositoryInterface)(nil).FindByID), id)
 }
 
-// IsUserMember mocks base method.
-func (m *MockProjectMembershipRepositoryInterface) IsUserMember(projectID, userID any) (bool, error) {
+// Redeem mocks base method.
+func (m *MockInvitationRepositoryInterface) Redeem(id any, now time.Time) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsUserMember", projectID, userID)
+	ret := m.ctrl.Call(m, "Redeem", id, now)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsUserMember indicates an expected call of IsUserMember.
-func (mr *MockProjectMembershipRepositoryInterfaceMockRecorder) IsUserMember(projectID, userID any) *gomock.Call {
+// Redeem indicates an expected call of Redeem.
+func (mr *MockInvitationRepositoryInterfaceMockRecorder) Redeem(id, now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserMember", reflect.TypeOf((*MockProjectMembershipRepositoryInterface)(nil).IsUserMember), projectID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockInvitationRepositoryInterface)(nil).Redeem), id, now)
 }
 
-// MockFileRepositoryInterface is a mock of FileRepositoryInterface interface.
-type MockFileRepositoryInterface struct {
+// MockAPITokenRepositoryInterface is a mock of APITokenRepositoryInterface interface.
+type MockAPITokenRepositoryInterface struct {
 	ctrl     *gomock.Controller
-	recorder *MockFileRepositoryInterfaceMockRecorder
+	recorder *MockAPITokenRepositoryInterfaceMockRecorder
 	isgomock struct{}
 }
 
-// MockFileRepositoryInterfaceMockRecorder is the mock recorder for MockFileRepositoryInterface.
-type MockFileRepositoryInterfaceMockRecorder struct {
-	mock *MockFileRepositoryInterface
+// MockAPITokenRepositoryInterfaceMockRecorder is the mock recorder for MockAPITokenRepositoryInterface.
+type MockAPITokenRepositoryInterfaceMockRecorder struct {
+	mock *MockAPITokenRepositoryInterface
 }
 
-// NewMockFileRepositoryInterface creates a new mock instance.
-func NewMockFileRepositoryInterface(ctrl *gomock.Controller) *MockFileRepositoryInterface {
-	mock := &MockFileRepositoryInterface{ctrl: ctrl}
-	mock.recorder = &MockFileRepositoryInterfaceMockRecorder{mock}
+// NewMockAPITokenRepositoryInterface creates a new mock instance.
+func NewMockAPITokenRepositoryInterface(ctrl *gomock.Controller) *MockAPITokenRepositoryInterface {
+	mock := &MockAPITokenRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAPITokenRepositoryInterfaceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockFileRepositoryInterface) EXPECT() *MockFileRepositoryInterfaceMockRecorder {
+func (m *MockAPITokenRepositoryInterface) EXPECT() *MockAPITokenRepositoryInterfaceMockRecorder {
 	return m.recorder
 }
 
 // FindAll mocks base method.
-func (m *MockFileRepositoryInterface) FindAll() (*models.Files, error) {
+func (m *MockAPITokenRepositoryInterface) FindAll() (*models.APITokens, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].(*models.Files)
+	ret0, _ := ret[0].(*models.APITokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockFileRepositoryInterfaceMockRecorder) FindAll() *gomock.Call {
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) FindAll() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).FindAll))
 }
 
-// FindAllHackathons mocks base method.
-func (m *MockFileRepositoryInterface) FindAllHackathons() (*models.Hackathons, error) {
+// FindByID mocks base method.
+func (m *MockAPITokenRepositoryInterface) FindByID(id any) (*models.APIToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllHackathons")
-	ret0, _ := ret[0].(*models.Hackathons)
+	ret := m.ctrl.Call(m, "FindByID", id)
+	ret0, _ := ret[0].(*models.APIToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllHackathons indicates an expected call of FindAllHackathons.
-func (mr *MockFileRepositoryInterfaceMockRecorder) FindAllHackathons() *gomock.Call {
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllHackathons", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindAllHackathons))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).FindByID), id)
 }
 
-// FindAllProjects mocks base method.
-func (m *MockFileRepositoryInterface) FindAllProjects() (*models.Projects, error) {
+// FindByTokenHash mocks base method.
+func (m *MockAPITokenRepositoryInterface) FindByTokenHash(tokenHash string) (*models.APIToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllProjects")
-	ret0, _ := ret[0].(*models.Projects)
+	ret := m.ctrl.Call(m, "FindByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.APIToken)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllProjects indicates an expected call of FindAllProjects.
-func (mr *MockFileRepositoryInterfaceMockRecorder) FindAllProjects() *gomock.Call {
+// FindByTokenHash indicates an expected call of FindByTokenHash.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) FindByTokenHash(tokenHash any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllProjects", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindAllProjects))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTokenHash", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).FindByTokenHash), tokenHash)
 }
 
-// FindByID mocks base method.
-func (m *MockFileRepositoryInterface) FindByID(id any) (*models.File, error) {
+// FindByUserID mocks base method.
+func (m *MockAPITokenRepositoryInterface) FindByUserID(userID any) (*models.APITokens, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", id)
-	ret0, _ := ret[0].(*models.File)
+	ret := m.ctrl.Call(m, "FindByUserID", userID)
+	ret0, _ := ret[0].(*models.APITokens)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByID indicates an expected call of FindByID.
-func (mr *MockFileRepositoryInterfaceMockRecorder) FindByID(id any) *gomock.Call {
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) FindByUserID(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockFileRepositoryInterface)(nil).FindByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).FindByUserID), userID)
 }
 
-// MockCompanyAllowedDomainRepositoryInterface is a mock of CompanyAllowedDomainRepositoryInterface interface.
-type MockCompanyAllowedDomainRepositoryInterface struct {
+// Touch mocks base method.
+func (m *MockAPITokenRepositoryInterface) Touch(id any, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) Touch(id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).Touch), id, at)
+}
+
+// MockAuditLogRepositoryInterface is a mock of AuditLogRepositoryInterface interface.
+type MockAuditLogRepositoryInterface struct {
 	ctrl     *gomock.Controller
-	recorder *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder
+	recorder *MockAuditLogRepositoryInterfaceMockRecorder
 	isgomock struct{}
 }
 
-// MockCompanyAllowedDomainRepositoryInterfaceMockRecorder is the mock recorder for MockCompanyAllowedDomainRepositoryInterface.
-type MockCompanyAllowedDomainRepositoryInterfaceMockRecorder struct {
-	mock *MockCompanyAllowedDomainRepositoryInterface
+// MockAuditLogRepositoryInterfaceMockRecorder is the mock recorder for MockAuditLogRepositoryInterface.
+type MockAuditLogRepositoryInterfaceMockRecorder struct {
+	mock *MockAuditLogRepositoryInterface
 }
 
-// NewMockCompanyAllowedDomainRepositoryInterface creates a new mock instance.
-func NewMockCompanyAllowedDomainRepositoryInterface(ctrl *gomock.Controller) *MockCompanyAllowedDomainRepositoryInterface {
-	mock := &MockCompanyAllowedDomainRepositoryInterface{ctrl: ctrl}
-	mock.recorder = &MockCompanyAllowedDomainRepositoryInterfaceMockRecorder{mock}
+// NewMockAuditLogRepositoryInterface creates a new mock instance.
+func NewMockAuditLogRepositoryInterface(ctrl *gomock.Controller) *MockAuditLogRepositoryInterface {
+	mock := &MockAuditLogRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepositoryInterfaceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockCompanyAllowedDomainRepositoryInterface) EXPECT() *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder {
+func (m *MockAuditLogRepositoryInterface) EXPECT() *MockAuditLogRepositoryInterfaceMockRecorder {
 	return m.recorder
 }
 
-// FindAll mocks base method.
-func (m *MockCompanyAllowedDomainRepositoryInterface) FindAll() (*models.CompanyAllowedDomains, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
-	ret0, _ := ret[0].(*models.CompanyAllowedDomains)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// FindAll indicates an expected call of FindAll.
-func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) FindAll() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).FindAll))
-}
-
-// FindAllActive mocks base method.
-func (m *MockCompanyAllowedDomainRepositoryInterface) FindAllActive() (*models.CompanyAllowedDomains, error) {
+// Create mocks base method.
+func (m *MockAuditLogRepositoryInterface) Create(auditLog *models.AuditLog) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllActive")
-	ret0, _ := ret[0].(*models.CompanyAllowedDomains)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Create", auditLog)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// FindAllActive indicates an expected call of FindAllActive.
-func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) FindAllActive() *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockAuditLogRepositoryInterfaceMockRecorder) Create(auditLog any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllActive", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).FindAllActive))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuditLogRepositoryInterface)(nil).Create), auditLog)
 }
 
-// IsDomainAllowed mocks base method.
-func (m *MockCompanyAllowedDomainRepositoryInterface) IsDomainAllowed(domain string) (bool, error) {
+// FindBetween mocks base method.
+func (m *MockAuditLogRepositoryInterface) FindBetween(since, until time.Time, action string) (*models.AuditLogs, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsDomainAllowed", domain)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "FindBetween", since, until, action)
+	ret0, _ := ret[0].(*models.AuditLogs)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsDomainAllowed indicates an expected call of IsDomainAllowed.
-func (mr *MockCompanyAllowedDomainRepositoryInterfaceMockRecorder) IsDomainAllowed(domain any) *gomock.Call {
+// FindBetween indicates an expected call of FindBetween.
+func (mr *MockAuditLogRepositoryInterfaceMockRecorder) FindBetween(since, until, action any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDomainAllowed", reflect.TypeOf((*MockCompanyAllowedDomainRepositoryInterface)(nil).IsDomainAllowed), domain)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBetween", reflect.TypeOf((*MockAuditLogRepositoryInterface)(nil).FindBetween), since, until, action)
 }
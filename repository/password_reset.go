@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// PasswordResetRepository handles forgot-password request database operations
+type PasswordResetRepository struct {
+	*BaseRepository
+}
+
+// NewPasswordResetRepository creates a new password reset repository
+func NewPasswordResetRepository(conn *pop.Connection) *PasswordResetRepository {
+	return &PasswordResetRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByTokenHash finds a password reset by the hash of its emailed token
+func (r *PasswordResetRepository) FindByTokenHash(tokenHash string) (*models.PasswordReset, error) {
+	reset := &models.PasswordReset{}
+	err := r.conn.Where("token_hash = ?", tokenHash).First(reset)
+	return reset, err
+}
+
+// CountByEmailSince counts the reset requests made for an email address since a point in time
+func (r *PasswordResetRepository) CountByEmailSince(email string, since time.Time) (int, error) {
+	return r.conn.Where("email = ? AND created_at >= ?", email, since).Count(&models.PasswordReset{})
+}
+
+// CountByIPAddressSince counts the reset requests made from an IP address since a point in time
+func (r *PasswordResetRepository) CountByIPAddressSince(ipAddress string, since time.Time) (int, error) {
+	return r.conn.Where("ip_address = ? AND created_at >= ?", ipAddress, since).Count(&models.PasswordReset{})
+}
+
+// ExpireByUserID marks every outstanding reset link of a user as used
+func (r *PasswordResetRepository) ExpireByUserID(userID interface{}) error {
+	return r.conn.RawQuery("UPDATE password_resets SET used_at = ?, updated_at = ? WHERE user_id = ? AND used_at IS NULL", time.Now(), time.Now(), userID).Exec()
+}
@@ -52,6 +52,13 @@ func (r *ProjectRepository) FindByHackathonID(hackathonID interface{}) (*models.
 	return projects, err
 }
 
+// FindApprovedByHackathonID finds the projects of a hackathon that passed review
+func (r *ProjectRepository) FindApprovedByHackathonID(hackathonID interface{}) (*models.Projects, error) {
+	projects := &models.Projects{}
+	err := r.conn.Where("hackathon_id = ? AND review_status = ?", hackathonID, models.ProjectReviewApproved).Eager("User").All(projects)
+	return projects, err
+}
+
 // FindByUserID finds all projects created by a specific user
 func (r *ProjectRepository) FindByUserID(userID interface{}) (*models.Projects, error) {
 	projects := &models.Projects{}
@@ -69,14 +76,14 @@ func (r *ProjectRepository) FindByUserIDWithHackathon(userID interface{}) (*mode
 // FindPresentingByHackathonID finds presenting projects for a specific hackathon
 func (r *ProjectRepository) FindPresentingByHackathonID(hackathonID interface{}) (*models.Projects, error) {
 	projects := &models.Projects{}
-	err := r.conn.Where("hackathon_id = ? AND presenting = ?", hackathonID, true).Order("presentation_order asc").Eager("User").All(projects)
+	err := r.conn.Where("hackathon_id = ? AND presenting = ? AND review_status = ?", hackathonID, true, models.ProjectReviewApproved).Order("presentation_order asc").Eager("User").All(projects)
 	return projects, err
 }
 
 // FindPresentingFromActiveHackathons finds all presenting projects from active/upcoming hackathons
 func (r *ProjectRepository) FindPresentingFromActiveHackathons() (*models.Projects, error) {
 	projects := &models.Projects{}
-	err := r.conn.Where("presenting = ? AND hackathon_id IN (SELECT id FROM hackathons WHERE status IN (?, ?))", true, "active", "upcoming").Order("presentation_order ASC").Eager("User", "Hackathon").All(projects)
+	err := r.conn.Where("presenting = ? AND review_status = ? AND hackathon_id IN (SELECT id FROM hackathons WHERE status IN (?, ?))", true, models.ProjectReviewApproved, "active", "upcoming").Order("presentation_order ASC").Eager("User", "Hackathon").All(projects)
 	return projects, err
 }
 
@@ -112,3 +119,39 @@ func (r *ProjectRepository) IsUserMemberOfProject(projectID, userID interface{})
 	count, err := r.conn.Where("project_id = ? AND user_id = ?", projectID, userID).Count(&models.ProjectMembership{})
 	return count > 0, err
 }
+
+// LockByID finds a project by ID and locks its row until the surrounding transaction ends,
+// serializing concurrent changes to the project's team
+func (r *ProjectRepository) LockByID(id interface{}) (*models.Project, error) {
+	project := &models.Project{}
+	err := r.conn.RawQuery("SELECT * FROM projects WHERE id = ? FOR UPDATE", id).First(project)
+	return project, err
+}
+
+// FindPendingReview finds all projects waiting for an organizer's decision, oldest first
+func (r *ProjectRepository) FindPendingReview() (*models.Projects, error) {
+	projects := &models.Projects{}
+	err := r.conn.Where("review_status = ?", models.ProjectReviewPending).Order("created_at asc").Eager("User", "Hackathon").All(projects)
+	return projects, err
+}
+
+// CountPendingReview returns the number of projects waiting for an organizer's decision
+func (r *ProjectRepository) CountPendingReview() (int, error) {
+	count, err := r.conn.Where("review_status = ?", models.ProjectReviewPending).Count(&models.Project{})
+	return count, err
+}
+
+// FindVisiblePage returns a page of projects, optionally of one hackathon. Unless includeAll is
+// true, projects waiting for review are only included for their team and their hackathon's owner.
+func (r *ProjectRepository) FindVisiblePage(hackathonID string, viewerID interface{}, includeAll bool, after *PageCursor, limit int) (*models.Projects, error) {
+	projects := &models.Projects{}
+	q := r.conn.Q()
+	if hackathonID != "" {
+		q = q.Where("hackathon_id = ?", hackathonID)
+	}
+	if !includeAll {
+		q = q.Where("(review_status = ? OR id IN (SELECT project_id FROM project_memberships WHERE user_id = ?) OR hackathon_id IN (SELECT id FROM hackathons WHERE owner_id = ?))", models.ProjectReviewApproved, viewerID, viewerID)
+	}
+	err := pageAfter(q, "projects", after, limit).All(projects)
+	return projects, err
+}
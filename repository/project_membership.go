@@ -35,3 +35,10 @@ func (r *ProjectMembershipRepository) IsUserMember(projectID, userID interface{}
 	count, err := r.conn.Where("project_id = ? AND user_id = ?", projectID, userID).Count(&models.ProjectMembership{})
 	return count > 0, err
 }
+
+// FindPageByProjectID returns a page of a project's memberships with their users
+func (r *ProjectMembershipRepository) FindPageByProjectID(projectID interface{}, after *PageCursor, limit int) (*models.ProjectMemberships, error) {
+	memberships := &models.ProjectMemberships{}
+	err := pageAfter(r.conn.Where("project_id = ?", projectID), "project_memberships", after, limit).Eager("User").All(memberships)
+	return memberships, err
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// RecoveryCodeRepository handles two-factor recovery code database operations
+type RecoveryCodeRepository struct {
+	*BaseRepository
+}
+
+// NewRecoveryCodeRepository creates a new recovery code repository
+func NewRecoveryCodeRepository(conn *pop.Connection) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindUnusedByUserIDAndHash finds a recovery code of a user that has not been redeemed yet
+func (r *RecoveryCodeRepository) FindUnusedByUserIDAndHash(userID interface{}, codeHash string) (*models.RecoveryCode, error) {
+	code := &models.RecoveryCode{}
+	err := r.conn.Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).First(code)
+	return code, err
+}
+
+// CountUnusedByUserID counts the recovery codes a user can still redeem
+func (r *RecoveryCodeRepository) CountUnusedByUserID(userID interface{}) (int, error) {
+	return r.conn.Where("user_id = ? AND used_at IS NULL", userID).Count(&models.RecoveryCode{})
+}
+
+// DeleteByUserID removes every recovery code of a user
+func (r *RecoveryCodeRepository) DeleteByUserID(userID interface{}) error {
+	return r.conn.RawQuery("DELETE FROM recovery_codes WHERE user_id = ?", userID).Exec()
+}
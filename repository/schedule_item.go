@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// ScheduleItemRepository handles schedule item database operations
+type ScheduleItemRepository struct {
+	*BaseRepository
+}
+
+// NewScheduleItemRepository creates a new schedule item repository
+func NewScheduleItemRepository(conn *pop.Connection) *ScheduleItemRepository {
+	return &ScheduleItemRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByID finds a schedule item by ID
+func (r *ScheduleItemRepository) FindByID(id interface{}) (*models.ScheduleItem, error) {
+	item := &models.ScheduleItem{}
+	err := r.conn.Find(item, id)
+	return item, err
+}
+
+// FindByHackathonID finds the schedule items of a hackathon in chronological order
+func (r *ScheduleItemRepository) FindByHackathonID(hackathonID interface{}) (*models.ScheduleItems, error) {
+	items := &models.ScheduleItems{}
+	err := r.conn.Where("hackathon_id = ?", hackathonID).Order("starts_at asc, position asc").All(items)
+	return items, err
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// SCIMTokenRepository handles SCIM provisioning token database operations
+type SCIMTokenRepository struct {
+	*BaseRepository
+}
+
+// NewSCIMTokenRepository creates a new SCIM token repository
+func NewSCIMTokenRepository(conn *pop.Connection) *SCIMTokenRepository {
+	return &SCIMTokenRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindAll returns every SCIM token, newest first
+func (r *SCIMTokenRepository) FindAll() (*models.SCIMTokens, error) {
+	tokens := &models.SCIMTokens{}
+	err := r.conn.Order("created_at desc").All(tokens)
+	return tokens, err
+}
+
+// FindByTokenHash finds a SCIM token by the hash of its bearer token
+func (r *SCIMTokenRepository) FindByTokenHash(tokenHash string) (*models.SCIMToken, error) {
+	token := &models.SCIMToken{}
+	err := r.conn.Where("token_hash = ?", tokenHash).First(token)
+	return token, err
+}
+
+// Touch records that a SCIM token was just used
+func (r *SCIMTokenRepository) Touch(id interface{}, at time.Time) error {
+	return r.conn.RawQuery("UPDATE scim_tokens SET last_used_at = ? WHERE id = ?", at, id).Exec()
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// TeamRequestRepository handles join request and project invitation database operations
+type TeamRequestRepository struct {
+	*BaseRepository
+}
+
+// NewTeamRequestRepository creates a new team request repository
+func NewTeamRequestRepository(conn *pop.Connection) *TeamRequestRepository {
+	return &TeamRequestRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindJoinRequestByID finds a join request by ID
+func (r *TeamRequestRepository) FindJoinRequestByID(id interface{}) (*models.JoinRequest, error) {
+	request := &models.JoinRequest{}
+	err := r.conn.Find(request, id)
+	return request, err
+}
+
+// FindPendingJoinRequestsByProjectID finds the undecided join requests of a project with requester data
+func (r *TeamRequestRepository) FindPendingJoinRequestsByProjectID(projectID interface{}) (*models.JoinRequests, error) {
+	requests := &models.JoinRequests{}
+	err := r.conn.Where("project_id = ? AND status = ?", projectID, models.TeamRequestPending).Order("created_at asc").Eager("User").All(requests)
+	return requests, err
+}
+
+// HasPendingJoinRequest checks if a user is waiting for an answer to join a project
+func (r *TeamRequestRepository) HasPendingJoinRequest(projectID, userID interface{}) (bool, error) {
+	return r.conn.Where("project_id = ? AND user_id = ? AND status = ?", projectID, userID, models.TeamRequestPending).Exists(&models.JoinRequest{})
+}
+
+// FindInvitationByID finds a project invitation by ID
+func (r *TeamRequestRepository) FindInvitationByID(id interface{}) (*models.ProjectInvitation, error) {
+	invitation := &models.ProjectInvitation{}
+	err := r.conn.Find(invitation, id)
+	return invitation, err
+}
+
+// FindPendingInvitationsByProjectID finds the unanswered invitations of a project
+func (r *TeamRequestRepository) FindPendingInvitationsByProjectID(projectID interface{}) (*models.ProjectInvitations, error) {
+	invitations := &models.ProjectInvitations{}
+	err := r.conn.Where("project_id = ? AND status = ?", projectID, models.TeamRequestPending).Order("created_at asc").All(invitations)
+	return invitations, err
+}
+
+// FindPendingInvitationsByEmail finds the unanswered invitations sent to an email address with project data
+func (r *TeamRequestRepository) FindPendingInvitationsByEmail(email string) (*models.ProjectInvitations, error) {
+	invitations := &models.ProjectInvitations{}
+	err := r.conn.Where("LOWER(email) = LOWER(?) AND status = ?", email, models.TeamRequestPending).Order("created_at desc").Eager("Project").All(invitations)
+	return invitations, err
+}
@@ -1,8 +1,11 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/arxdsilva/hackathon/models"
 	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
 )
 
 // UserRepository handles user-related database operations
@@ -31,6 +34,27 @@ func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
 	return user, err
 }
 
+// FindByOIDCSubject finds a user by the subject of their single sign-on identity
+func (r *UserRepository) FindByOIDCSubject(subject string) (*models.User, error) {
+	user := &models.User{}
+	err := r.conn.Where("oidc_subject = ?", subject).First(user)
+	return user, err
+}
+
+// FindByLDAPDN finds a user by the DN of their directory entry
+func (r *UserRepository) FindByLDAPDN(dn string) (*models.User, error) {
+	user := &models.User{}
+	err := r.conn.Where("ldap_dn = ?", dn).First(user)
+	return user, err
+}
+
+// FindBySCIMExternalID finds a user by the ID their identity provider provisioned them with
+func (r *UserRepository) FindBySCIMExternalID(externalID string) (*models.User, error) {
+	user := &models.User{}
+	err := r.conn.Where("scim_external_id = ?", externalID).First(user)
+	return user, err
+}
+
 // FindByID finds a user by ID
 func (r *UserRepository) FindByID(id interface{}) (*models.User, error) {
 	user := &models.User{}
@@ -38,6 +62,14 @@ func (r *UserRepository) FindByID(id interface{}) (*models.User, error) {
 	return user, err
 }
 
+// LockByID finds a user by ID and locks its row until the surrounding transaction ends,
+// serializing concurrent changes to rows that belong to the user
+func (r *UserRepository) LockByID(id interface{}) (*models.User, error) {
+	user := &models.User{}
+	err := r.conn.RawQuery("SELECT * FROM users WHERE id = ? FOR UPDATE", id).First(user)
+	return user, err
+}
+
 // FindByIDs finds multiple users by their IDs
 func (r *UserRepository) FindByIDs(ids []interface{}) (*models.Users, error) {
 	users := &models.Users{}
@@ -51,3 +83,51 @@ func (r *UserRepository) GetRecent(limit int) (*models.Users, error) {
 	err := r.conn.Order("created_at DESC").Limit(limit).All(users)
 	return users, err
 }
+
+// FindPage returns users in the order they were created, skipping offset and returning at most
+// limit of them, along with the total number of users
+func (r *UserRepository) FindPage(offset, limit int) (*models.Users, int, error) {
+	users := &models.Users{}
+	total, err := r.conn.Count(&models.User{})
+	if err != nil {
+		return users, 0, err
+	}
+	err = r.conn.RawQuery("SELECT * FROM users ORDER BY created_at ASC, id ASC OFFSET ? LIMIT ?", offset, limit).All(users)
+	return users, total, err
+}
+
+// FindByRole finds all users with the given role
+func (r *UserRepository) FindByRole(role string) (*models.Users, error) {
+	users := &models.Users{}
+	err := r.conn.Where("role = ?", role).Order("name asc").All(users)
+	return users, err
+}
+
+// FindByCalendarToken finds the user owning a personal calendar feed token
+func (r *UserRepository) FindByCalendarToken(token string) (*models.User, error) {
+	user := &models.User{}
+	err := r.conn.Where("calendar_token = ?", token).First(user)
+	return user, err
+}
+
+// FindServiceAccounts returns the service accounts, ordered by name
+func (r *UserRepository) FindServiceAccounts() (*models.Users, error) {
+	users := &models.Users{}
+	err := r.conn.Where("is_service_account = ?", true).Order("name asc").All(users)
+	return users, err
+}
+
+// Create validates and creates a user, hashing its password
+func (r *UserRepository) Create(user *models.User) (*validate.Errors, error) {
+	return user.Create(r.conn)
+}
+
+// SetRole changes the role of a user
+func (r *UserRepository) SetRole(id interface{}, role string) error {
+	return r.conn.RawQuery("UPDATE users SET role = ?, updated_at = ? WHERE id = ?", role, time.Now(), id).Exec()
+}
+
+// SetForcePasswordReset makes a user pick a new password at the next sign-in, or stops asking them to
+func (r *UserRepository) SetForcePasswordReset(id interface{}, force bool) error {
+	return r.conn.RawQuery("UPDATE users SET force_password_reset = ?, updated_at = ? WHERE id = ?", force, time.Now(), id).Exec()
+}
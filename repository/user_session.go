@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// UserSessionRepository handles server-side session database operations
+type UserSessionRepository struct {
+	*BaseRepository
+}
+
+// NewUserSessionRepository creates a new user session repository
+func NewUserSessionRepository(conn *pop.Connection) *UserSessionRepository {
+	return &UserSessionRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByID finds a session by ID
+func (r *UserSessionRepository) FindByID(id interface{}) (*models.UserSession, error) {
+	session := &models.UserSession{}
+	err := r.conn.Find(session, id)
+	return session, err
+}
+
+// FindByUserID finds the sessions of a user, most recently used first
+func (r *UserSessionRepository) FindByUserID(userID interface{}) (*models.UserSessions, error) {
+	sessions := &models.UserSessions{}
+	err := r.conn.Where("user_id = ?", userID).Order("last_seen_at desc").All(sessions)
+	return sessions, err
+}
+
+// CountByUserID counts the sessions of a user
+func (r *UserSessionRepository) CountByUserID(userID interface{}) (int, error) {
+	return r.conn.Where("user_id = ?", userID).Count(&models.UserSession{})
+}
+
+// DeleteByUserID signs a user out of every session
+func (r *UserSessionRepository) DeleteByUserID(userID interface{}) error {
+	return r.conn.RawQuery("DELETE FROM user_sessions WHERE user_id = ?", userID).Exec()
+}
+
+// DeleteExpired removes sessions last used before idleBefore or started before createdBefore
+func (r *UserSessionRepository) DeleteExpired(idleBefore, createdBefore time.Time) (int, error) {
+	return r.conn.RawQuery("DELETE FROM user_sessions WHERE last_seen_at < ? OR created_at < ?", idleBefore, createdBefore).ExecWithCount()
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/arxdsilva/hackathon/models"
+	"github.com/gobuffalo/pop/v6"
+)
+
+// VoteRepository handles people's-choice vote database operations
+type VoteRepository struct {
+	*BaseRepository
+}
+
+// NewVoteRepository creates a new vote repository
+func NewVoteRepository(conn *pop.Connection) *VoteRepository {
+	return &VoteRepository{
+		BaseRepository: NewBaseRepository(conn),
+	}
+}
+
+// FindByHackathonIDAndUserID finds the votes a user cast in a hackathon
+func (r *VoteRepository) FindByHackathonIDAndUserID(hackathonID, userID interface{}) (*models.Votes, error) {
+	votes := &models.Votes{}
+	err := r.conn.Where("hackathon_id = ? AND user_id = ?", hackathonID, userID).All(votes)
+	return votes, err
+}
+
+// FindByProjectIDAndUserID finds the vote a user cast for a project
+func (r *VoteRepository) FindByProjectIDAndUserID(projectID, userID interface{}) (*models.Vote, error) {
+	vote := &models.Vote{}
+	err := r.conn.Where("project_id = ? AND user_id = ?", projectID, userID).First(vote)
+	return vote, err
+}
+
+// CountByHackathonIDAndUserID returns the number of votes a user cast in a hackathon
+func (r *VoteRepository) CountByHackathonIDAndUserID(hackathonID, userID interface{}) (int, error) {
+	count, err := r.conn.Where("hackathon_id = ? AND user_id = ?", hackathonID, userID).Count(&models.Vote{})
+	return count, err
+}
+
+// TallyByHackathonID returns the number of votes per project of a hackathon, most voted first
+func (r *VoteRepository) TallyByHackathonID(hackathonID interface{}) (*models.VoteTallies, error) {
+	tallies := &models.VoteTallies{}
+	err := r.conn.RawQuery("SELECT project_id, COUNT(*) AS votes FROM votes WHERE hackathon_id = ? GROUP BY project_id ORDER BY votes DESC", hackathonID).All(tallies)
+	return tallies, err
+}
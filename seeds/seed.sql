@@ -1,12 +1,12 @@
 -- Seed data for hackathon after migrations
 
 -- Insert users - password hashed for 'password'
-INSERT INTO users (id, created_at, updated_at, name, email, company_team, role, password_hash, force_password_reset) VALUES
-('550e8400-e29b-41d4-a716-446655440000', NOW(), NOW(), 'Admin Owner', 'admin@example.com', 'Platform', 'owner', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false),
-('550e8400-e29b-41d4-a716-446655440001', NOW(), NOW(), 'John Developer', 'john@example.com', 'Developer Experience', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false),
-('550e8400-e29b-41d4-a716-446655440002', NOW(), NOW(), 'Jane Designer', 'jane@example.com', 'Design', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false),
-('550e8400-e29b-41d4-a716-446655440003', NOW(), NOW(), 'Bob Engineer', 'bob@example.com', 'Infra', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false),
-('550e8400-e29b-41d4-a716-446655440004', NOW(), NOW(), 'Alice Analyst', 'alice@example.com', '', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false);
+INSERT INTO users (id, created_at, updated_at, name, email, company_team, role, password_hash, force_password_reset, email_verified_at) VALUES
+('550e8400-e29b-41d4-a716-446655440000', NOW(), NOW(), 'Admin Owner', 'admin@example.com', 'Platform', 'owner', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false, NOW()),
+('550e8400-e29b-41d4-a716-446655440001', NOW(), NOW(), 'John Developer', 'john@example.com', 'Developer Experience', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false, NOW()),
+('550e8400-e29b-41d4-a716-446655440002', NOW(), NOW(), 'Jane Designer', 'jane@example.com', 'Design', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false, NOW()),
+('550e8400-e29b-41d4-a716-446655440003', NOW(), NOW(), 'Bob Engineer', 'bob@example.com', 'Infra', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false, NOW()),
+('550e8400-e29b-41d4-a716-446655440004', NOW(), NOW(), 'Alice Analyst', 'alice@example.com', '', 'hacker', '$2a$10$izUpv9c8SjPd2zLsIMaAmOtQdxOYE5QKPcyZjexgkn441Cmf22Wk6', false, NOW());
 
 INSERT INTO hackathons (id, created_at, updated_at, title, description, start_date, end_date, owner_id, status, schedule) VALUES
 ('HACK25WINTER', NOW(), NOW(), 'Winter Code Fest 2025', 'A month-long coding competition focusing on innovative solutions for real-world problems. Teams will build applications using modern technologies.', '2025-12-27 09:00:00', '2026-01-27 17:00:00', '550e8400-e29b-41d4-a716-446655440000', 'active', 'Day 1: Kickoff\nWeek 1-3: Build\nFinale: Demos & awards'),